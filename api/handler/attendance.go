@@ -0,0 +1,414 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/rbac"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// ClockIn godoc
+// @Router       /attendance/clock-in [POST]
+// @Summary      Clock in
+// @Description  start a shift of the acting staff in the branch
+// @Tags         attendance
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 clock body models.ClockRequest true "clock"
+// @Success      201  {object}  models.Attendance
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ClockIn(c *gin.Context) {
+	request := models.ClockRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !checkBranch(c, request.BranchID) {
+		return
+	}
+
+	staffID := actingStaff(c).ID
+
+	if _, err := h.storage.Attendance().Open(context.Background(), staffID); err == nil {
+		handleResponse(c, "error while clocking in", http.StatusBadRequest, "staff is already clocked in")
+		return
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		handleResponse(c, "error while getting open attendance", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := h.storage.Attendance().ClockIn(context.Background(), staffID, request.BranchID, time.Now())
+	if err != nil {
+		handleResponse(c, "error while clocking in", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	attendance, err := h.storage.Attendance().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting attendance by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, attendance)
+}
+
+// ClockOut godoc
+// @Router       /attendance/clock-out [POST]
+// @Summary      Clock out
+// @Description  end the open shift of the acting staff, the branch has to be the one the shift started in
+// @Tags         attendance
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 clock body models.ClockRequest true "clock"
+// @Success      200  {object}  models.Attendance
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ClockOut(c *gin.Context) {
+	request := models.ClockRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attendance, ok := h.openAttendance(c)
+	if !ok {
+		return
+	}
+
+	if attendance.BranchID != request.BranchID {
+		handleResponse(c, "error while clocking out", http.StatusBadRequest, "staff is clocked in at another branch")
+		return
+	}
+
+	if err := h.storage.Attendance().ClockOut(context.Background(), attendance.ID, time.Now()); err != nil {
+		handleResponse(c, "error while clocking out", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	closedAttendance, err := h.storage.Attendance().GetByID(context.Background(), models.PrimaryKey{ID: attendance.ID})
+	if err != nil {
+		handleResponse(c, "error while getting attendance by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, closedAttendance)
+}
+
+// StartBreak godoc
+// @Router       /attendance/break-start [POST]
+// @Summary      Start break
+// @Description  start a break in the open shift of the acting staff
+// @Tags         attendance
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.Attendance
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) StartBreak(c *gin.Context) {
+	attendance, ok := h.openAttendance(c)
+	if !ok {
+		return
+	}
+
+	for _, b := range attendance.Breaks {
+		if b.EndedAt == nil {
+			handleResponse(c, "error while starting break", http.StatusBadRequest, "staff is already on a break")
+			return
+		}
+	}
+
+	if err := h.storage.Attendance().StartBreak(context.Background(), attendance.ID, time.Now()); err != nil {
+		handleResponse(c, "error while starting break", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondAttendance(c, attendance.ID)
+}
+
+// EndBreak godoc
+// @Router       /attendance/break-end [POST]
+// @Summary      End break
+// @Description  end the running break of the acting staff
+// @Tags         attendance
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.Attendance
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) EndBreak(c *gin.Context) {
+	attendance, ok := h.openAttendance(c)
+	if !ok {
+		return
+	}
+
+	if err := h.storage.Attendance().EndBreak(context.Background(), attendance.ID, time.Now()); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while ending break", http.StatusBadRequest, "staff is not on a break")
+			return
+		}
+		handleResponse(c, "error while ending break", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondAttendance(c, attendance.ID)
+}
+
+// GetAttendance godoc
+// @Router       /attendance/{id} [GET]
+// @Summary      Get attendance by id
+// @Description  get attendance by id
+// @Tags         attendance
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "attendance_id"
+// @Success      200  {object}  models.Attendance
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetAttendance(c *gin.Context) {
+	attendance, err := h.storage.Attendance().GetByID(context.Background(), models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		handleResponse(c, "error while getting attendance by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.checkStaffAccess(c, attendance.StaffID, true) {
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, attendance)
+}
+
+// GetAttendanceList godoc
+// @Router       /attendances [GET]
+// @Summary      Get attendance list
+// @Description  get attendances by staff, branch, status and clock in date, managers see their branch
+// @Tags         attendance
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 staff_id query string false "staff_id"
+// @Param 		 branch_id query string false "branch_id"
+// @Param 		 status query string false "open, closed or auto_closed"
+// @Param 		 from query string false "first day in YYYY-MM-DD format"
+// @Param 		 to query string false "last day in YYYY-MM-DD format"
+// @Success      200  {object}  models.AttendancesResponse
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetAttendanceList(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, to, err := parsePeriod(c)
+	if err != nil {
+		handleResponse(c, "error while parsing period", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	branchID := c.Query("branch_id")
+	if acting := actingStaff(c); branchID == "" && !rbac.AllBranches(acting.StaffType) {
+		branchID = acting.BranchID
+	}
+
+	if !checkBranch(c, branchID) {
+		return
+	}
+
+	response, err := h.storage.Attendance().GetList(context.Background(), models.AttendanceGetListRequest{
+		Page:     page,
+		Limit:    limit,
+		StaffID:  c.Query("staff_id"),
+		BranchID: branchID,
+		Status:   c.Query("status"),
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		handleResponse(c, "error while getting attendance list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// UpdateAttendance godoc
+// @Router       /attendance/{id} [PUT]
+// @Summary      Correct attendance
+// @Description  set the clock in and clock out of a shift, used to fix forgotten or auto closed clock outs
+// @Tags         attendance
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "attendance_id"
+// @Param 		 attendance body models.UpdateAttendance true "attendance"
+// @Success      200  {object}  models.Attendance
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) UpdateAttendance(c *gin.Context) {
+	uid := c.Param("id")
+
+	request := models.UpdateAttendance{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+	request.ID = uid
+
+	if !request.ClockOut.After(request.ClockIn) {
+		handleResponse(c, "error while checking attendance", http.StatusBadRequest, "clock_out should be after clock_in")
+		return
+	}
+
+	attendance, err := h.storage.Attendance().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting attendance by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !h.checkStaffAccess(c, attendance.StaffID, false) {
+		return
+	}
+
+	if err = h.storage.Attendance().Update(context.Background(), request); err != nil {
+		handleResponse(c, "error while updating attendance", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondAttendance(c, uid)
+}
+
+// GetStaffTimesheet godoc
+// @Router       /staff/{id}/timesheet [GET]
+// @Summary      Get staff timesheet
+// @Description  sum the closed shifts of the staff by day, the current month by default
+// @Tags         attendance
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "staff_id"
+// @Param 		 from query string false "first day in YYYY-MM-DD format"
+// @Param 		 to query string false "last day in YYYY-MM-DD format"
+// @Success      200  {object}  models.Timesheet
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetStaffTimesheet(c *gin.Context) {
+	uid := c.Param("id")
+
+	if !h.checkStaffAccess(c, uid, true) {
+		return
+	}
+
+	from, to, err := parsePeriod(c)
+	if err != nil {
+		handleResponse(c, "error while parsing period", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if from.IsZero() {
+		day := time.Now()
+		if !to.IsZero() {
+			day = to.AddDate(0, 0, -1)
+		}
+		from = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	if to.IsZero() {
+		to = time.Date(from.Year(), from.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	timesheet, err := h.storage.Attendance().Timesheet(context.Background(), uid, from, to)
+	if err != nil {
+		handleResponse(c, "error while getting timesheet", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// the period is shown with its last day
+	timesheet.To = to.AddDate(0, 0, -1).Format("2006-01-02")
+
+	handleResponse(c, "", http.StatusOK, timesheet)
+}
+
+// openAttendance returns the open attendance of the acting staff, it answers 400 when there is none.
+func (h Handler) openAttendance(c *gin.Context) (models.Attendance, bool) {
+	attendance, err := h.storage.Attendance().Open(context.Background(), actingStaff(c).ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while getting open attendance", http.StatusBadRequest, "staff is not clocked in")
+			return models.Attendance{}, false
+		}
+		handleResponse(c, "error while getting open attendance", http.StatusInternalServerError, err.Error())
+		return models.Attendance{}, false
+	}
+
+	return attendance, true
+}
+
+func (h Handler) respondAttendance(c *gin.Context, id string) {
+	attendance, err := h.storage.Attendance().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting attendance by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, attendance)
+}
+
+// parsePeriod reads the from and to days of the query. The to day is included,
+// so the returned to is the start of the next day. Missing days are zero.
+func parsePeriod(c *gin.Context) (time.Time, time.Time, error) {
+	var from, to time.Time
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		day, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = day
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		day, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = day.AddDate(0, 0, 1)
+	}
+
+	if !from.IsZero() && !to.IsZero() && !to.After(from) {
+		return time.Time{}, time.Time{}, errors.New("to should not be before from")
+	}
+
+	return from, to, nil
+}
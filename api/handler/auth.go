@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/security"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const actingStaffKey = "acting_staff"
+
+// Login godoc
+// @Router       /auth/login [POST]
+// @Summary      Log in
+// @Description  log in with the staff login and password, returns an access and a refresh token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param 		 login body models.LoginRequest true "login"
+// @Success      200  {object}  models.TokenResponse
+// @Failure      400  {object}  models.Response
+// @Failure      401  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      423  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) Login(c *gin.Context) {
+	login := models.LoginRequest{}
+
+	if err := c.ShouldBindJSON(&login); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	credentials, err := h.storage.Staff().GetByLogin(context.Background(), login.Login)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		handleResponse(c, "error while getting staff by login", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err != nil {
+		security.CompareDummyPassword(login.Password)
+		handleResponse(c, "login or password is not correct", http.StatusUnauthorized, "login or password is not correct")
+		return
+	}
+
+	if !h.checkPassword(c, credentials, login.Password, http.StatusUnauthorized, "login or password is not correct") {
+		return
+	}
+
+	if credentials.MustChangePassword {
+		handleResponse(c, "password change is required", http.StatusForbidden, "password was reset, set a new one with the reset token")
+		return
+	}
+
+	tokens, err := h.issueTokens(context.Background(), credentials.ID)
+	if err != nil {
+		handleResponse(c, "error while issuing tokens", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, tokens)
+}
+
+// RefreshToken godoc
+// @Router       /auth/refresh [POST]
+// @Summary      Refresh tokens
+// @Description  exchange a refresh token for a new access and refresh token, the old refresh token stops working
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param 		 refresh body models.RefreshRequest true "refresh token"
+// @Success      200  {object}  models.TokenResponse
+// @Failure      400  {object}  models.Response
+// @Failure      401  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) RefreshToken(c *gin.Context) {
+	request := models.RefreshRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, err := h.storage.RefreshToken().GetByHash(context.Background(), security.HashToken(request.RefreshToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "refresh token is not valid", http.StatusUnauthorized, "refresh token is not valid")
+			return
+		}
+		handleResponse(c, "error while getting refresh token", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if token.RevokedAt != nil || time.Now().After(token.ExpiresAt) {
+		handleResponse(c, "refresh token is not valid", http.StatusUnauthorized, "refresh token is expired or revoked")
+		return
+	}
+
+	if err = h.storage.RefreshToken().Revoke(context.Background(), token.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "refresh token is not valid", http.StatusUnauthorized, "refresh token is already used")
+			return
+		}
+		handleResponse(c, "error while revoking refresh token", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	tokens, err := h.issueTokens(context.Background(), token.StaffID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "staff is not found", http.StatusUnauthorized, "staff is deleted")
+			return
+		}
+		handleResponse(c, "error while issuing tokens", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Router       /auth/logout [POST]
+// @Summary      Log out
+// @Description  revoke a refresh token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param 		 refresh body models.RefreshRequest true "refresh token"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) Logout(c *gin.Context) {
+	request := models.RefreshRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, err := h.storage.RefreshToken().GetByHash(context.Background(), security.HashToken(request.RefreshToken))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		handleResponse(c, "error while getting refresh token", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err == nil && token.RevokedAt == nil {
+		if err = h.storage.RefreshToken().Revoke(context.Background(), token.ID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while revoking refresh token", http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	handleResponse(c, "", http.StatusOK, "logged out")
+}
+
+// GetMe godoc
+// @Router       /auth/me [GET]
+// @Summary      Get the acting staff
+// @Description  get the staff the access token belongs to
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  models.Staff
+// @Failure      401  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetMe(c *gin.Context) {
+	staff, err := h.storage.Staff().StaffByID(context.Background(), models.PrimaryKey{ID: actingStaff(c).ID})
+	if err != nil {
+		handleResponse(c, "error while getting staff by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, staff)
+}
+
+// AuthMiddleware checks the bearer access token and keeps the acting staff in the context.
+func (h Handler) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == "" {
+			handleResponse(c, "access token is missing", http.StatusUnauthorized, "authorization header should be Bearer <access token>")
+			c.Abort()
+			return
+		}
+
+		claims, err := security.ParseAccessToken(token, h.cfg.JWTSecret)
+		if err != nil {
+			handleResponse(c, "access token is not valid", http.StatusUnauthorized, err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Set(actingStaffKey, models.ActingStaff{
+			ID:        claims.StaffID,
+			BranchID:  claims.BranchID,
+			StaffType: claims.StaffType,
+		})
+		c.Next()
+	}
+}
+
+// actingStaff returns the staff AuthMiddleware put in the context.
+func actingStaff(c *gin.Context) models.ActingStaff {
+	staff, _ := c.MustGet(actingStaffKey).(models.ActingStaff)
+	return staff
+}
+
+// issueTokens signs an access token for the staff and stores a new refresh token.
+func (h Handler) issueTokens(ctx context.Context, staffID string) (models.TokenResponse, error) {
+	staff, err := h.storage.Staff().StaffByID(ctx, models.PrimaryKey{ID: staffID})
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	accessToken, err := security.NewAccessToken(security.Claims{
+		StaffID:   staff.ID,
+		StaffType: staff.StaffType,
+		BranchID:  staff.BranchID,
+	}, h.cfg.JWTSecret, h.cfg.AccessTokenTTL)
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	refreshToken, err := security.NewToken()
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	if _, err = h.storage.RefreshToken().Create(ctx, models.CreateRefreshToken{
+		StaffID:   staff.ID,
+		TokenHash: security.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(h.cfg.RefreshTokenTTL),
+	}); err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	return models.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(h.cfg.AccessTokenTTL.Seconds()),
+		Staff:        staff,
+	}, nil
+}
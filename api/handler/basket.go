@@ -2,8 +2,12 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"math"
 	"net/http"
 	"sell/api/models"
+	"sell/pkg/check"
+	"sell/storage"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -14,60 +18,157 @@ import (
 // @Summary      Create a new basket
 // @Description  create a new basket
 // @Tags         basket
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 basket body models.CreateBasket false "basket"
 // @Success      200  {object}  models.Basket
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) CreateBasket(c *gin.Context) {
 	basket := models.CreateBasket{}
-	var id string
-	var err error
 
 	if err := c.ShouldBindJSON(&basket); err != nil {
 		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
 		return
 	}
 
-	product, err := h.storage.Product().GetByID(context.Background(), basket.ProductID)
+	if !h.checkSaleBranch(c, basket.SaleID) {
+		return
+	}
+
+	// the price is always calculated, only scanned price labels set it
+	basket.Price = 0
+
+	responseBasket, err := h.addToBasket(basket)
 	if err != nil {
-		handleResponse(c, "error is while getting product by id", http.StatusInternalServerError, err.Error())
+		handleBasketError(c, err)
+		return
 	}
 
-	baskets, err := h.storage.Basket().GetList(context.Background(), models.GetListRequest{
-		Page:   1,
-		Limit:  10,
-		Search: basket.SaleID,
-	})
+	handleResponse(c, "", http.StatusCreated, responseBasket)
+}
+
+// basketError is a failed basket change with the status it is answered with.
+type basketError struct {
+	status  int
+	message string
+	err     error
+}
+
+func (e basketError) Error() string {
+	return e.err.Error()
+}
+
+func basketFail(status int, message string, err error) (models.Basket, error) {
+	return models.Basket{}, basketError{status: status, message: message, err: err}
+}
 
+func handleBasketError(c *gin.Context, err error) {
+	var basketErr basketError
+	if errors.As(err, &basketErr) {
+		handleResponse(c, basketErr.message, basketErr.status, basketErr.Error())
+		return
+	}
+
+	handleResponse(c, "error while creating basket", http.StatusInternalServerError, err.Error())
+}
+
+// addToBasket adds the product to the sale basket, merging it with the same
+// product and variant already there. The price comes from the branch price
+// list, a bundle or a variant; a non zero basket.Price is taken as the total
+// price instead, as printed on scale labels.
+func (h Handler) addToBasket(basket models.CreateBasket) (models.Basket, error) {
+	var id string
+
+	product, err := h.storage.Product().GetByID(context.Background(), basket.ProductID)
 	if err != nil {
-		handleResponse(c, "error is while getting product by id", http.StatusInternalServerError, err.Error())
+		return basketFail(http.StatusInternalServerError, "error is while getting product by id", err)
 	}
 
-	repo, err := h.storage.Repository().GetList(context.Background(), models.GetListRequest{
-		Page:   1,
-		Limit:  10,
-		Search: basket.ProductID,
-	})
+	sale, err := h.storage.Sale().GetByID(context.Background(), basket.SaleID)
 	if err != nil {
-		handleResponse(c, "error while getting repo", http.StatusInternalServerError, err.Error())
-		return
+		return basketFail(http.StatusInternalServerError, "error is while getting sale by id", err)
+	}
+
+	bundle, err := h.storage.Bundle().GetByProductID(context.Background(), basket.ProductID)
+	if err != nil {
+		return basketFail(http.StatusInternalServerError, "error is while getting bundle by product id", err)
 	}
 
-	var repoQuantity int
+	if bundle.ID != "" && basket.VariantID != "" {
+		return basketFail(http.StatusBadRequest, "error is while checking variant", errors.New("bundle can not have variants"))
+	}
+
+	if product.Price, err = h.branchPrice(context.Background(), sale.BranchID, product); err != nil {
+		return basketFail(http.StatusInternalServerError, "error is while getting branch price", err)
+	}
 
-	for _, repository := range repo.Repositories {
-		repoQuantity += repository.Count
+	price := float64(product.Price)
+	if bundle.ID != "" {
+		if price, err = h.bundlePrice(context.Background(), bundle, product, sale.BranchID); err != nil {
+			return basketFail(http.StatusInternalServerError, "error is while calculating bundle price", err)
+		}
 	}
 
-	totalSum := product.Price * basket.Quantity
+	if basket.VariantID != "" {
+		variant, err := h.storage.ProductVariant().GetByID(context.Background(), models.PrimaryKey{ID: basket.VariantID})
+		if err != nil {
+			return basketFail(http.StatusInternalServerError, "error is while getting product variant by id", err)
+		}
+
+		if variant.ProductID != basket.ProductID {
+			return basketFail(http.StatusBadRequest, "error is while checking variant", errors.New("variant does not belong to product"))
+		}
+
+		if variant.Price != 0 {
+			price = float64(variant.Price)
+		}
+	}
+
+	if basket.UnitID != "" {
+		unit, err := h.storage.ProductUnit().GetByID(context.Background(), models.PrimaryKey{ID: basket.UnitID})
+		if err != nil {
+			return basketFail(http.StatusInternalServerError, "error is while getting product unit by id", err)
+		}
+
+		if unit.ProductID != basket.ProductID {
+			return basketFail(http.StatusBadRequest, "error is while converting unit", errors.New("unit does not belong to product"))
+		}
+
+		basket.Quantity *= unit.Factor
+	}
+
+	if err = check.ValidateQuantity(product.Unit, basket.Quantity); err != nil {
+		return basketFail(http.StatusBadRequest, "error is while validating quantity", err)
+	}
+
+	baskets, err := h.storage.Basket().GetBySaleID(context.Background(), basket.SaleID)
+	if err != nil {
+		return basketFail(http.StatusInternalServerError, "error is while getting baskets of the sale", err)
+	}
+
+	var repoQuantity float64
+	if bundle.ID != "" {
+		repoQuantity, err = h.bundleAvailable(context.Background(), bundle, sale.BranchID)
+	} else {
+		repoQuantity, err = h.storage.Repository().GetProductCount(context.Background(), sale.BranchID, basket.ProductID, basket.VariantID)
+	}
+	if err != nil {
+		return basketFail(http.StatusInternalServerError, "error while getting repo", err)
+	}
+
+	totalSum := math.Round(price*basket.Quantity*100) / 100
+	if basket.Price > 0 {
+		totalSum = math.Round(basket.Price*100) / 100
+	}
 	isTrue := true
 
-	for _, value := range baskets.Baskets {
+	for _, value := range baskets {
 
-		if basket.ProductID == value.ProductID {
+		if basket.ProductID == value.ProductID && basket.VariantID == value.VariantID {
 			isTrue = false
 			if repoQuantity >= basket.Quantity+value.Quantity {
 
@@ -75,19 +176,18 @@ func (h Handler) CreateBasket(c *gin.Context) {
 					ID:        value.ID,
 					SaleID:    value.SaleID,
 					ProductID: value.ProductID,
+					VariantID: value.VariantID,
 					Quantity:  basket.Quantity + value.Quantity,
 					Price:     value.Price + totalSum,
 				})
 
 				if err != nil {
-					handleResponse(c, "error while creating basket", http.StatusInternalServerError, err.Error())
-					return
+					return basketFail(http.StatusInternalServerError, "error while creating basket", err)
 				}
 
 			} else {
 
-				handleResponse(c, "error while creating basket", http.StatusNoContent, "not enough product")
-				return
+				return basketFail(http.StatusBadRequest, "error while creating basket", storage.ErrNotEnoughStock)
 
 			}
 
@@ -102,18 +202,17 @@ func (h Handler) CreateBasket(c *gin.Context) {
 			id, err = h.storage.Basket().Create(context.Background(), models.CreateBasket{
 				SaleID:    basket.SaleID,
 				ProductID: basket.ProductID,
+				VariantID: basket.VariantID,
 				Quantity:  basket.Quantity,
 				Price:     totalSum,
 			})
 			if err != nil {
-				handleResponse(c, "error while creating basket", http.StatusInternalServerError, err.Error())
-				return
+				return basketFail(http.StatusInternalServerError, "error while creating basket", err)
 			}
 
 		} else {
 
-			handleResponse(c, "error while creating basket", http.StatusNoContent, "not enough product in storage")
-			return
+			return basketFail(http.StatusBadRequest, "error while creating basket", storage.ErrNotEnoughStock)
 
 		}
 
@@ -124,11 +223,10 @@ func (h Handler) CreateBasket(c *gin.Context) {
 	})
 
 	if err != nil {
-		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
-		return
+		return basketFail(http.StatusInternalServerError, "error while getting by ID", err)
 	}
 
-	handleResponse(c, "", http.StatusCreated, responseBasket)
+	return responseBasket, nil
 }
 
 // GetBasket godoc
@@ -136,6 +234,7 @@ func (h Handler) CreateBasket(c *gin.Context) {
 // @Summary      Get basket by id
 // @Description  get basket by id
 // @Tags         basket
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "basket_id"
@@ -160,6 +259,7 @@ func (h Handler) GetBasket(c *gin.Context) {
 // @Summary      Get basket list
 // @Description  get basket list
 // @Tags         basket
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 page query string false "page"
@@ -209,12 +309,14 @@ func (h Handler) GetBasketList(c *gin.Context) {
 // @Summary      Update basket
 // @Description  get basket
 // @Tags         basket
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "basket_id"
 // @Param 		 basket body models.UpdateBasket false "basket"
 // @Success      200  {object}  models.Basket
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateBasket(c *gin.Context) {
@@ -226,6 +328,10 @@ func (h Handler) UpdateBasket(c *gin.Context) {
 		return
 	}
 
+	if !h.checkBasketBranch(c, uid) || !h.checkSaleBranch(c, basket.SaleID) {
+		return
+	}
+
 	basket.ID = uid
 	if _, err := h.storage.Basket().Update(context.Background(), basket); err != nil {
 		handleResponse(c, "error while updating basket ", http.StatusInternalServerError, err.Error())
@@ -246,16 +352,22 @@ func (h Handler) UpdateBasket(c *gin.Context) {
 // @Summary      Delete basket
 // @Description  delete basket
 // @Tags         basket
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "basket_id"
 // @Success      200  {object}  models.Response
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteBasket(c *gin.Context) {
 	uid := c.Param("id")
 
+	if !h.checkBasketBranch(c, uid) {
+		return
+	}
+
 	if err := h.storage.Basket().Delete(context.Background(), uid); err != nil {
 		handleResponse(c, "error while deleting basket ", http.StatusInternalServerError, err.Error())
 		return
@@ -14,6 +14,7 @@ import (
 // @Summary      Create a new branch
 // @Description  create a new branch
 // @Tags         branch
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 branch body models.CreateBranch false "branch"
@@ -49,6 +50,7 @@ func (h Handler) CreateBranch(c *gin.Context) {
 // @Summary      Get branch by id
 // @Description  get branch by id
 // @Tags         branch
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "branch_id"
@@ -73,6 +75,7 @@ func (h Handler) GetBranch(c *gin.Context) {
 // @Summary      Get branch list
 // @Description  get branch list
 // @Tags         branch
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 page query string false "page"
@@ -119,6 +122,7 @@ func (h Handler) GetBranchList(c *gin.Context) {
 // @Summary      Update branch
 // @Description  update branch
 // @Tags         branch
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "branch_id"
@@ -157,6 +161,7 @@ func (h Handler) UpdateBranch(c *gin.Context) {
 // @Summary      Delete branch
 // @Description  delete branch
 // @Tags         branch
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "branch_id"
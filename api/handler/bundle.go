@@ -0,0 +1,319 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sell/api/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateBundle godoc
+// @Router       /bundle [POST]
+// @Summary      Create a new bundle
+// @Description  create a new bundle
+// @Tags         bundle
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 bundle body models.CreateBundle false "bundle"
+// @Success      200  {object}  models.Bundle
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateBundle(c *gin.Context) {
+	bundle := models.CreateBundle{}
+
+	if err := c.ShouldBindJSON(&bundle); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if bundle.PricingType == "" {
+		bundle.PricingType = "own"
+	}
+
+	if err := h.validateBundle(context.Background(), bundle.ProductID, bundle.PricingType, bundle.Discount, bundle.Items); err != nil {
+		handleResponse(c, "error while validating bundle", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.storage.Bundle().Create(context.Background(), bundle)
+	if err != nil {
+		handleResponse(c, "error while creating bundle", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdBundle, err := h.storage.Bundle().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdBundle)
+}
+
+// GetBundle godoc
+// @Router       /bundle/{id} [GET]
+// @Summary      Get bundle by id
+// @Description  get bundle by id
+// @Tags         bundle
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "bundle_id"
+// @Success      200  {object}  models.Bundle
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetBundle(c *gin.Context) {
+	uid := c.Param("id")
+
+	bundle, err := h.storage.Bundle().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting bundle by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, bundle)
+}
+
+// GetBundleList godoc
+// @Router       /bundles [GET]
+// @Summary      Get bundle list
+// @Description  get bundle list
+// @Tags         bundle
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Success      200  {object}  models.BundlesResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetBundleList(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := h.storage.Bundle().GetList(context.Background(), models.GetListRequest{
+		Page:  page,
+		Limit: limit,
+	})
+	if err != nil {
+		handleResponse(c, "error while getting bundle list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// UpdateBundle godoc
+// @Router       /bundle/{id} [PUT]
+// @Summary      Update bundle
+// @Description  update bundle
+// @Tags         bundle
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "bundle_id"
+// @Param 		 bundle body models.UpdateBundle false "bundle"
+// @Success      200  {object}  models.Bundle
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) UpdateBundle(c *gin.Context) {
+	uid := c.Param("id")
+
+	bundle := models.UpdateBundle{}
+	if err := c.ShouldBindJSON(&bundle); err != nil {
+		handleResponse(c, "error while reading from body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	existing, err := h.storage.Bundle().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting bundle by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if bundle.PricingType == "" {
+		bundle.PricingType = "own"
+	}
+
+	if err = h.validateBundle(context.Background(), existing.ProductID, bundle.PricingType, bundle.Discount, bundle.Items); err != nil {
+		handleResponse(c, "error while validating bundle", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bundle.ID = uid
+	if _, err = h.storage.Bundle().Update(context.Background(), bundle); err != nil {
+		handleResponse(c, "error while updating bundle ", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	updatedBundle, err := h.storage.Bundle().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, updatedBundle)
+}
+
+// DeleteBundle godoc
+// @Router       /bundle/{id} [DELETE]
+// @Summary      Delete bundle
+// @Description  delete bundle
+// @Tags         bundle
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "bundle_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteBundle(c *gin.Context) {
+	uid := c.Param("id")
+
+	if err := h.storage.Bundle().Delete(context.Background(), uid); err != nil {
+		handleResponse(c, "error while deleting bundle ", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "bundle deleted")
+}
+
+func (h Handler) validateBundle(ctx context.Context, productID, pricingType string, discount float64, items []models.BundleItem) error {
+	if pricingType != "own" && pricingType != "components" {
+		return errors.New("pricing type should be own or components")
+	}
+
+	if discount < 0 || discount > 100 {
+		return errors.New("discount should be between 0 and 100")
+	}
+
+	if len(items) == 0 {
+		return errors.New("bundle should have at least one item")
+	}
+
+	for _, item := range items {
+		if item.ProductID == productID {
+			return errors.New("bundle can not contain itself")
+		}
+
+		if item.Quantity <= 0 {
+			return errors.New("item quantity should be more than 0")
+		}
+
+		component, err := h.storage.Bundle().GetByProductID(ctx, item.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if component.ID != "" {
+			return errors.New("bundle can not contain another bundle")
+		}
+	}
+
+	return nil
+}
+
+// bundlePrice returns the price of one bundle in the branch.
+func (h Handler) bundlePrice(ctx context.Context, bundle models.Bundle, product models.Product, branchID string) (float64, error) {
+	if bundle.PricingType != "components" {
+		return float64(product.Price), nil
+	}
+
+	productIDs := make([]string, 0, len(bundle.Items))
+	for _, item := range bundle.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	prices, err := h.storage.PriceList().EffectivePrices(ctx, branchID, productIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0.0
+	for _, item := range bundle.Items {
+		sum += float64(prices[item.ProductID]) * item.Quantity
+	}
+
+	return math.Round(sum*(100-bundle.Discount)) / 100, nil
+}
+
+// bundleAvailable returns how many bundles can be assembled from the branch stock.
+func (h Handler) bundleAvailable(ctx context.Context, bundle models.Bundle, branchID string) (float64, error) {
+	available := math.Inf(1)
+	for _, item := range bundle.Items {
+		count, err := h.storage.Repository().GetProductCount(ctx, branchID, item.ProductID, "")
+		if err != nil {
+			return 0, err
+		}
+		available = math.Min(available, math.Floor(count/item.Quantity))
+	}
+
+	if math.IsInf(available, 1) {
+		return 0, nil
+	}
+
+	return available, nil
+}
+
+// bundleMovements splits a sold bundle into one stock movement per item.
+// The basket price is shared between the items by their value.
+func (h Handler) bundleMovements(ctx context.Context, bundle models.Bundle, basket models.Basket) ([]models.CreateRepositoryTransaction, error) {
+	var (
+		movements = make([]models.CreateRepositoryTransaction, 0, len(bundle.Items))
+		values    = make([]float64, 0, len(bundle.Items))
+		total     = 0.0
+	)
+
+	for _, item := range bundle.Items {
+		component, err := h.storage.Product().GetByID(ctx, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		value := float64(component.Price) * item.Quantity
+		values = append(values, value)
+		total += value
+
+		movements = append(movements, models.CreateRepositoryTransaction{
+			ProductID:                 item.ProductID,
+			RepositoryTransactionType: "minus",
+			Quantity:                  item.Quantity * basket.Quantity,
+		})
+	}
+
+	for i := range movements {
+		if total == 0 {
+			movements[i].Price = basket.Price / float64(len(movements))
+			continue
+		}
+		movements[i].Price = math.Round(basket.Price*values[i]/total*100) / 100
+	}
+
+	return movements, nil
+}
@@ -2,9 +2,12 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"net/http"
 	"sell/api/models"
+	"sell/storage"
 	"strconv"
 )
 
@@ -13,6 +16,7 @@ import (
 // @Summary      Create a new category
 // @Description  create a new category
 // @Tags         category
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 category body models.CreateCategory false "category"
@@ -47,6 +51,7 @@ func (h Handler) CreateCategory(c *gin.Context) {
 // @Summary      Get category by id
 // @Description  get category by id
 // @Tags         category
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "category_id"
@@ -70,6 +75,7 @@ func (h Handler) GetCategory(c *gin.Context) {
 // @Summary      Get category list
 // @Description  get category list
 // @Tags         category
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 page query string false "page"
@@ -119,6 +125,7 @@ func (h Handler) GetCategoryList(c *gin.Context) {
 // @Summary      Update category
 // @Description  get category
 // @Tags         category
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "category_id"
@@ -140,7 +147,7 @@ func (h Handler) UpdateCategory(c *gin.Context) {
 
 	id, err := h.storage.Category().Update(context.Background(), category)
 	if err != nil {
-		handleResponse(c, "error is while updating category", http.StatusInternalServerError, err.Error())
+		categoryParentError(c, "error is while updating category", err)
 		return
 	}
 
@@ -158,6 +165,7 @@ func (h Handler) UpdateCategory(c *gin.Context) {
 // @Summary      Delete category
 // @Description  delete category
 // @Tags         category
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "category_id"
@@ -167,6 +175,18 @@ func (h Handler) UpdateCategory(c *gin.Context) {
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteCategory(c *gin.Context) {
 	uid := c.Param("id")
+
+	usage, err := h.storage.Category().GetUsage(context.Background(), uid)
+	if err != nil {
+		handleResponse(c, "error is while getting category usage", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if usage.Children > 0 || usage.Products > 0 {
+		handleResponse(c, "error is while deleting", http.StatusBadRequest, "category still has children or products")
+		return
+	}
+
 	if err := h.storage.Category().Delete(context.Background(), uid); err != nil {
 		handleResponse(c, "error is while deleting", http.StatusInternalServerError, err.Error())
 		return
@@ -174,3 +194,175 @@ func (h Handler) DeleteCategory(c *gin.Context) {
 
 	handleResponse(c, "", http.StatusOK, "category deleted!")
 }
+
+// GetCategoryTree godoc
+// @Router       /categories/tree [GET]
+// @Summary      Get category tree
+// @Description  get all categories as a tree
+// @Tags         category
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   models.CategoryNode
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetCategoryTree(c *gin.Context) {
+	tree, err := h.storage.Category().GetTree(context.Background(), "")
+	if err != nil {
+		handleResponse(c, "error is while getting tree", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, tree)
+}
+
+// GetCategorySubtree godoc
+// @Router       /category/{id}/subtree [GET]
+// @Summary      Get category subtree
+// @Description  get category with all of its descendants
+// @Tags         category
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "category_id"
+// @Success      200  {object}  models.CategoryNode
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetCategorySubtree(c *gin.Context) {
+	uid := c.Param("id")
+
+	tree, err := h.storage.Category().GetTree(context.Background(), uid)
+	if err != nil {
+		handleResponse(c, "error is while getting subtree", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if len(tree) == 0 {
+		handleResponse(c, "error is while getting subtree", http.StatusNotFound, "category not found")
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, tree[0])
+}
+
+// GetCategoryBreadcrumbs godoc
+// @Router       /category/{id}/breadcrumbs [GET]
+// @Summary      Get category breadcrumbs
+// @Description  get the path from the root category to the category
+// @Tags         category
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "category_id"
+// @Success      200  {array}   models.Category
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetCategoryBreadcrumbs(c *gin.Context) {
+	uid := c.Param("id")
+
+	breadcrumbs, err := h.storage.Category().GetBreadcrumbs(context.Background(), uid)
+	if err != nil {
+		handleResponse(c, "error is while getting breadcrumbs", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, breadcrumbs)
+}
+
+// GetCategoryProducts godoc
+// @Router       /category/{id}/products [GET]
+// @Summary      Get category products
+// @Description  get products of the category and all of its descendants
+// @Tags         category
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "category_id"
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Success      200  {object}  models.ProductResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetCategoryProducts(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	page, err = strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err = strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	products, err := h.storage.Product().GetList(context.Background(), models.ProductGetListRequest{
+		Page:       page,
+		Limit:      limit,
+		CategoryID: c.Param("id"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while getting products", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, products)
+}
+
+// MoveCategory godoc
+// @Router       /category/{id}/move [PUT]
+// @Summary      Move category
+// @Description  move category under another parent, empty parent makes it a root
+// @Tags         category
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "category_id"
+// @Param 		 category body models.MoveCategory true "category"
+// @Success      200  {object}  models.Category
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) MoveCategory(c *gin.Context) {
+	category := models.MoveCategory{}
+	if err := c.ShouldBindJSON(&category); err != nil {
+		handleResponse(c, "error is while reading from body", http.StatusBadRequest, err.Error())
+		return
+	}
+	category.ID = c.Param("id")
+
+	id, err := h.storage.Category().Move(context.Background(), category)
+	if err != nil {
+		categoryParentError(c, "error is while moving category", err)
+		return
+	}
+
+	movedCategory, err := h.storage.Category().GetByID(context.Background(), id)
+	if err != nil {
+		handleResponse(c, "error is while getting by id", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, movedCategory)
+}
+
+// categoryParentError answers a parent the category can not be put under with 400.
+func categoryParentError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		handleResponse(c, message, http.StatusBadRequest, "parent category not found")
+	case errors.Is(err, storage.ErrCategoryCycle):
+		handleResponse(c, message, http.StatusBadRequest, err.Error())
+	default:
+		handleResponse(c, message, http.StatusInternalServerError, err.Error())
+	}
+}
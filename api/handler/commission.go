@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/storage"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateCommissionRule godoc
+// @Router       /staff-tariff/{id}/rule [POST]
+// @Summary      Create commission rule
+// @Description  add a commission rule to the tariff for a category, a monthly sales volume tier and a date range
+// @Tags         staff-tariff
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "staff-tariff_id"
+// @Param 		 rule body models.CreateCommissionRule true "rule"
+// @Success      201  {object}  models.CommissionRule
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateCommissionRule(c *gin.Context) {
+	rule := models.CreateCommissionRule{}
+
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+	rule.TariffID = c.Param("id")
+
+	if !h.checkCommissionRule(c, rule) {
+		return
+	}
+
+	id, err := h.storage.Commission().Create(context.Background(), rule)
+	if err != nil {
+		handleResponse(c, "error while creating commission rule", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdRule, err := h.storage.Commission().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting commission rule by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdRule)
+}
+
+// UpdateCommissionRule godoc
+// @Router       /staff-tariff/{id}/rule/{rule_id} [PUT]
+// @Summary      Update commission rule
+// @Description  update commission rule of the tariff
+// @Tags         staff-tariff
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "staff-tariff_id"
+// @Param 		 rule_id path string true "rule_id"
+// @Param 		 rule body models.UpdateCommissionRule true "rule"
+// @Success      200  {object}  models.CommissionRule
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) UpdateCommissionRule(c *gin.Context) {
+	rule := models.UpdateCommissionRule{}
+
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+	rule.ID, rule.TariffID = c.Param("rule_id"), c.Param("id")
+
+	if !h.checkCommissionRule(c, models.CreateCommissionRule{
+		TariffID:      rule.TariffID,
+		CategoryID:    rule.CategoryID,
+		MinVolume:     rule.MinVolume,
+		TariffType:    rule.TariffType,
+		AmountForCash: rule.AmountForCash,
+		AmountForCard: rule.AmountForCard,
+		EffectiveFrom: rule.EffectiveFrom,
+		EffectiveTo:   rule.EffectiveTo,
+	}) {
+		return
+	}
+
+	if _, err := h.storage.Commission().Update(context.Background(), rule); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while updating commission rule", http.StatusNotFound, "commission rule not found")
+			return
+		}
+		handleResponse(c, "error while updating commission rule", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	updatedRule, err := h.storage.Commission().GetByID(context.Background(), models.PrimaryKey{ID: rule.ID})
+	if err != nil {
+		handleResponse(c, "error while getting commission rule by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, updatedRule)
+}
+
+// DeleteCommissionRule godoc
+// @Router       /staff-tariff/{id}/rule/{rule_id} [DELETE]
+// @Summary      Delete commission rule
+// @Description  delete commission rule of the tariff
+// @Tags         staff-tariff
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "staff-tariff_id"
+// @Param 		 rule_id path string true "rule_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteCommissionRule(c *gin.Context) {
+	if err := h.storage.Commission().Delete(context.Background(), c.Param("id"), c.Param("rule_id")); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while deleting commission rule", http.StatusNotFound, "commission rule not found")
+			return
+		}
+		handleResponse(c, "error while deleting commission rule", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "commission rule deleted")
+}
+
+// CalculateCommission godoc
+// @Router       /staff-tariff/commission [POST]
+// @Summary      Calculate commission
+// @Description  calculate the commission of the staff for a sale line, a basket or a product with its line total, quantity and payment type
+// @Tags         staff-tariff
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 line body models.CommissionRequest true "sale line"
+// @Success      200  {object}  models.Commission
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CalculateCommission(c *gin.Context) {
+	request := models.CommissionRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if request.StaffID == "" {
+		request.StaffID = actingStaff(c).ID
+	}
+
+	if request.BasketID == "" {
+		if request.ProductID == "" {
+			handleResponse(c, "error while validating sale line", http.StatusBadRequest, "either basket_id or product_id is required")
+			return
+		}
+
+		if request.Price < 0 || request.Quantity <= 0 {
+			handleResponse(c, "error while validating sale line", http.StatusBadRequest, "price should not be negative and quantity should be positive")
+			return
+		}
+
+		if request.PaymentType != "cash" && request.PaymentType != "card" {
+			handleResponse(c, "error while validating sale line", http.StatusBadRequest, "payment_type should be cash or card")
+			return
+		}
+	}
+
+	if !h.checkStaffAccess(c, request.StaffID, true) {
+		return
+	}
+
+	commission, err := h.storage.Commission().Calculate(context.Background(), request)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNoTariff):
+			handleResponse(c, "error while calculating commission", http.StatusBadRequest, err.Error())
+		case errors.Is(err, pgx.ErrNoRows):
+			handleResponse(c, "error while calculating commission", http.StatusNotFound, "basket or product not found")
+		default:
+			handleResponse(c, "error while calculating commission", http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, commission)
+}
+
+// checkCommissionRule answers 400 for a wrong rule and 404 when its tariff or category is missing.
+func (h Handler) checkCommissionRule(c *gin.Context, rule models.CreateCommissionRule) bool {
+	if err := validateCommissionRule(rule); err != nil {
+		handleResponse(c, "error while validating commission rule", http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	if _, err := h.storage.StaffTariff().GetStaffTariffByID(context.Background(), models.PrimaryKey{ID: rule.TariffID}); err != nil {
+		handleResponse(c, "error while getting staff tariff by ID", http.StatusNotFound, err.Error())
+		return false
+	}
+
+	if rule.CategoryID != "" {
+		if _, err := h.storage.Category().GetByID(context.Background(), rule.CategoryID); err != nil {
+			handleResponse(c, "error while getting category by ID", http.StatusNotFound, err.Error())
+			return false
+		}
+	}
+
+	return true
+}
+
+func validateCommissionRule(rule models.CreateCommissionRule) error {
+	if rule.TariffType != "percent" && rule.TariffType != "fixed" {
+		return errors.New("tariff_type should be percent or fixed")
+	}
+
+	if rule.AmountForCash < 0 || rule.AmountForCard < 0 || rule.MinVolume < 0 {
+		return errors.New("amounts and min_volume should not be negative")
+	}
+
+	if rule.TariffType == "percent" && (rule.AmountForCash > 100 || rule.AmountForCard > 100) {
+		return errors.New("percent amounts should not be above 100")
+	}
+
+	from, err := time.Parse("2006-01-02", rule.EffectiveFrom)
+	if err != nil {
+		return errors.New("effective_from should be in YYYY-MM-DD format")
+	}
+
+	if rule.EffectiveTo != "" {
+		to, err := time.Parse("2006-01-02", rule.EffectiveTo)
+		if err != nil {
+			return errors.New("effective_to should be in YYYY-MM-DD format")
+		}
+
+		if to.Before(from) {
+			return errors.New("effective_to should not be before effective_from")
+		}
+	}
+
+	return nil
+}
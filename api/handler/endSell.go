@@ -2,106 +2,110 @@ package handler
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"net/http"
 	"sell/api/models"
+	"sell/storage"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // EndSell godoc
 // @Router       /end-sell/{id} [PUT]
 // @Summary      end sell
-// @Description  end sell
+// @Description  finish a sale in process, take its products from stock, post it to the general ledger and pay the commissions of the cashier and the shop assistant
 // @Tags         sell
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "sale_id"
 // @Success      200  {object}  models.Response
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) EndSell(c *gin.Context) {
 	saleID := c.Param("id")
 
-	baskets, err := h.storage.Basket().GetList(context.Background(), models.GetListRequest{
-		Page:   1,
-		Limit:  10,
-		Search: saleID,
-	})
-	if err != nil {
-		handleResponse(c, "error is while getting  baskets list", http.StatusInternalServerError, err.Error())
+	if !h.checkSaleBranch(c, saleID) {
 		return
 	}
 
-	totalPrice := 0
-
-	for _, value := range baskets.Baskets {
-		totalPrice += value.Price
-	}
-
-	fmt.Println("totalprice", totalPrice)
-	id, err := h.storage.Sale().UpdatePrice(context.Background(), totalPrice, saleID)
+	saleDate, err := h.storage.Sale().GetByID(context.Background(), saleID)
 	if err != nil {
-		handleResponse(c, "error is while updating price", http.StatusInternalServerError, err.Error())
+		handleResponse(c, "error is while getting sale by id", http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	saleDate, err := h.storage.Sale().GetByID(context.Background(), saleID)
-
+	totalPrice, movements, err := h.saleMovements(context.Background(), saleID)
 	if err != nil {
-		handleResponse(c, "error is while updating price", http.StatusInternalServerError, err.Error())
+		handleResponse(c, "error is while getting sale movements", http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	repoGetList, err := h.storage.Repository().GetList(context.Background(), models.GetListRequest{
-		Page:   1,
-		Limit:  1000,
-		Search: saleDate.BranchID,
+	err = h.storage.Sale().End(context.Background(), models.EndSale{
+		ID:        saleID,
+		Price:     totalPrice,
+		StaffID:   saleDate.CashierID,
+		Movements: movements,
 	})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		handleResponse(c, "error is while ending sale", http.StatusBadRequest, "only sales in process can be ended")
+		return
+	case errors.Is(err, storage.ErrNotEnoughStock):
+		handleResponse(c, "error is while ending sale", http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		handleResponse(c, "error is while ending sale", http.StatusInternalServerError, err.Error())
+		return
+	}
 
+	resp, err := h.storage.Sale().GetByID(context.Background(), saleID)
 	if err != nil {
-		handleResponse(c, "error is while updating price", http.StatusInternalServerError, err.Error())
+		handleResponse(c, "error is while getting sale by id", http.StatusInternalServerError, err.Error())
 		return
 	}
+	handleResponse(c, "success", http.StatusOK, resp)
+}
 
-	repoProducts := make(map[string]int)
-
-	for _, v := range repoGetList.Repositories {
-		repoProducts[v.ProductID] = v.Count
+// saleMovements returns the total price of the baskets of the sale and the stock they take,
+// bundles are split into their components.
+func (h Handler) saleMovements(ctx context.Context, saleID string) (float64, []models.CreateRepositoryTransaction, error) {
+	baskets, err := h.storage.Basket().GetBySaleID(ctx, saleID)
+	if err != nil {
+		return 0, nil, err
 	}
 
-	for _, v := range baskets.Baskets {
+	totalPrice := 0.0
+	movements := []models.CreateRepositoryTransaction{}
 
-		_, err := h.storage.Repository().UpdateProductQuantity(context.Background(), models.UpdateRepository{
-			ProductID: v.ProductID,
-			BranchID:  saleDate.BranchID,
-			Count:     repoProducts[v.ProductID] - v.Quantity,
-		})
+	for _, value := range baskets {
+		totalPrice += value.Price
 
+		bundle, err := h.storage.Bundle().GetByProductID(ctx, value.ProductID)
 		if err != nil {
-			handleResponse(c, "error is while updating price", http.StatusInternalServerError, err.Error())
-			return
+			return 0, nil, err
 		}
 
-		_, err = h.storage.RTransaction().Create(context.Background(), models.CreateRepositoryTransaction{
-			StaffID:                   saleDate.CashierID,
-			ProductID:                 v.ProductID,
-			RepositoryTransactionType: "minus",
-			Price:                     v.Price,
-			Quantity:                  v.Quantity,
-		})
+		if bundle.ID == "" {
+			movements = append(movements, models.CreateRepositoryTransaction{
+				ProductID:                 value.ProductID,
+				VariantID:                 value.VariantID,
+				RepositoryTransactionType: "minus",
+				Price:                     value.Price,
+				Quantity:                  value.Quantity,
+			})
+			continue
+		}
 
+		bundleMovements, err := h.bundleMovements(ctx, bundle, value)
 		if err != nil {
-			handleResponse(c, "error is while updating price", http.StatusInternalServerError, err.Error())
-			return
+			return 0, nil, err
 		}
+		movements = append(movements, bundleMovements...)
 	}
 
-	resp, err := h.storage.Sale().GetByID(context.Background(), id)
-	if err != nil {
-		handleResponse(c, "error is while getting sale by id", http.StatusInternalServerError, err.Error())
-		return
-	}
-	handleResponse(c, "success", http.StatusOK, resp)
+	return totalPrice, movements, nil
 }
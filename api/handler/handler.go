@@ -3,16 +3,22 @@ package handler
 import (
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
 	"sell/api/models"
+	"sell/config"
+	"sell/pkg/blob"
 	"sell/storage"
 )
 
 type Handler struct {
+	cfg     config.Config
 	storage storage.IStorage
+	blob    blob.Store
 }
 
-func New(store storage.IStorage) Handler {
-	return Handler{storage: store}
+func New(cfg config.Config, store storage.IStorage, blobStore blob.Store) Handler {
+	return Handler{cfg: cfg, storage: store, blob: blobStore}
 }
 
 func handleResponse(c *gin.Context, msg string, statusCode int, data interface{}) {
@@ -34,3 +40,19 @@ func handleResponse(c *gin.Context, msg string, statusCode int, data interface{}
 
 	c.JSON(resp.StatusCode, resp)
 }
+
+// validIDs answers 400 when one of the ids is given but is not a uuid.
+func validIDs(c *gin.Context, ids ...string) bool {
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+
+		if _, err := uuid.Parse(id); err != nil {
+			handleResponse(c, "error while parsing id", http.StatusBadRequest, err.Error())
+			return false
+		}
+	}
+
+	return true
+}
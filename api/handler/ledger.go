@@ -0,0 +1,364 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/storage"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateAccount godoc
+// @Router       /account [POST]
+// @Summary      Create an account
+// @Description  add an account to the general ledger
+// @Tags         ledger
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 account body models.CreateAccount true "account"
+// @Success      201  {object}  models.Account
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateAccount(c *gin.Context) {
+	account := models.CreateAccount{}
+
+	if err := c.ShouldBindJSON(&account); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if account.Code == "" || account.Name == "" {
+		handleResponse(c, "error while validating account", http.StatusBadRequest, "code and name are required")
+		return
+	}
+
+	switch account.AccountType {
+	case "asset", "liability", "equity", "revenue", "expense":
+	default:
+		handleResponse(c, "error while validating account", http.StatusBadRequest, "account_type should be asset, liability, equity, revenue or expense")
+		return
+	}
+
+	id, err := h.storage.Ledger().CreateAccount(context.Background(), account)
+	if err != nil {
+		handleResponse(c, "error while creating account", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdAccount, err := h.storage.Ledger().GetAccountByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting account by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdAccount)
+}
+
+// GetAccountList godoc
+// @Router       /accounts [GET]
+// @Summary      Get account list
+// @Description  get the chart of accounts
+// @Tags         ledger
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.AccountsResponse
+// @Failure      500  {object}  models.Response
+func (h Handler) GetAccountList(c *gin.Context) {
+	accounts, err := h.storage.Ledger().GetAccountList(context.Background())
+	if err != nil {
+		handleResponse(c, "error while getting accounts", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, accounts)
+}
+
+// GetAccountStatement godoc
+// @Router       /account/{id}/statement [GET]
+// @Summary      Get account statement
+// @Description  get the opening balance, the postings of the period with running balances and the closing balance of an account
+// @Tags         ledger
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "account_id"
+// @Param 		 from query string false "from date, 2006-01-02"
+// @Param 		 to query string false "to date, 2006-01-02, inclusive"
+// @Param 		 staff_id query string false "staff_id"
+// @Param 		 branch_id query string false "branch_id"
+// @Success      200  {object}  models.AccountStatement
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetAccountStatement(c *gin.Context) {
+	from, to, err := parsePeriod(c)
+	if err != nil {
+		handleResponse(c, "error while parsing period", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !validIDs(c, c.Param("id"), c.Query("staff_id"), c.Query("branch_id")) {
+		return
+	}
+
+	statement, err := h.storage.Ledger().AccountStatement(context.Background(), models.AccountStatementRequest{
+		AccountID: c.Param("id"),
+		StaffID:   c.Query("staff_id"),
+		BranchID:  c.Query("branch_id"),
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		ledgerError(c, "error while getting account statement", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, statement)
+}
+
+// GetTrialBalance godoc
+// @Router       /ledger/trial-balance [GET]
+// @Summary      Get trial balance
+// @Description  get the debit or credit balance of every account, the two totals are equal when the ledger is sound
+// @Tags         ledger
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 to query string false "balances at the end of this date, 2006-01-02"
+// @Param 		 branch_id query string false "branch_id"
+// @Success      200  {object}  models.TrialBalance
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetTrialBalance(c *gin.Context) {
+	var to time.Time
+
+	if toStr := c.Query("to"); toStr != "" {
+		day, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			handleResponse(c, "error while parsing date", http.StatusBadRequest, err.Error())
+			return
+		}
+		to = day.AddDate(0, 0, 1)
+	}
+
+	if !validIDs(c, c.Query("branch_id")) {
+		return
+	}
+
+	balance, err := h.storage.Ledger().TrialBalance(context.Background(), models.TrialBalanceRequest{
+		BranchID: c.Query("branch_id"),
+		To:       to,
+	})
+	if err != nil {
+		handleResponse(c, "error while getting trial balance", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, balance)
+}
+
+// CreateJournalEntry godoc
+// @Router       /journal-entry [POST]
+// @Summary      Post a journal entry
+// @Description  post a manual entry, the debits of the postings should equal the credits
+// @Tags         ledger
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 entry body models.CreateJournalEntry true "journal entry"
+// @Success      201  {object}  models.JournalEntry
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateJournalEntry(c *gin.Context) {
+	entry := models.CreateJournalEntry{}
+
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if entry.EntryDate.After(time.Now()) {
+		handleResponse(c, "error while validating journal entry", http.StatusBadRequest, "entry_date should not be in the future")
+		return
+	}
+
+	for _, posting := range entry.Postings {
+		if !validIDs(c, posting.StaffID, posting.BranchID) {
+			return
+		}
+	}
+
+	entry.SourceType = "manual"
+	entry.CreatedBy = actingStaff(c).ID
+
+	id, err := h.storage.Ledger().CreateEntry(context.Background(), entry)
+	if err != nil {
+		ledgerError(c, "error while posting journal entry", err)
+		return
+	}
+
+	createdEntry, err := h.storage.Ledger().GetEntryByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting journal entry by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdEntry)
+}
+
+// GetJournalEntry godoc
+// @Router       /journal-entry/{id} [GET]
+// @Summary      Get journal entry by id
+// @Description  get journal entry with its postings
+// @Tags         ledger
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "journal_entry_id"
+// @Success      200  {object}  models.JournalEntry
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetJournalEntry(c *gin.Context) {
+	if !validIDs(c, c.Param("id")) {
+		return
+	}
+
+	entry, err := h.storage.Ledger().GetEntryByID(context.Background(), models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		ledgerError(c, "error while getting journal entry by ID", err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, entry)
+}
+
+// GetJournalEntryList godoc
+// @Router       /journal-entries [GET]
+// @Summary      Get journal entry list
+// @Description  get journal entries with their postings, newest first
+// @Tags         ledger
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 source_type query string false "sale, refund, transaction or manual"
+// @Param 		 source_id query string false "id of the sale or transaction"
+// @Param 		 account_code query string false "account_code"
+// @Param 		 from query string false "from date, 2006-01-02"
+// @Param 		 to query string false "to date, 2006-01-02, inclusive"
+// @Success      200  {object}  models.JournalEntriesResponse
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetJournalEntryList(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, to, err := parsePeriod(c)
+	if err != nil {
+		handleResponse(c, "error while parsing period", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !validIDs(c, c.Query("source_id")) {
+		return
+	}
+
+	entries, err := h.storage.Ledger().GetEntryList(context.Background(), models.JournalEntryGetListRequest{
+		Page:        page,
+		Limit:       limit,
+		SourceType:  c.Query("source_type"),
+		SourceID:    c.Query("source_id"),
+		AccountCode: c.Query("account_code"),
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		handleResponse(c, "error while getting journal entries", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, entries)
+}
+
+// ReverseJournalEntry godoc
+// @Router       /journal-entry/{id}/reverse [PUT]
+// @Summary      Reverse a journal entry
+// @Description  post the lines of a manual entry on the other side, the automatic entries follow their sales and transactions
+// @Tags         ledger
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "journal_entry_id"
+// @Param 		 reversal body models.ReverseJournalEntry false "reversal"
+// @Success      200  {object}  models.JournalEntry
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ReverseJournalEntry(c *gin.Context) {
+	request := models.ReverseJournalEntry{}
+
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if !validIDs(c, c.Param("id")) {
+		return
+	}
+
+	request.ID = c.Param("id")
+	request.CreatedBy = actingStaff(c).ID
+	if request.Description == "" {
+		request.Description = "reversal"
+	}
+
+	id, err := h.storage.Ledger().ReverseEntry(context.Background(), request)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while reversing journal entry", http.StatusNotFound, "no manual entry left to reverse")
+			return
+		}
+		ledgerError(c, "error while reversing journal entry", err)
+		return
+	}
+
+	reversal, err := h.storage.Ledger().GetEntryByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting journal entry by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, reversal)
+}
+
+// ledgerError answers 400 for entries that cannot be posted and 404 for missing ones.
+func ledgerError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, storage.ErrUnbalancedEntry), errors.Is(err, storage.ErrUnknownAccount):
+		handleResponse(c, message, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		handleResponse(c, message, http.StatusNotFound, "not found")
+	default:
+		handleResponse(c, message, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/check"
+	"sell/pkg/security"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreatePasswordReset godoc
+// @Router       /staff/{id}/password-reset [POST]
+// @Summary      Reset staff password
+// @Description  issue a one time token the staff sets a new password with, the staff can not log in until then
+// @Tags         staff
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "staff_id"
+// @Success      201  {object}  models.PasswordResetResponse
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreatePasswordReset(c *gin.Context) {
+	uid := c.Param("id")
+
+	if !h.checkStaffAccess(c, uid, false) {
+		return
+	}
+
+	token, err := security.NewToken()
+	if err != nil {
+		handleResponse(c, "error while creating reset token", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	expiresAt := time.Now().Add(h.cfg.PasswordResetTTL)
+	if _, err = h.storage.PasswordReset().Create(context.Background(), models.CreatePasswordReset{
+		StaffID:   uid,
+		CreatedBy: actingStaff(c).ID,
+		TokenHash: security.HashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		handleResponse(c, "error while creating password reset", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, models.PasswordResetResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// ResetPassword godoc
+// @Router       /auth/reset-password [POST]
+// @Summary      Set a new password with a reset token
+// @Description  set a new password with the one time token a manager issued
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param 		 reset body models.ResetPasswordRequest true "reset"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      401  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ResetPassword(c *gin.Context) {
+	request := models.ResetPasswordRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reset, err := h.storage.PasswordReset().GetByHash(context.Background(), security.HashToken(request.Token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "reset token is not valid", http.StatusUnauthorized, "reset token is not valid")
+			return
+		}
+		handleResponse(c, "error while getting password reset", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if reset.UsedAt != nil || time.Now().After(reset.ExpiresAt) {
+		handleResponse(c, "reset token is not valid", http.StatusUnauthorized, "reset token is expired or used")
+		return
+	}
+
+	if err = h.validateNewPassword(context.Background(), reset.StaffID, request.NewPassword); err != nil {
+		handleResponse(c, "new password is weak", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.storage.PasswordReset().Use(context.Background(), reset.ID, request.NewPassword); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "reset token is not valid", http.StatusUnauthorized, "reset token is expired or used")
+			return
+		}
+		handleResponse(c, "error while resetting password", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "password successfully updated")
+}
+
+func (h Handler) passwordPolicy() check.PasswordPolicy {
+	return check.PasswordPolicy{
+		MinLength:  h.cfg.PasswordMinLength,
+		MinClasses: h.cfg.PasswordMinClasses,
+	}
+}
+
+// validateNewPassword checks the password policy and, for an existing staff,
+// that none of the last PasswordHistory passwords is used again.
+func (h Handler) validateNewPassword(ctx context.Context, staffID, password string) error {
+	if err := h.passwordPolicy().Validate(password); err != nil {
+		return err
+	}
+
+	if staffID == "" || h.cfg.PasswordHistory <= 0 {
+		return nil
+	}
+
+	hashes, err := h.storage.Staff().PasswordHistory(ctx, staffID, h.cfg.PasswordHistory)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		if security.CompareHashAndPassword(hash, password) {
+			return errors.New("password was used recently, choose another one")
+		}
+	}
+
+	return nil
+}
+
+// checkPassword compares the password and keeps count of the wrong ones.
+// A locked staff is answered with 423, a wrong password with status and message.
+func (h Handler) checkPassword(c *gin.Context, credentials models.StaffCredentials, password string, status int, message string) bool {
+	if credentials.LockedUntil != nil && time.Now().Before(*credentials.LockedUntil) {
+		handleResponse(c, "staff is locked", http.StatusLocked, "too many wrong passwords, try again after "+credentials.LockedUntil.Format(time.RFC3339))
+		return false
+	}
+
+	if !security.CompareHashAndPassword(credentials.Password, password) {
+		if err := h.storage.Staff().RegisterFailedLogin(context.Background(), credentials.ID, h.cfg.LoginMaxAttempts, time.Now().Add(h.cfg.LoginLockout)); err != nil {
+			handleResponse(c, "error while registering failed login", http.StatusInternalServerError, err.Error())
+			return false
+		}
+
+		handleResponse(c, message, status, message)
+		return false
+	}
+
+	if err := h.storage.Staff().ResetFailedLogins(context.Background(), credentials.ID); err != nil {
+		handleResponse(c, "error while resetting failed logins", http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	return true
+}
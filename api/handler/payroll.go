@@ -0,0 +1,348 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/rbac"
+	"sell/pkg/sheet"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreatePayrollRun godoc
+// @Router       /payroll-run [POST]
+// @Summary      Calculate payroll run
+// @Description  calculate a draft payroll run of the month for the staff of the branch, or of every branch without one
+// @Tags         payroll
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 payroll body models.CreatePayrollRun true "payroll"
+// @Success      201  {object}  models.PayrollRun
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreatePayrollRun(c *gin.Context) {
+	run := models.CreatePayrollRun{}
+
+	if err := c.ShouldBindJSON(&run); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := time.Parse("2006-01", run.Month); err != nil {
+		handleResponse(c, "error while parsing month", http.StatusBadRequest, "month should be in YYYY-MM format")
+		return
+	}
+
+	if !checkBranch(c, run.BranchID) {
+		return
+	}
+	run.CreatedBy = actingStaff(c).ID
+
+	id, err := h.storage.Payroll().Create(context.Background(), run)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while creating payroll run", http.StatusBadRequest, "every staff is already in a payroll run of the month")
+			return
+		}
+		handleResponse(c, "error while creating payroll run", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdRun, err := h.storage.Payroll().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting payroll run by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdRun)
+}
+
+// GetPayrollRun godoc
+// @Router       /payroll-run/{id} [GET]
+// @Summary      Get payroll run by id
+// @Description  get payroll run with the item of every staff
+// @Tags         payroll
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "payroll_run_id"
+// @Success      200  {object}  models.PayrollRun
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetPayrollRun(c *gin.Context) {
+	run, ok := h.payrollRun(c)
+	if !ok {
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, run)
+}
+
+// GetPayrollRunList godoc
+// @Router       /payroll-runs [GET]
+// @Summary      Get payroll run list
+// @Description  get payroll runs, managers see the runs of their branch
+// @Tags         payroll
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 status query string false "draft or approved"
+// @Param 		 branch_id query string false "branch_id"
+// @Success      200  {object}  models.PayrollRunsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetPayrollRunList(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	branchID := c.Query("branch_id")
+	if acting := actingStaff(c); branchID == "" && !rbac.AllBranches(acting.StaffType) {
+		branchID = acting.BranchID
+	}
+
+	if !checkBranch(c, branchID) {
+		return
+	}
+
+	response, err := h.storage.Payroll().GetList(context.Background(), models.PayrollRunGetListRequest{
+		Page:     page,
+		Limit:    limit,
+		Status:   c.Query("status"),
+		BranchID: branchID,
+	})
+	if err != nil {
+		handleResponse(c, "error while getting payroll run list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// ApprovePayrollRun godoc
+// @Router       /payroll-run/{id}/approve [PUT]
+// @Summary      Approve payroll run
+// @Description  approve a draft payroll run, it tops up the base salaries and pays out the net pay of every staff, or the balance when it is lower
+// @Tags         payroll
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "payroll_run_id"
+// @Success      200  {object}  models.PayrollRun
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ApprovePayrollRun(c *gin.Context) {
+	run, ok := h.payrollRun(c)
+	if !ok {
+		return
+	}
+
+	if run.Status != "draft" {
+		handleResponse(c, "error while checking payroll run", http.StatusBadRequest, "payroll run is already "+run.Status)
+		return
+	}
+
+	if err := h.storage.Payroll().Approve(context.Background(), run.ID, actingStaff(c).ID); err != nil {
+		handleResponse(c, "error while approving payroll run", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	approvedRun, err := h.storage.Payroll().GetByID(context.Background(), models.PrimaryKey{ID: run.ID})
+	if err != nil {
+		handleResponse(c, "error while getting payroll run by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, approvedRun)
+}
+
+// DeletePayrollRun godoc
+// @Router       /payroll-run/{id} [DELETE]
+// @Summary      Delete payroll run
+// @Description  delete a draft payroll run so the month can be calculated again
+// @Tags         payroll
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "payroll_run_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeletePayrollRun(c *gin.Context) {
+	run, ok := h.payrollRun(c)
+	if !ok {
+		return
+	}
+
+	if run.Status != "draft" {
+		handleResponse(c, "error while checking payroll run", http.StatusBadRequest, "only draft payroll runs can be deleted")
+		return
+	}
+
+	if err := h.storage.Payroll().Delete(context.Background(), run.ID); err != nil {
+		handleResponse(c, "error while deleting payroll run", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "payroll run deleted")
+}
+
+// DownloadPayslips godoc
+// @Router       /payroll-run/{id}/payslips [GET]
+// @Summary      Download payslips
+// @Description  download the payslips of every staff of the run as one csv or xlsx sheet
+// @Tags         payroll
+// @Security     ApiKeyAuth
+// @Produce      octet-stream
+// @Param 		 id path string true "payroll_run_id"
+// @Param 		 format query string false "csv or xlsx, csv by default"
+// @Success      200  {file}  file
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DownloadPayslips(c *gin.Context) {
+	format := c.DefaultQuery("format", sheet.CSV)
+	if format != sheet.CSV && format != sheet.XLSX {
+		handleResponse(c, "error while reading format", http.StatusBadRequest, sheet.ErrUnknownFormat.Error())
+		return
+	}
+
+	run, ok := h.payrollRun(c)
+	if !ok {
+		return
+	}
+
+	records := [][]string{{"staff_id", "staff_name", "month", "status", "base_salary", "commissions", "bonuses", "fines", "net", "paid"}}
+	for _, item := range run.Items {
+		records = append(records, []string{
+			item.StaffID,
+			item.StaffName,
+			run.Month,
+			run.Status,
+			formatMoney(item.BaseSalary),
+			formatMoney(item.Commissions),
+			formatMoney(item.Bonuses),
+			formatMoney(item.Fines),
+			formatMoney(item.Net),
+			formatMoney(item.Paid),
+		})
+	}
+
+	writeSheet(c, fmt.Sprintf("payslips-%s", run.Month), format, records)
+}
+
+// DownloadPayslip godoc
+// @Router       /payroll-run/{id}/payslip/{staff_id} [GET]
+// @Summary      Download payslip
+// @Description  download the payslip of one staff as csv or xlsx, staff can download their own payslips
+// @Tags         payroll
+// @Security     ApiKeyAuth
+// @Produce      octet-stream
+// @Param 		 id path string true "payroll_run_id"
+// @Param 		 staff_id path string true "staff_id"
+// @Param 		 format query string false "csv or xlsx, csv by default"
+// @Success      200  {file}  file
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DownloadPayslip(c *gin.Context) {
+	staffID := c.Param("staff_id")
+
+	format := c.DefaultQuery("format", sheet.CSV)
+	if format != sheet.CSV && format != sheet.XLSX {
+		handleResponse(c, "error while reading format", http.StatusBadRequest, sheet.ErrUnknownFormat.Error())
+		return
+	}
+
+	if !h.checkStaffAccess(c, staffID, true) {
+		return
+	}
+
+	run, err := h.storage.Payroll().GetByID(context.Background(), models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while getting payroll run by ID", http.StatusNotFound, "payroll run not found")
+			return
+		}
+		handleResponse(c, "error while getting payroll run by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for _, item := range run.Items {
+		if item.StaffID != staffID {
+			continue
+		}
+
+		writeSheet(c, fmt.Sprintf("payslip-%s-%s", run.Month, item.StaffID), format, [][]string{
+			{"payslip", run.Month},
+			{"staff", item.StaffName},
+			{"status", run.Status},
+			{"base_salary", formatMoney(item.BaseSalary)},
+			{"commissions", formatMoney(item.Commissions)},
+			{"bonuses", formatMoney(item.Bonuses)},
+			{"fines", formatMoney(item.Fines)},
+			{"net", formatMoney(item.Net)},
+			{"paid", formatMoney(item.Paid)},
+		})
+		return
+	}
+
+	handleResponse(c, "error while getting payslip", http.StatusNotFound, "staff is not in the payroll run")
+}
+
+// payrollRun reads the run of the id param, managers only reach the runs of their branch.
+func (h Handler) payrollRun(c *gin.Context) (models.PayrollRun, bool) {
+	run, err := h.storage.Payroll().GetByID(context.Background(), models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while getting payroll run by ID", http.StatusNotFound, "payroll run not found")
+			return models.PayrollRun{}, false
+		}
+		handleResponse(c, "error while getting payroll run by ID", http.StatusInternalServerError, err.Error())
+		return models.PayrollRun{}, false
+	}
+
+	if !checkBranch(c, run.BranchID) {
+		return models.PayrollRun{}, false
+	}
+
+	return run, true
+}
+
+func writeSheet(c *gin.Context, name, format string, records [][]string) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", name, format))
+	c.Header("Content-Type", sheet.ContentType(format))
+	if err := sheet.Write(c.Writer, format, records); err != nil {
+		handleResponse(c, "error while writing file", http.StatusInternalServerError, err.Error())
+	}
+}
+
+func formatMoney(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
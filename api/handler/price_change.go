@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/check"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePriceChange godoc
+// @Router       /price-change [POST]
+// @Summary      Schedule a price change
+// @Description  schedule an absolute or percent price change of products or of a category subtree
+// @Tags         price change
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 price_change body models.CreatePriceChange false "price_change"
+// @Success      200  {object}  models.PriceChange
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreatePriceChange(c *gin.Context) {
+	change, ok := h.readPriceChange(c)
+	if !ok {
+		return
+	}
+
+	if change.Name == "" {
+		handleResponse(c, "error while validating price change", http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if change.EffectiveAt.IsZero() {
+		handleResponse(c, "error while validating price change", http.StatusBadRequest, "effective_at is required")
+		return
+	}
+
+	id, err := h.storage.PriceChange().Create(context.Background(), change)
+	if err != nil {
+		handleResponse(c, "error while creating price change", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdChange, err := h.storage.PriceChange().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdChange)
+}
+
+// PreviewPriceChange godoc
+// @Router       /price-change/preview [POST]
+// @Summary      Preview a price change
+// @Description  show the current and resulting prices without saving anything
+// @Tags         price change
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 price_change body models.CreatePriceChange false "price_change"
+// @Success      200  {object}  models.PriceChangePreview
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) PreviewPriceChange(c *gin.Context) {
+	change, ok := h.readPriceChange(c)
+	if !ok {
+		return
+	}
+
+	preview, err := h.storage.PriceChange().Preview(context.Background(), change)
+	if err != nil {
+		handleResponse(c, "error while previewing price change", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, preview)
+}
+
+// GetPriceChange godoc
+// @Router       /price-change/{id} [GET]
+// @Summary      Get price change by id
+// @Description  get price change by id
+// @Tags         price change
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "price_change_id"
+// @Success      200  {object}  models.PriceChange
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetPriceChange(c *gin.Context) {
+	change, err := h.storage.PriceChange().GetByID(context.Background(), models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		handleResponse(c, "error while getting price change by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, change)
+}
+
+// GetScheduledPriceChangePreview godoc
+// @Router       /price-change/{id}/preview [GET]
+// @Summary      Preview a scheduled price change
+// @Description  show the current and resulting prices of a saved price change
+// @Tags         price change
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "price_change_id"
+// @Success      200  {object}  models.PriceChangePreview
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetScheduledPriceChangePreview(c *gin.Context) {
+	change, err := h.storage.PriceChange().GetByID(context.Background(), models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		handleResponse(c, "error while getting price change by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	preview, err := h.storage.PriceChange().Preview(context.Background(), models.CreatePriceChange{
+		ChangeType:   change.ChangeType,
+		Value:        change.Value,
+		Rounding:     change.Rounding,
+		RoundingStep: change.RoundingStep,
+		CategoryID:   change.CategoryID,
+		ProductIDs:   change.ProductIDs,
+	})
+	if err != nil {
+		handleResponse(c, "error while previewing price change", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, preview)
+}
+
+// GetPriceChangeList godoc
+// @Router       /price-changes [GET]
+// @Summary      Get price change list
+// @Description  get price change list
+// @Tags         price change
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 status query string false "scheduled, applied or cancelled"
+// @Success      200  {object}  models.PriceChangesResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetPriceChangeList(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := h.storage.PriceChange().GetList(context.Background(), models.GetListRequest{
+		Page:   page,
+		Limit:  limit,
+		Search: c.Query("status"),
+	})
+	if err != nil {
+		handleResponse(c, "error while getting price change list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// CancelPriceChange godoc
+// @Router       /price-change/{id}/cancel [PUT]
+// @Summary      Cancel price change
+// @Description  cancel a scheduled price change
+// @Tags         price change
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "price_change_id"
+// @Success      200  {object}  models.PriceChange
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CancelPriceChange(c *gin.Context) {
+	key := models.PrimaryKey{ID: c.Param("id")}
+
+	if err := h.storage.PriceChange().Cancel(context.Background(), key); err != nil {
+		handleResponse(c, "error while cancelling price change", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	change, err := h.storage.PriceChange().GetByID(context.Background(), key)
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, change)
+}
+
+// GetProductPriceHistory godoc
+// @Router       /product/{id}/price-history [GET]
+// @Summary      Get product price history
+// @Description  get the base price changes of the product, newest first
+// @Tags         product
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Success      200  {object}  models.ProductPriceHistoryResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductPriceHistory(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	history, err := h.storage.PriceChange().History(context.Background(), models.GetListRequest{
+		Page:   page,
+		Limit:  limit,
+		Search: c.Param("id"),
+	})
+	if err != nil {
+		handleResponse(c, "error while getting product price history", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, history)
+}
+
+// readPriceChange binds and validates a price change body, filling the rounding defaults.
+func (h Handler) readPriceChange(c *gin.Context) (models.CreatePriceChange, bool) {
+	change := models.CreatePriceChange{}
+
+	if err := c.ShouldBindJSON(&change); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return models.CreatePriceChange{}, false
+	}
+
+	if change.Rounding == "" {
+		change.Rounding = "none"
+	}
+
+	if change.RoundingStep == 0 {
+		change.RoundingStep = 1
+	}
+
+	if err := check.ValidatePriceChange(change.ChangeType, change.Rounding, change.RoundingStep, change.ProductIDs, change.CategoryID); err != nil {
+		handleResponse(c, "error while validating price change", http.StatusBadRequest, err.Error())
+		return models.CreatePriceChange{}, false
+	}
+
+	return change, true
+}
@@ -0,0 +1,380 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sell/api/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePriceList godoc
+// @Router       /price-list [POST]
+// @Summary      Create a new price list
+// @Description  create a new price list
+// @Tags         price list
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 price_list body models.CreatePriceList false "price_list"
+// @Success      200  {object}  models.PriceList
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreatePriceList(c *gin.Context) {
+	priceList := models.CreatePriceList{}
+
+	if err := c.ShouldBindJSON(&priceList); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if priceList.Name == "" {
+		handleResponse(c, "error while validating price list", http.StatusBadRequest, "name is required")
+		return
+	}
+
+	id, err := h.storage.PriceList().Create(context.Background(), priceList)
+	if err != nil {
+		handleResponse(c, "error while creating price list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdPriceList, err := h.storage.PriceList().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdPriceList)
+}
+
+// GetPriceList godoc
+// @Router       /price-list/{id} [GET]
+// @Summary      Get price list by id
+// @Description  get price list by id with its items
+// @Tags         price list
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "price_list_id"
+// @Success      200  {object}  models.PriceList
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetPriceList(c *gin.Context) {
+	uid := c.Param("id")
+
+	priceList, err := h.storage.PriceList().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting price list by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, priceList)
+}
+
+// GetPriceListList godoc
+// @Router       /price-lists [GET]
+// @Summary      Get price list list
+// @Description  get price list list
+// @Tags         price list
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 search query string false "search"
+// @Success      200  {object}  models.PriceListsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetPriceListList(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := h.storage.PriceList().GetList(context.Background(), models.GetListRequest{
+		Page:   page,
+		Limit:  limit,
+		Search: c.Query("search"),
+	})
+	if err != nil {
+		handleResponse(c, "error while getting price list list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// UpdatePriceList godoc
+// @Router       /price-list/{id} [PUT]
+// @Summary      Update price list
+// @Description  update price list
+// @Tags         price list
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "price_list_id"
+// @Param 		 price_list body models.UpdatePriceList false "price_list"
+// @Success      200  {object}  models.PriceList
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) UpdatePriceList(c *gin.Context) {
+	uid := c.Param("id")
+
+	priceList := models.UpdatePriceList{}
+	if err := c.ShouldBindJSON(&priceList); err != nil {
+		handleResponse(c, "error while reading from body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if priceList.Name == "" {
+		handleResponse(c, "error while validating price list", http.StatusBadRequest, "name is required")
+		return
+	}
+
+	priceList.ID = uid
+	if _, err := h.storage.PriceList().Update(context.Background(), priceList); err != nil {
+		handleResponse(c, "error while updating price list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	updatedPriceList, err := h.storage.PriceList().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, updatedPriceList)
+}
+
+// DeletePriceList godoc
+// @Router       /price-list/{id} [DELETE]
+// @Summary      Delete price list
+// @Description  delete price list, its branches fall back to base prices
+// @Tags         price list
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "price_list_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeletePriceList(c *gin.Context) {
+	uid := c.Param("id")
+
+	if err := h.storage.PriceList().Delete(context.Background(), models.PrimaryKey{ID: uid}); err != nil {
+		handleResponse(c, "error while deleting price list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "price list deleted")
+}
+
+// SetPriceListItem godoc
+// @Router       /price-list/{id}/item [PUT]
+// @Summary      Set price list item
+// @Description  set the price of a product in the price list
+// @Tags         price list
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "price_list_id"
+// @Param 		 item body models.SetPriceListItem true "item"
+// @Success      200  {object}  models.PriceList
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) SetPriceListItem(c *gin.Context) {
+	uid := c.Param("id")
+
+	item := models.SetPriceListItem{}
+	if err := c.ShouldBindJSON(&item); err != nil {
+		handleResponse(c, "error while reading from body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if item.Price < 0 {
+		handleResponse(c, "error while validating price", http.StatusBadRequest, "price should not be negative")
+		return
+	}
+
+	if _, err := h.storage.PriceList().GetByID(context.Background(), models.PrimaryKey{ID: uid}); err != nil {
+		handleResponse(c, "error while getting price list by ID", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if _, err := h.storage.Product().GetByID(context.Background(), item.ProductID); err != nil {
+		handleResponse(c, "error while getting product by ID", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item.PriceListID = uid
+	if err := h.storage.PriceList().SetItem(context.Background(), item); err != nil {
+		handleResponse(c, "error while setting price list item", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	priceList, err := h.storage.PriceList().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, priceList)
+}
+
+// RemovePriceListItem godoc
+// @Router       /price-list/{id}/item/{product_id} [DELETE]
+// @Summary      Remove price list item
+// @Description  remove the product from the price list, it falls back to the base price
+// @Tags         price list
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "price_list_id"
+// @Param 		 product_id path string true "product_id"
+// @Success      200  {object}  models.PriceList
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) RemovePriceListItem(c *gin.Context) {
+	uid := c.Param("id")
+
+	if err := h.storage.PriceList().RemoveItem(context.Background(), uid, c.Param("product_id")); err != nil {
+		handleResponse(c, "error while removing price list item", http.StatusNotFound, err.Error())
+		return
+	}
+
+	priceList, err := h.storage.PriceList().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, priceList)
+}
+
+// GetPriceListHistory godoc
+// @Router       /price-list/{id}/history [GET]
+// @Summary      Get price list history
+// @Description  get the changes of the price list items, newest first
+// @Tags         price list
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "price_list_id"
+// @Param 		 product_id query string false "product_id"
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Success      200  {object}  models.PriceListHistoryResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetPriceListHistory(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	history, err := h.storage.PriceList().History(context.Background(), models.PriceListHistoryRequest{
+		Page:        page,
+		Limit:       limit,
+		PriceListID: c.Param("id"),
+		ProductID:   c.Query("product_id"),
+	})
+	if err != nil {
+		handleResponse(c, "error while getting price list history", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, history)
+}
+
+// AssignBranchPriceList godoc
+// @Router       /branch/{id}/price-list [PUT]
+// @Summary      Assign price list to branch
+// @Description  assign a price list to the branch, an empty price_list_id falls back to base prices
+// @Tags         price list
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "branch_id"
+// @Param 		 price_list body models.AssignPriceList true "price_list"
+// @Success      200  {object}  models.Branch
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) AssignBranchPriceList(c *gin.Context) {
+	request := models.AssignPriceList{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading from body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := h.storage.Branch().GetByID(context.Background(), c.Param("id")); err != nil {
+		handleResponse(c, "error while getting branch by ID", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if request.PriceListID != "" {
+		if _, err := h.storage.PriceList().GetByID(context.Background(), models.PrimaryKey{ID: request.PriceListID}); err != nil {
+			handleResponse(c, "error while getting price list by ID", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	request.BranchID = c.Param("id")
+	if err := h.storage.PriceList().Assign(context.Background(), request); err != nil {
+		handleResponse(c, "error while assigning price list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	branch, err := h.storage.Branch().GetByID(context.Background(), request.BranchID)
+	if err != nil {
+		handleResponse(c, "error while getting branch by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, branch)
+}
+
+// branchPrice returns the price of the product in the branch price list, or its base price.
+func (h Handler) branchPrice(ctx context.Context, branchID string, product models.Product) (int, error) {
+	prices, err := h.storage.PriceList().EffectivePrices(ctx, branchID, []string{product.ID})
+	if err != nil {
+		return 0, err
+	}
+
+	if price, ok := prices[product.ID]; ok {
+		return price, nil
+	}
+
+	return product.Price, nil
+}
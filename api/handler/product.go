@@ -5,7 +5,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"sell/api/models"
+	"sell/pkg/check"
 	"strconv"
+	"strings"
 )
 
 // CreateProduct godoc
@@ -13,6 +15,7 @@ import (
 // @Summary      Create a new product
 // @Description  create a new product
 // @Tags         product
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 product body models.CreateProduct false "sale"
@@ -28,6 +31,30 @@ func (h Handler) CreateProduct(c *gin.Context) {
 		return
 	}
 
+	if product.Unit == "" {
+		product.Unit = "piece"
+	}
+
+	if err := check.ValidateUnit(product.Unit); err != nil {
+		handleResponse(c, "error is while validating unit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if product.PLU != "" {
+		if err := check.ValidatePLU(product.PLU); err != nil {
+			handleResponse(c, "error is while validating plu", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	product.Barcode = strings.TrimSpace(product.Barcode)
+	if product.Barcode != "" {
+		if err := h.checkBarcode(context.Background(), product.Barcode, "", ""); err != nil {
+			handleResponse(c, "error is while validating barcode", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	id, err := h.storage.Product().Create(context.Background(), product)
 	if err != nil {
 		handleResponse(c, "error is while creating product", http.StatusInternalServerError, err.Error())
@@ -48,6 +75,7 @@ func (h Handler) CreateProduct(c *gin.Context) {
 // @Summary      Get product by id
 // @Description  get product by id
 // @Tags         product
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "product_id"
@@ -71,12 +99,15 @@ func (h Handler) GetProduct(c *gin.Context) {
 // @Summary      Get product list
 // @Description  get product list
 // @Tags         product
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 page query string false "page"
 // @Param 		 limit query string false "limit"
 // @Param 		 name query string false "name"
-// @Param 		 barcode query int false "barcode"
+// @Param 		 barcode query string false "any barcode of the product or its variants"
+// @Param 		 category_id query string false "category_id, includes subcategories"
+// @Param 		 branch_id query string false "branch_id, product prices from the branch price list; a non zero variant price still overrides it, as in the basket"
 // @Success      200  {object}  models.ProductResponse
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
@@ -85,7 +116,7 @@ func (h Handler) GetProductList(c *gin.Context) {
 	var (
 		page, limit int
 		name        string
-		barcode     int
+		barcode     string
 		err         error
 	)
 
@@ -105,23 +136,39 @@ func (h Handler) GetProductList(c *gin.Context) {
 
 	name = c.Query("search")
 
-	barcode, err = strconv.Atoi(c.DefaultQuery("barcode", "0"))
-	if err != nil {
-		handleResponse(c, "error is while converting barcode", http.StatusBadRequest, err.Error())
-		return
-	}
+	barcode = strings.TrimSpace(c.Query("barcode"))
 
 	products, err := h.storage.Product().GetList(context.Background(), models.ProductGetListRequest{
-		Page:    page,
-		Limit:   limit,
-		Name:    name,
-		Barcode: barcode,
+		Page:       page,
+		Limit:      limit,
+		Name:       name,
+		Barcode:    barcode,
+		CategoryID: c.Query("category_id"),
 	})
 	if err != nil {
 		handleResponse(c, "error is while getting list", http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if branchID := c.Query("branch_id"); branchID != "" {
+		productIDs := make([]string, 0, len(products.Products))
+		for _, product := range products.Products {
+			productIDs = append(productIDs, product.ID)
+		}
+
+		prices, err := h.storage.PriceList().EffectivePrices(context.Background(), branchID, productIDs)
+		if err != nil {
+			handleResponse(c, "error is while getting branch prices", http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		for i := range products.Products {
+			if price, ok := prices[products.Products[i].ID]; ok {
+				products.Products[i].Price = price
+			}
+		}
+	}
+
 	handleResponse(c, "", http.StatusOK, products)
 }
 
@@ -130,6 +177,7 @@ func (h Handler) GetProductList(c *gin.Context) {
 // @Summary      Update product
 // @Description  update
 // @Tags         product
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "product_id"
@@ -147,6 +195,22 @@ func (h Handler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	if product.Unit == "" {
+		product.Unit = "piece"
+	}
+
+	if err := check.ValidateUnit(product.Unit); err != nil {
+		handleResponse(c, "error is while validating unit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if product.PLU != "" {
+		if err := check.ValidatePLU(product.PLU); err != nil {
+			handleResponse(c, "error is while validating plu", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
 	product.ID = uid
 	id, err := h.storage.Product().Update(context.Background(), product)
 	if err != nil {
@@ -168,6 +232,7 @@ func (h Handler) UpdateProduct(c *gin.Context) {
 // @Summary      Delete product
 // @Description  delete product
 // @Tags         product
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "product_id"
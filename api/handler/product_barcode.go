@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/barcode"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateProductBarcode godoc
+// @Router       /product/{id}/barcode [POST]
+// @Summary      Add product barcode
+// @Description  add an EAN-8, EAN-13, UPC-A or internal code to the product or one of its variants, the kind is detected when empty
+// @Tags         product barcode
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Param 		 barcode body models.CreateProductBarcode true "barcode"
+// @Success      201  {object}  models.ProductBarcode
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateProductBarcode(c *gin.Context) {
+	code := models.CreateProductBarcode{}
+
+	if err := c.ShouldBindJSON(&code); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	code.ProductID = c.Param("id")
+	code.Code = strings.TrimSpace(code.Code)
+
+	if _, err := h.storage.Product().GetByID(context.Background(), code.ProductID); err != nil {
+		handleResponse(c, "error while getting product by ID", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if code.VariantID != "" {
+		variant, err := h.storage.ProductVariant().GetByID(context.Background(), models.PrimaryKey{ID: code.VariantID})
+		if err != nil || variant.ProductID != code.ProductID {
+			handleResponse(c, "error while checking variant", http.StatusBadRequest, "variant does not belong to product")
+			return
+		}
+	}
+
+	kind, err := barcode.Validate(code.Code, code.Kind)
+	if err != nil {
+		handleResponse(c, "error while validating barcode", http.StatusBadRequest, err.Error())
+		return
+	}
+	code.Kind = kind
+
+	if _, err = h.storage.ProductBarcode().GetByCode(context.Background(), code.Code); err == nil {
+		handleResponse(c, "error while validating barcode", http.StatusBadRequest, "barcode is already used")
+		return
+	}
+
+	id, err := h.storage.ProductBarcode().Create(context.Background(), code)
+	if err != nil {
+		handleResponse(c, "error while creating barcode", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdCode, err := h.storage.ProductBarcode().GetByID(context.Background(), id)
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdCode)
+}
+
+// GetProductBarcodes godoc
+// @Router       /product/{id}/barcodes [GET]
+// @Summary      Get product barcodes
+// @Description  get the codes of the product and its variants
+// @Tags         product barcode
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Success      200  {object}  []models.ProductBarcode
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductBarcodes(c *gin.Context) {
+	codes, err := h.storage.ProductBarcode().GetList(context.Background(), c.Param("id"))
+	if err != nil {
+		handleResponse(c, "error while getting barcodes", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, codes)
+}
+
+// DeleteProductBarcode godoc
+// @Router       /product-barcode/{id} [DELETE]
+// @Summary      Delete product barcode
+// @Description  delete product barcode
+// @Tags         product barcode
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "barcode_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteProductBarcode(c *gin.Context) {
+	if err := h.storage.ProductBarcode().Delete(context.Background(), c.Param("id")); err != nil {
+		handleResponse(c, "error while deleting barcode", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "barcode deleted")
+}
+
+// LookupBarcode godoc
+// @Router       /barcode/{code} [GET]
+// @Summary      Find product by barcode
+// @Description  find the product, and the variant, by any of its codes
+// @Tags         product barcode
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 code path string true "code"
+// @Success      200  {object}  models.BarcodeLookup
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) LookupBarcode(c *gin.Context) {
+	lookup, err := h.lookupBarcode(context.Background(), strings.TrimSpace(c.Param("code")))
+	if errors.Is(err, pgx.ErrNoRows) {
+		handleResponse(c, "error while finding barcode", http.StatusNotFound, "barcode not found")
+		return
+	}
+	if err != nil {
+		handleResponse(c, "error while finding barcode", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, lookup)
+}
+
+func (h Handler) lookupBarcode(ctx context.Context, code string) (models.BarcodeLookup, error) {
+	found, err := h.storage.ProductBarcode().GetByCode(ctx, code)
+	if err != nil {
+		return models.BarcodeLookup{}, err
+	}
+
+	product, err := h.storage.Product().GetByID(ctx, found.ProductID)
+	if err != nil {
+		return models.BarcodeLookup{}, err
+	}
+
+	lookup := models.BarcodeLookup{Barcode: found, Product: product}
+	if found.VariantID != "" {
+		variant, err := h.storage.ProductVariant().GetByID(ctx, models.PrimaryKey{ID: found.VariantID})
+		if err != nil {
+			return models.BarcodeLookup{}, err
+		}
+		lookup.Variant = &variant
+	}
+
+	return lookup, nil
+}
+
+// checkBarcode validates a code given with a product or variant and makes sure
+// no other product or variant uses it.
+func (h Handler) checkBarcode(ctx context.Context, code, productID, variantID string) error {
+	if _, err := barcode.Validate(code, ""); err != nil {
+		return err
+	}
+
+	found, err := h.storage.ProductBarcode().GetByCode(ctx, code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if found.ProductID != productID || found.VariantID != variantID {
+		return errors.New("barcode is already used")
+	}
+
+	return nil
+}
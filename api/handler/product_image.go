@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/blob"
+	"sell/pkg/imaging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UploadProductImage godoc
+// @Router       /product/{id}/images [POST]
+// @Summary      Upload product image
+// @Description  upload a jpeg, png or gif image to the end of the product gallery
+// @Tags         product image
+// @Security     ApiKeyAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Param 		 image formData file true "image"
+// @Success      201  {object}  models.ProductImage
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) UploadProductImage(c *gin.Context) {
+	productID := c.Param("id")
+
+	if _, err := h.storage.Product().GetByID(context.Background(), productID); err != nil {
+		handleResponse(c, "error is while getting product", http.StatusNotFound, err.Error())
+		return
+	}
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		handleResponse(c, "error is while reading image", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if fileHeader.Size > h.cfg.MaxImageSize {
+		handleResponse(c, "error is while reading image", http.StatusBadRequest, "image is too large")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		handleResponse(c, "error is while opening image", http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, h.cfg.MaxImageSize))
+	if err != nil {
+		handleResponse(c, "error is while reading image", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	contentType, err := imaging.ContentType(data)
+	if err != nil {
+		handleResponse(c, "error is while checking image type", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	thumbnail, err := imaging.Thumbnail(data, h.cfg.ThumbnailSize, h.cfg.MaxImagePixels)
+	if err != nil {
+		handleResponse(c, "error is while making thumbnail", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	image := models.CreateProductImage{
+		ID:          uuid.New().String(),
+		ProductID:   productID,
+		ContentType: contentType,
+	}
+	image.OriginalKey = "products/" + productID + "/" + image.ID + imaging.Extension(contentType)
+	image.ThumbnailKey = "products/" + productID + "/" + image.ID + "_thumb.jpg"
+
+	if err = h.blob.Put(context.Background(), image.OriginalKey, bytes.NewReader(data)); err != nil {
+		handleResponse(c, "error is while saving image", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err = h.blob.Put(context.Background(), image.ThumbnailKey, bytes.NewReader(thumbnail)); err != nil {
+		h.removeImageFiles(image.OriginalKey)
+		handleResponse(c, "error is while saving thumbnail", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := h.storage.ProductImage().Create(context.Background(), image)
+	if err != nil {
+		h.removeImageFiles(image.OriginalKey, image.ThumbnailKey)
+		handleResponse(c, "error is while creating product image", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdImage, err := h.storage.ProductImage().GetByID(context.Background(), id)
+	if err != nil {
+		handleResponse(c, "error is while getting product image", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdImage)
+}
+
+// GetProductImages godoc
+// @Router       /product/{id}/images [GET]
+// @Summary      Get product images
+// @Description  get the product gallery in order
+// @Tags         product image
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Success      200  {object}  []models.ProductImage
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductImages(c *gin.Context) {
+	images, err := h.storage.ProductImage().GetList(context.Background(), c.Param("id"))
+	if err != nil {
+		handleResponse(c, "error is while getting product images", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, images)
+}
+
+// ReorderProductImages godoc
+// @Router       /product/{id}/images/order [PUT]
+// @Summary      Reorder product images
+// @Description  set the gallery order, image_ids should list every image of the product
+// @Tags         product image
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_id"
+// @Param 		 order body models.ReorderProductImages true "order"
+// @Success      200  {object}  []models.ProductImage
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ReorderProductImages(c *gin.Context) {
+	request := models.ReorderProductImages{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error is while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, id := range request.ImageIDs {
+		if seen[id] {
+			handleResponse(c, "error is while checking image ids", http.StatusBadRequest, "image "+id+" is repeated")
+			return
+		}
+		seen[id] = true
+	}
+
+	request.ProductID = c.Param("id")
+	if err := h.storage.ProductImage().Reorder(context.Background(), request); err != nil {
+		handleResponse(c, "error is while reordering product images", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	images, err := h.storage.ProductImage().GetList(context.Background(), request.ProductID)
+	if err != nil {
+		handleResponse(c, "error is while getting product images", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, images)
+}
+
+// GetProductImageFile godoc
+// @Router       /product-image/{id} [GET]
+// @Summary      Get product image file
+// @Description  serve the original image
+// @Tags         product image
+// @Security     ApiKeyAuth
+// @Produce      octet-stream
+// @Param 		 id path string true "image_id"
+// @Success      200  {file}  file
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductImageFile(c *gin.Context) {
+	image, err := h.storage.ProductImage().GetByID(context.Background(), c.Param("id"))
+	if err != nil {
+		handleResponse(c, "error is while getting product image", http.StatusNotFound, err.Error())
+		return
+	}
+
+	h.serveImage(c, image.OriginalKey, image.ContentType)
+}
+
+// GetProductImageThumbnail godoc
+// @Router       /product-image/{id}/thumbnail [GET]
+// @Summary      Get product image thumbnail
+// @Description  serve the jpeg thumbnail of the image
+// @Tags         product image
+// @Security     ApiKeyAuth
+// @Produce      octet-stream
+// @Param 		 id path string true "image_id"
+// @Success      200  {file}  file
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductImageThumbnail(c *gin.Context) {
+	image, err := h.storage.ProductImage().GetByID(context.Background(), c.Param("id"))
+	if err != nil {
+		handleResponse(c, "error is while getting product image", http.StatusNotFound, err.Error())
+		return
+	}
+
+	h.serveImage(c, image.ThumbnailKey, "image/jpeg")
+}
+
+// DeleteProductImage godoc
+// @Router       /product-image/{id} [DELETE]
+// @Summary      Delete product image
+// @Description  delete the image and its files
+// @Tags         product image
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "image_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteProductImage(c *gin.Context) {
+	image, err := h.storage.ProductImage().GetByID(context.Background(), c.Param("id"))
+	if err != nil {
+		handleResponse(c, "error is while getting product image", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err = h.storage.ProductImage().Delete(context.Background(), image.ID); err != nil {
+		handleResponse(c, "error is while deleting product image", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.removeImageFiles(image.OriginalKey, image.ThumbnailKey)
+
+	handleResponse(c, "", http.StatusOK, "product image deleted!")
+}
+
+func (h Handler) serveImage(c *gin.Context, key, contentType string) {
+	file, err := h.blob.Get(context.Background(), key)
+	if errors.Is(err, blob.ErrNotFound) {
+		handleResponse(c, "error is while reading image file", http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		handleResponse(c, "error is while reading image file", http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer file.Close()
+
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.DataFromReader(http.StatusOK, -1, contentType, file, nil)
+}
+
+// removeImageFiles deletes stored files, failures only leave orphan files behind.
+func (h Handler) removeImageFiles(keys ...string) {
+	for _, key := range keys {
+		if err := h.blob.Delete(context.Background(), key); err != nil {
+			fmt.Println("error is while deleting image file", key, err.Error())
+		}
+	}
+}
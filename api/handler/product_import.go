@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sell/api/models"
+	"sell/pkg/barcode"
+	"sell/pkg/check"
+	"sell/pkg/sheet"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportProducts godoc
+// @Router       /products/import [POST]
+// @Summary      Import products
+// @Description  import products from a csv or xlsx file with the columns name, price, barcode, category_path, unit and stock:<branch id or name>
+// @Tags         product
+// @Security     ApiKeyAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param 		 file formData file true "csv or xlsx file"
+// @Param 		 format query string false "csv or xlsx, taken from the file extension by default"
+// @Param 		 dry_run query bool false "validate only"
+// @Success      200  {object}  models.ProductImportReport
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ImportProducts(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		handleResponse(c, "error is while reading file", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		handleResponse(c, "error is while parsing dry_run", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		handleResponse(c, "error is while opening file", http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	records, err := sheet.Read(file, format)
+	if err != nil {
+		handleResponse(c, "error is while reading file", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(records) < 2 {
+		handleResponse(c, "error is while reading file", http.StatusBadRequest, "file has no products")
+		return
+	}
+
+	branches, err := h.storage.Branch().GetList(context.Background(), models.GetListRequest{Page: 1, Limit: 1000})
+	if err != nil {
+		handleResponse(c, "error is while getting branches", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	rows, importErrors := parseProductImport(records, branches.Branches)
+	if len(importErrors) > 0 {
+		report := models.ProductImportReport{DryRun: dryRun, Rows: len(records) - 1, Errors: importErrors}
+		if dryRun {
+			handleResponse(c, "", http.StatusOK, report)
+			return
+		}
+
+		handleResponse(c, "error is while validating file", http.StatusBadRequest, report)
+		return
+	}
+
+	// managers import the catalog of every branch but the stock of their own one
+	for _, row := range rows {
+		for branchID := range row.Stock {
+			if !checkBranch(c, branchID) {
+				return
+			}
+		}
+	}
+
+	report, err := h.storage.Product().Import(context.Background(), rows, dryRun)
+	if err != nil {
+		handleResponse(c, "error is while importing products", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, report)
+}
+
+// ExportProducts godoc
+// @Router       /products/export [GET]
+// @Summary      Export products
+// @Description  export products with their category path and stock by branch
+// @Tags         product
+// @Security     ApiKeyAuth
+// @Produce      octet-stream
+// @Param 		 format query string false "csv or xlsx, csv by default"
+// @Success      200  {file}  file
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ExportProducts(c *gin.Context) {
+	format := c.DefaultQuery("format", sheet.CSV)
+	if format != sheet.CSV && format != sheet.XLSX {
+		handleResponse(c, "error is while reading format", http.StatusBadRequest, sheet.ErrUnknownFormat.Error())
+		return
+	}
+
+	products, err := h.storage.Product().Export(context.Background())
+	if err != nil {
+		handleResponse(c, "error is while exporting products", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	branches, err := h.storage.Branch().GetList(context.Background(), models.GetListRequest{Page: 1, Limit: 1000})
+	if err != nil {
+		handleResponse(c, "error is while getting branches", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	header := []string{"name", "price", "barcode", "category_path", "unit"}
+	for _, branch := range branches.Branches {
+		header = append(header, "stock:"+branch.ID)
+	}
+
+	records := [][]string{header}
+	for _, product := range products {
+		record := []string{
+			product.Name,
+			strconv.Itoa(product.Price),
+			product.Barcode,
+			product.CategoryPath,
+			product.Unit,
+		}
+		for _, branch := range branches.Branches {
+			record = append(record, strconv.FormatFloat(product.Stock[branch.ID], 'f', -1, 64))
+		}
+		records = append(records, record)
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=products.%s", format))
+	c.Header("Content-Type", sheet.ContentType(format))
+	if err = sheet.Write(c.Writer, format, records); err != nil {
+		handleResponse(c, "error is while writing file", http.StatusInternalServerError, err.Error())
+		return
+	}
+}
+
+// parseProductImport maps the records to rows by the header line and collects
+// every validation error with its line number.
+func parseProductImport(records [][]string, branches []models.Branch) ([]models.ProductImportRow, []models.ProductImportError) {
+	var (
+		rows     []models.ProductImportRow
+		errs     = []models.ProductImportError{}
+		columns  = map[string]int{}
+		stock    = map[int]string{}
+		barcodes = map[string]int{}
+	)
+
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !strings.HasPrefix(name, "stock:") {
+			columns[name] = i
+			continue
+		}
+
+		key := strings.TrimSpace(records[0][i][len("stock:"):])
+		branchID := ""
+		for _, branch := range branches {
+			if branch.ID == key || strings.EqualFold(branch.Name, key) {
+				branchID = branch.ID
+				break
+			}
+		}
+		if branchID == "" {
+			errs = append(errs, models.ProductImportError{Line: 1, Message: fmt.Sprintf("unknown branch %q", key)})
+			continue
+		}
+		stock[i] = branchID
+	}
+
+	for _, name := range []string{"name", "price", "barcode"} {
+		if _, ok := columns[name]; !ok {
+			errs = append(errs, models.ProductImportError{Line: 1, Message: fmt.Sprintf("column %s is missing", name)})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	cell := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for i, record := range records[1:] {
+		line := i + 2
+		row := models.ProductImportRow{
+			Line:         line,
+			Name:         cell(record, "name"),
+			CategoryPath: cell(record, "category_path"),
+			Unit:         cell(record, "unit"),
+			Stock:        map[string]float64{},
+		}
+		fail := func(format string, args ...interface{}) {
+			errs = append(errs, models.ProductImportError{Line: line, Message: fmt.Sprintf(format, args...)})
+		}
+
+		if row.Name == "" {
+			fail("name is required")
+		}
+
+		price, err := strconv.Atoi(cell(record, "price"))
+		if err != nil || price < 0 {
+			fail("price should be a non negative integer")
+		}
+		row.Price = price
+
+		row.Barcode = cell(record, "barcode")
+		if row.Barcode == "" {
+			fail("barcode is required")
+		} else if _, err := barcode.Validate(row.Barcode, ""); err != nil {
+			fail("barcode %s: %s", row.Barcode, err.Error())
+		} else if previous, ok := barcodes[row.Barcode]; ok {
+			fail("barcode %s is repeated from line %d", row.Barcode, previous)
+		} else {
+			barcodes[row.Barcode] = line
+		}
+
+		if row.Unit == "" {
+			row.Unit = "piece"
+		}
+		if err = check.ValidateUnit(row.Unit); err != nil {
+			fail(err.Error())
+		}
+
+		for column, branchID := range stock {
+			value := ""
+			if column < len(record) {
+				value = strings.TrimSpace(record[column])
+			}
+			if value == "" {
+				continue
+			}
+
+			count, err := strconv.ParseFloat(value, 64)
+			if err != nil || count < 0 {
+				fail("stock %q should be a non negative number", value)
+				continue
+			}
+			if err = check.ValidateQuantity(row.Unit, count); err != nil && count != 0 {
+				fail(err.Error())
+				continue
+			}
+			row.Stock[branchID] = count
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, errs
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sell/api/models"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchProducts godoc
+// @Router       /products/search [GET]
+// @Summary      Search products
+// @Description  typo tolerant product search ranked by relevance
+// @Tags         product
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 q query string false "search text"
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 category_id query string false "category_id, includes subcategories"
+// @Param 		 min_price query int false "min_price"
+// @Param 		 max_price query int false "max_price"
+// @Param 		 branch_id query string false "only products in stock at the branch"
+// @Success      200  {object}  models.ProductSearchResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) SearchProducts(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error is while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	minPrice, err := strconv.Atoi(c.DefaultQuery("min_price", "0"))
+	if err != nil {
+		handleResponse(c, "error is while converting min_price", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	maxPrice, err := strconv.Atoi(c.DefaultQuery("max_price", "0"))
+	if err != nil {
+		handleResponse(c, "error is while converting max_price", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if maxPrice > 0 && minPrice > maxPrice {
+		handleResponse(c, "error is while checking price range", http.StatusBadRequest, "min_price is more than max_price")
+		return
+	}
+
+	products, err := h.storage.Product().Search(context.Background(), models.ProductSearchRequest{
+		Page:       page,
+		Limit:      limit,
+		Query:      strings.TrimSpace(c.Query("q")),
+		CategoryID: c.Query("category_id"),
+		MinPrice:   minPrice,
+		MaxPrice:   maxPrice,
+		BranchID:   c.Query("branch_id"),
+	})
+	if err != nil {
+		handleResponse(c, "error is while searching products", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, products)
+}
+
+// SuggestProducts godoc
+// @Router       /products/suggest [GET]
+// @Summary      Suggest products
+// @Description  product names for a search box
+// @Tags         product
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 q query string true "search text"
+// @Param 		 limit query string false "limit, at most 20"
+// @Success      200  {object}  []models.ProductSuggestion
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) SuggestProducts(c *gin.Context) {
+	search := strings.TrimSpace(c.Query("q"))
+	if search == "" {
+		handleResponse(c, "", http.StatusOK, []models.ProductSuggestion{})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "8"))
+	if err != nil {
+		handleResponse(c, "error is while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if limit <= 0 || limit > 20 {
+		limit = 20
+	}
+
+	suggestions, err := h.storage.Product().Suggest(context.Background(), search, limit)
+	if err != nil {
+		handleResponse(c, "error is while getting suggestions", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, suggestions)
+}
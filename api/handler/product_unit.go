@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sell/api/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateProductUnit godoc
+// @Router       /product-unit [POST]
+// @Summary      Create a new product unit
+// @Description  create a new product unit
+// @Tags         product-unit
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 product_unit body models.CreateProductUnit false "product_unit"
+// @Success      200  {object}  models.ProductUnit
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateProductUnit(c *gin.Context) {
+	unit := models.CreateProductUnit{}
+
+	if err := c.ShouldBindJSON(&unit); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if unit.Factor <= 0 {
+		handleResponse(c, "error while validating factor", http.StatusBadRequest, "factor should be more than 0")
+		return
+	}
+
+	id, err := h.storage.ProductUnit().Create(context.Background(), unit)
+	if err != nil {
+		handleResponse(c, "error while creating product unit", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdUnit, err := h.storage.ProductUnit().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdUnit)
+}
+
+// GetProductUnit godoc
+// @Router       /product-unit/{id} [GET]
+// @Summary      Get product unit by id
+// @Description  get product unit by id
+// @Tags         product-unit
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_unit_id"
+// @Success      200  {object}  models.ProductUnit
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductUnit(c *gin.Context) {
+	uid := c.Param("id")
+
+	unit, err := h.storage.ProductUnit().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting product unit by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, unit)
+}
+
+// GetProductUnitList godoc
+// @Router       /product-units [GET]
+// @Summary      Get product unit list
+// @Description  get product unit list
+// @Tags         product-unit
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 product_id query string false "product_id"
+// @Success      200  {object}  models.ProductUnitsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductUnitList(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := h.storage.ProductUnit().GetList(context.Background(), models.GetListRequest{
+		Page:   page,
+		Limit:  limit,
+		Search: c.Query("product_id"),
+	})
+	if err != nil {
+		handleResponse(c, "error while getting product unit list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// UpdateProductUnit godoc
+// @Router       /product-unit/{id} [PUT]
+// @Summary      Update product unit
+// @Description  update product unit
+// @Tags         product-unit
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_unit_id"
+// @Param 		 product_unit body models.UpdateProductUnit false "product_unit"
+// @Success      200  {object}  models.ProductUnit
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) UpdateProductUnit(c *gin.Context) {
+	uid := c.Param("id")
+
+	unit := models.UpdateProductUnit{}
+	if err := c.ShouldBindJSON(&unit); err != nil {
+		handleResponse(c, "error while reading from body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if unit.Factor <= 0 {
+		handleResponse(c, "error while validating factor", http.StatusBadRequest, "factor should be more than 0")
+		return
+	}
+
+	unit.ID = uid
+	if _, err := h.storage.ProductUnit().Update(context.Background(), unit); err != nil {
+		handleResponse(c, "error while updating product unit ", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	updatedUnit, err := h.storage.ProductUnit().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, updatedUnit)
+}
+
+// DeleteProductUnit godoc
+// @Router       /product-unit/{id} [DELETE]
+// @Summary      Delete product unit
+// @Description  delete product unit
+// @Tags         product-unit
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_unit_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteProductUnit(c *gin.Context) {
+	uid := c.Param("id")
+
+	if err := h.storage.ProductUnit().Delete(context.Background(), uid); err != nil {
+		handleResponse(c, "error while deleting product unit ", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "product unit deleted")
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sell/api/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateProductVariant godoc
+// @Router       /product-variant [POST]
+// @Summary      Create a new product variant
+// @Description  create a new product variant
+// @Tags         product-variant
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 product_variant body models.CreateProductVariant false "product_variant"
+// @Success      200  {object}  models.ProductVariant
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateProductVariant(c *gin.Context) {
+	variant := models.CreateProductVariant{}
+
+	if err := c.ShouldBindJSON(&variant); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if variant.Price < 0 {
+		handleResponse(c, "error while validating price", http.StatusBadRequest, "price should not be negative")
+		return
+	}
+
+	if variant.Barcode != "" {
+		if err := h.checkBarcode(context.Background(), variant.Barcode, variant.ProductID, ""); err != nil {
+			handleResponse(c, "error while validating barcode", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	id, err := h.storage.ProductVariant().Create(context.Background(), variant)
+	if err != nil {
+		handleResponse(c, "error while creating product variant", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdVariant, err := h.storage.ProductVariant().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdVariant)
+}
+
+// GetProductVariant godoc
+// @Router       /product-variant/{id} [GET]
+// @Summary      Get product variant by id
+// @Description  get product variant by id
+// @Tags         product-variant
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_variant_id"
+// @Success      200  {object}  models.ProductVariant
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductVariant(c *gin.Context) {
+	uid := c.Param("id")
+
+	variant, err := h.storage.ProductVariant().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting product variant by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, variant)
+}
+
+// GetProductVariantList godoc
+// @Router       /product-variants [GET]
+// @Summary      Get product variant list
+// @Description  get product variant list
+// @Tags         product-variant
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 product_id query string false "product_id"
+// @Success      200  {object}  models.ProductVariantsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetProductVariantList(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := h.storage.ProductVariant().GetList(context.Background(), models.GetListRequest{
+		Page:   page,
+		Limit:  limit,
+		Search: c.Query("product_id"),
+	})
+	if err != nil {
+		handleResponse(c, "error while getting product variant list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// UpdateProductVariant godoc
+// @Router       /product-variant/{id} [PUT]
+// @Summary      Update product variant
+// @Description  update product variant
+// @Tags         product-variant
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_variant_id"
+// @Param 		 product_variant body models.UpdateProductVariant false "product_variant"
+// @Success      200  {object}  models.ProductVariant
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) UpdateProductVariant(c *gin.Context) {
+	uid := c.Param("id")
+
+	variant := models.UpdateProductVariant{}
+	if err := c.ShouldBindJSON(&variant); err != nil {
+		handleResponse(c, "error while reading from body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if variant.Price < 0 {
+		handleResponse(c, "error while validating price", http.StatusBadRequest, "price should not be negative")
+		return
+	}
+
+	if variant.Barcode != "" {
+		if err := h.checkBarcode(context.Background(), variant.Barcode, variant.ProductID, uid); err != nil {
+			handleResponse(c, "error while validating barcode", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	variant.ID = uid
+	if _, err := h.storage.ProductVariant().Update(context.Background(), variant); err != nil {
+		handleResponse(c, "error while updating product variant ", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	updatedVariant, err := h.storage.ProductVariant().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, updatedVariant)
+}
+
+// DeleteProductVariant godoc
+// @Router       /product-variant/{id} [DELETE]
+// @Summary      Delete product variant
+// @Description  delete product variant
+// @Tags         product-variant
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "product_variant_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteProductVariant(c *gin.Context) {
+	uid := c.Param("id")
+
+	if err := h.storage.ProductVariant().Delete(context.Background(), uid); err != nil {
+		handleResponse(c, "error while deleting product variant ", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "product variant deleted")
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Require lets the request through when the role of the acting staff has the permission.
+// It runs after AuthMiddleware.
+func (h Handler) Require(permission rbac.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !rbac.Allowed(actingStaff(c).StaffType, permission) {
+			handleResponse(c, "error while checking permission", http.StatusForbidden, "staff is not allowed to do this")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// checkBranch answers 403 when the acting staff works in another branch, owners pass for every branch.
+func checkBranch(c *gin.Context, branchID string) bool {
+	staff := actingStaff(c)
+	if rbac.AllBranches(staff.StaffType) || (staff.BranchID != "" && staff.BranchID == branchID) {
+		return true
+	}
+
+	handleResponse(c, "error while checking branch", http.StatusForbidden, "staff can only work with their own branch")
+	return false
+}
+
+// checkSaleBranch is checkBranch for the branch of a sale.
+func (h Handler) checkSaleBranch(c *gin.Context, saleID string) bool {
+	sale, err := h.storage.Sale().GetByID(context.Background(), saleID)
+	if err != nil {
+		handleResponse(c, "error while getting sale by id", http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	return checkBranch(c, sale.BranchID)
+}
+
+// checkBasketBranch is checkBranch for the branch of the sale a basket belongs to.
+func (h Handler) checkBasketBranch(c *gin.Context, basketID string) bool {
+	basket, err := h.storage.Basket().GetByID(context.Background(), models.PrimaryKey{ID: basketID})
+	if err != nil {
+		handleResponse(c, "error while getting basket by ID", http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	return h.checkSaleBranch(c, basket.SaleID)
+}
+
+// checkRepositoryBranch is checkBranch for the branch of a repository.
+func (h Handler) checkRepositoryBranch(c *gin.Context, repositoryID string) bool {
+	repository, err := h.storage.Repository().GetByID(context.Background(), models.PrimaryKey{ID: repositoryID})
+	if err != nil {
+		handleResponse(c, "error while getting repository by ID", http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	return checkBranch(c, repository.BranchID)
+}
+
+// checkRTransactionBranch is checkBranch for the branch of a repository transaction.
+func (h Handler) checkRTransactionBranch(c *gin.Context, rTransactionID string) bool {
+	rTransaction, err := h.storage.RTransaction().GetByID(context.Background(), models.PrimaryKey{ID: rTransactionID})
+	if err != nil {
+		handleResponse(c, "error while getting repository transaction by ID", http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	return checkBranch(c, rTransaction.BranchID)
+}
+
+// checkStaffAccess answers 403 unless the acting staff is the staff itself or may manage them.
+// Managers manage the cashiers and shop assistants of their branch, owners manage everybody.
+func (h Handler) checkStaffAccess(c *gin.Context, staffID string, allowSelf bool) bool {
+	acting := actingStaff(c)
+	if allowSelf && acting.ID == staffID {
+		return true
+	}
+
+	if !rbac.Allowed(acting.StaffType, rbac.ManageStaff) {
+		handleResponse(c, "error while checking permission", http.StatusForbidden, "staff is not allowed to do this")
+		return false
+	}
+
+	if rbac.AllBranches(acting.StaffType) {
+		return true
+	}
+
+	staff, err := h.storage.Staff().StaffByID(context.Background(), models.PrimaryKey{ID: staffID})
+	if err != nil {
+		handleResponse(c, "error while getting staff by ID", http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	return checkStaffType(c, staff.StaffType) && checkBranch(c, staff.BranchID)
+}
+
+// checkStaffType answers 403 when a manager hands out a role only owners can give.
+func checkStaffType(c *gin.Context, staffType string) bool {
+	if rbac.AllBranches(actingStaff(c).StaffType) || staffType == rbac.Cashier || staffType == rbac.ShopAssistant {
+		return true
+	}
+
+	handleResponse(c, "error while checking staff type", http.StatusForbidden, "only owners manage owners and branch managers")
+	return false
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// RefundSale godoc
+// @Router       /sale/{id}/refund [PUT]
+// @Summary      refund sale
+// @Description  cancel a finished sale, put its products back in stock, take the commissions back and post the refund to the general ledger
+// @Tags         sell
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "sale_id"
+// @Success      200  {object}  models.Sale
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) RefundSale(c *gin.Context) {
+	saleID := c.Param("id")
+
+	if !h.checkSaleBranch(c, saleID) {
+		return
+	}
+
+	_, movements, err := h.saleMovements(context.Background(), saleID)
+	if err != nil {
+		handleResponse(c, "error is while getting sale movements", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	err = h.storage.Sale().Refund(context.Background(), models.RefundSale{
+		ID:        saleID,
+		StaffID:   actingStaff(c).ID,
+		Movements: movements,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error is while refunding sale", http.StatusBadRequest, "only finished sales can be refunded")
+			return
+		}
+		if errors.Is(err, storage.ErrNotEnoughBalance) {
+			handleResponse(c, "error is while refunding sale", http.StatusBadRequest, "the commission of the sale was already paid out: "+err.Error())
+			return
+		}
+		handleResponse(c, "error is while refunding sale", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp, err := h.storage.Sale().GetByID(context.Background(), saleID)
+	if err != nil {
+		handleResponse(c, "error is while getting sale by id", http.StatusInternalServerError, err.Error())
+		return
+	}
+	handleResponse(c, "success", http.StatusOK, resp)
+}
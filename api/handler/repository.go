@@ -14,11 +14,13 @@ import (
 // @Summary      Create a new repository
 // @Description  create a new repository
 // @Tags         repository
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 repository body models.CreateRepository false "repository"
 // @Success      200  {object}  models.Repository
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) CreateRepository(c *gin.Context) {
@@ -29,6 +31,16 @@ func (h Handler) CreateRepository(c *gin.Context) {
 		return
 	}
 
+	if !checkBranch(c, repository.BranchID) {
+		return
+	}
+
+	if repository.Count <= 0 {
+		handleResponse(c, "error while validating repository", http.StatusBadRequest, "count should be positive")
+		return
+	}
+
+	repository.StaffID = actingStaff(c).ID
 	id, err := h.storage.Repository().Create(context.Background(), repository)
 	if err != nil {
 		handleResponse(c, "error while creating repository", http.StatusInternalServerError, err.Error())
@@ -51,6 +63,7 @@ func (h Handler) CreateRepository(c *gin.Context) {
 // @Summary      Get repository by id
 // @Description  get repository by id
 // @Tags         repository
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "repository_id"
@@ -75,6 +88,7 @@ func (h Handler) GetRepository(c *gin.Context) {
 // @Summary      Get repository list
 // @Description  get repository list
 // @Tags         repository
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 page query string false "page"
@@ -124,12 +138,14 @@ func (h Handler) GetRepositoryList(c *gin.Context) {
 // @Summary      Update repository
 // @Description  get repository
 // @Tags         repository
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "repository_id"
 // @Param 		 repository body models.UpdateRepository false "repository"
 // @Success      200  {object}  models.Repository
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateRepository(c *gin.Context) {
@@ -141,7 +157,17 @@ func (h Handler) UpdateRepository(c *gin.Context) {
 		return
 	}
 
+	if !h.checkRepositoryBranch(c, uid) || !checkBranch(c, repository.BranchID) {
+		return
+	}
+
+	if repository.Count < 0 {
+		handleResponse(c, "error while validating repository", http.StatusBadRequest, "count should not be negative")
+		return
+	}
+
 	repository.ID = uid
+	repository.StaffID = actingStaff(c).ID
 	if _, err := h.storage.Repository().Update(context.Background(), repository); err != nil {
 		handleResponse(c, "error while updating repository ", http.StatusInternalServerError, err.Error())
 		return
@@ -161,16 +187,22 @@ func (h Handler) UpdateRepository(c *gin.Context) {
 // @Summary      Delete repository
 // @Description  delete repository
 // @Tags         repository
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "repository_id"
 // @Success      200  {object}  models.Response
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteRepository(c *gin.Context) {
 	uid := c.Param("id")
 
+	if !h.checkRepositoryBranch(c, uid) {
+		return
+	}
+
 	if err := h.storage.Repository().Delete(context.Background(), uid); err != nil {
 		handleResponse(c, "error while deleting repository ", http.StatusInternalServerError, err.Error())
 		return
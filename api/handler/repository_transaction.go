@@ -14,11 +14,13 @@ import (
 // @Summary      Create a new rtransaction
 // @Description  create a new rtransaction
 // @Tags         rtransaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 rtransaction body models.CreateRepositoryTransaction false "rtransaction"
 // @Success      200  {object}  models.RepositoryTransaction
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) CreateRepositoryTransaction(c *gin.Context) {
@@ -29,6 +31,10 @@ func (h Handler) CreateRepositoryTransaction(c *gin.Context) {
 		return
 	}
 
+	if !checkBranch(c, rtransaction.BranchID) {
+		return
+	}
+
 	id, err := h.storage.RTransaction().Create(context.Background(), rtransaction)
 	if err != nil {
 		handleResponse(c, "error while creating repository transaction", http.StatusInternalServerError, err.Error())
@@ -51,6 +57,7 @@ func (h Handler) CreateRepositoryTransaction(c *gin.Context) {
 // @Summary      Get rtransaction by id
 // @Description  get rtransaction by id
 // @Tags         rtransaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "rtransaction_id"
@@ -75,6 +82,7 @@ func (h Handler) GetRepositoryTransaction(c *gin.Context) {
 // @Summary      Get rtransaction list
 // @Description  get rtransaction list
 // @Tags         rtransaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 page query string false "page"
@@ -124,12 +132,14 @@ func (h Handler) GetRepositoryTransactionList(c *gin.Context) {
 // @Summary      Update rtransaction
 // @Description  get rtransaction
 // @Tags         rtransaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "rtransaction_id"
 // @Param 		 rtransaction body models.UpdateRepositoryTransaction false "rtransaction"
 // @Success      200  {object}  models.Repository
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateRepositoryTransaction(c *gin.Context) {
@@ -141,6 +151,10 @@ func (h Handler) UpdateRepositoryTransaction(c *gin.Context) {
 		return
 	}
 
+	if !h.checkRTransactionBranch(c, uid) || !checkBranch(c, rTransaction.BranchID) {
+		return
+	}
+
 	rTransaction.ID = uid
 	if _, err := h.storage.RTransaction().Update(context.Background(), rTransaction); err != nil {
 		handleResponse(c, "error while updating repository transaction ", http.StatusInternalServerError, err.Error())
@@ -161,16 +175,22 @@ func (h Handler) UpdateRepositoryTransaction(c *gin.Context) {
 // @Summary      Delete rtransaction
 // @Description  delete rtransaction
 // @Tags         rtransaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "rtransaction_id"
 // @Success      200  {object}  models.Response
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteRepositoryTransaction(c *gin.Context) {
 	uid := c.Param("id")
 
+	if !h.checkRTransactionBranch(c, uid) {
+		return
+	}
+
 	if err := h.storage.RTransaction().Delete(context.Background(), uid); err != nil {
 		handleResponse(c, "error while deleting repository transaction ", http.StatusInternalServerError, err.Error())
 		return
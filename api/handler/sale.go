@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"net/http"
 	"sell/api/models"
 	"strconv"
@@ -13,11 +15,13 @@ import (
 // @Summary      Create a new sale
 // @Description  create a new sale
 // @Tags         sale
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 sale body models.CreateSale false "sale"
 // @Success      200  {object}  models.Sale
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) CreateSale(c *gin.Context) {
@@ -27,6 +31,10 @@ func (h Handler) CreateSale(c *gin.Context) {
 		return
 	}
 
+	if !checkBranch(c, sale.BranchID) {
+		return
+	}
+
 	id, err := h.storage.Sale().Create(context.Background(), sale)
 	if err != nil {
 		handleResponse(c, "error is while creating sale", http.StatusInternalServerError, err.Error())
@@ -52,6 +60,7 @@ func (h Handler) CreateSale(c *gin.Context) {
 // @Summary      Get sale by id
 // @Description  get sale by id
 // @Tags         sale
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "sale_id"
@@ -76,6 +85,7 @@ func (h Handler) GetSale(c *gin.Context) {
 // @Summary      Get sale list
 // @Description  get sale list
 // @Tags         sale
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 page query string false "page"
@@ -120,14 +130,16 @@ func (h Handler) GetSaleList(c *gin.Context) {
 // UpdateSale godoc
 // @Router       /sale/{id} [PUT]
 // @Summary      Update sale
-// @Description  update sale
+// @Description  update the details of a sale in process, its price and status follow ending and refunding it
 // @Tags         sale
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "sale_id"
 // @Param 		 sale body models.UpdateSale false "sale"
 // @Success      200  {object}  models.Response
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateSale(c *gin.Context) {
@@ -139,9 +151,17 @@ func (h Handler) UpdateSale(c *gin.Context) {
 		return
 	}
 
+	if !h.checkSaleBranch(c, uid) || !checkBranch(c, sale.BranchID) {
+		return
+	}
+
 	sale.ID = uid
 	id, err := h.storage.Sale().Update(context.Background(), sale)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error is while updating sale", http.StatusBadRequest, "only sales in process can be updated")
+			return
+		}
 		handleResponse(c, "error is while updating sale", http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -160,15 +180,21 @@ func (h Handler) UpdateSale(c *gin.Context) {
 // @Summary      Delete sale
 // @Description  delete sale
 // @Tags         sale
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "sale_id"
 // @Success      200  {object}  models.Sale
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteSale(c *gin.Context) {
 	uid := c.Param("id")
+	if !h.checkSaleBranch(c, uid) {
+		return
+	}
+
 	if err := h.storage.Sale().Delete(context.Background(), uid); err != nil {
 		handleResponse(c, "error is while deleting", http.StatusInternalServerError, err.Error())
 		return
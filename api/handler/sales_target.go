@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/rbac"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateSalesTarget godoc
+// @Router       /sales-target [POST]
+// @Summary      Create sales target
+// @Description  create a monthly sales target of a staff or of a branch, every tier reached when the month closes pays its bonus
+// @Description  a branch tier pays every staff who was the cashier or the shop assistant of a completed sale of the branch that month
+// @Tags         sales-target
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 sales_target body models.CreateSalesTarget true "sales_target"
+// @Success      201  {object}  models.SalesTarget
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateSalesTarget(c *gin.Context) {
+	target := models.CreateSalesTarget{}
+
+	if err := c.ShouldBindJSON(&target); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validateSalesTarget(target); err != nil {
+		handleResponse(c, "error while validating sales target", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !h.checkSalesTargetAccess(c, target.StaffID, target.BranchID) {
+		return
+	}
+	target.CreatedBy = actingStaff(c).ID
+
+	id, err := h.storage.SalesTarget().Create(context.Background(), target)
+	if err != nil {
+		handleResponse(c, "error while creating sales target", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdTarget, err := h.storage.SalesTarget().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting sales target by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdTarget)
+}
+
+// GetSalesTarget godoc
+// @Router       /sales-target/{id} [GET]
+// @Summary      Get sales target by id
+// @Description  get sales target with its tiers
+// @Tags         sales-target
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "sales_target_id"
+// @Success      200  {object}  models.SalesTarget
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetSalesTarget(c *gin.Context) {
+	target, ok := h.salesTarget(c)
+	if !ok {
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, target)
+}
+
+// GetSalesTargetList godoc
+// @Router       /sales-targets [GET]
+// @Summary      Get sales target list
+// @Description  get sales targets, managers see the targets of their branch and of its staff
+// @Tags         sales-target
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 month query string false "month in YYYY-MM format"
+// @Param 		 staff_id query string false "staff_id"
+// @Param 		 branch_id query string false "branch_id"
+// @Success      200  {object}  models.SalesTargetsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetSalesTargetList(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	month := c.Query("month")
+	if _, err := time.Parse("2006-01", month); month != "" && err != nil {
+		handleResponse(c, "error while parsing month", http.StatusBadRequest, "month should be in YYYY-MM format")
+		return
+	}
+
+	branchID := c.Query("branch_id")
+	if acting := actingStaff(c); branchID == "" && !rbac.AllBranches(acting.StaffType) {
+		branchID = acting.BranchID
+	}
+
+	if !checkBranch(c, branchID) {
+		return
+	}
+
+	response, err := h.storage.SalesTarget().GetList(context.Background(), models.SalesTargetGetListRequest{
+		Page:     page,
+		Limit:    limit,
+		Month:    month,
+		StaffID:  c.Query("staff_id"),
+		BranchID: branchID,
+	})
+	if err != nil {
+		handleResponse(c, "error while getting sales target list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// DeleteSalesTarget godoc
+// @Router       /sales-target/{id} [DELETE]
+// @Summary      Delete sales target
+// @Description  delete sales target that has not paid any bonus yet
+// @Tags         sales-target
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "sales_target_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteSalesTarget(c *gin.Context) {
+	target, ok := h.salesTarget(c)
+	if !ok {
+		return
+	}
+
+	if err := h.storage.SalesTarget().Delete(context.Background(), target.ID); err != nil {
+		handleResponse(c, "error while deleting sales target", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "sales target deleted")
+}
+
+// GetSalesTargetProgress godoc
+// @Router       /sales-targets/progress [GET]
+// @Summary      Get sales target progress
+// @Description  get the completed sales of the month against the tiers of the staff and branch targets
+// @Description  staff see their own progress, managers see the progress of their branch with branch_id
+// @Tags         sales-target
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 month query string false "month in YYYY-MM format, current month by default"
+// @Param 		 staff_id query string false "staff_id, the acting staff by default"
+// @Param 		 branch_id query string false "branch_id"
+// @Success      200  {array}   models.SalesTargetProgress
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetSalesTargetProgress(c *gin.Context) {
+	request := models.SalesTargetProgressRequest{
+		Month:    c.DefaultQuery("month", time.Now().Format("2006-01")),
+		StaffID:  c.Query("staff_id"),
+		BranchID: c.Query("branch_id"),
+	}
+
+	if _, err := time.Parse("2006-01", request.Month); err != nil {
+		handleResponse(c, "error while parsing month", http.StatusBadRequest, "month should be in YYYY-MM format")
+		return
+	}
+
+	acting := actingStaff(c)
+	if request.BranchID != "" && request.StaffID == "" {
+		if !rbac.Allowed(acting.StaffType, rbac.ManagePayroll) {
+			handleResponse(c, "error while checking permission", http.StatusForbidden, "staff is not allowed to do this")
+			return
+		}
+
+		if !checkBranch(c, request.BranchID) {
+			return
+		}
+	} else {
+		if request.StaffID == "" {
+			request.StaffID = acting.ID
+		}
+
+		if !h.checkStaffAccess(c, request.StaffID, true) {
+			return
+		}
+		request.BranchID = ""
+	}
+
+	progress, err := h.storage.SalesTarget().Progress(context.Background(), request)
+	if err != nil {
+		handleResponse(c, "error while getting sales target progress", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, progress)
+}
+
+// salesTarget reads the target of the id param when the acting staff may manage it.
+func (h Handler) salesTarget(c *gin.Context) (models.SalesTarget, bool) {
+	target, err := h.storage.SalesTarget().GetByID(context.Background(), models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while getting sales target by ID", http.StatusNotFound, "sales target not found")
+			return models.SalesTarget{}, false
+		}
+		handleResponse(c, "error while getting sales target by ID", http.StatusInternalServerError, err.Error())
+		return models.SalesTarget{}, false
+	}
+
+	if !h.checkSalesTargetAccess(c, target.StaffID, target.BranchID) {
+		return models.SalesTarget{}, false
+	}
+
+	return target, true
+}
+
+// checkSalesTargetAccess is checkStaffAccess for a staff target and checkBranch for a branch target.
+func (h Handler) checkSalesTargetAccess(c *gin.Context, staffID, branchID string) bool {
+	if staffID != "" {
+		return h.checkStaffAccess(c, staffID, false)
+	}
+
+	return checkBranch(c, branchID)
+}
+
+func validateSalesTarget(target models.CreateSalesTarget) error {
+	if (target.StaffID == "") == (target.BranchID == "") {
+		return errors.New("sales target should have either staff_id or branch_id")
+	}
+
+	if _, err := time.Parse("2006-01", target.Month); err != nil {
+		return errors.New("month should be in YYYY-MM format")
+	}
+
+	if len(target.Tiers) == 0 {
+		return errors.New("sales target should have at least one tier")
+	}
+
+	thresholds := map[float64]bool{}
+	for _, tier := range target.Tiers {
+		if tier.Threshold <= 0 || tier.Bonus <= 0 {
+			return errors.New("tier threshold and bonus should be positive")
+		}
+
+		if thresholds[tier.Threshold] {
+			return errors.New("tier thresholds should be unique")
+		}
+		thresholds[tier.Threshold] = true
+	}
+
+	return nil
+}
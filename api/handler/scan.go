@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/scale"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// ScanBasket godoc
+// @Router       /sale/{id}/scan [POST]
+// @Summary      Scan a code into the sale
+// @Description  add the product of a barcode, or of an in-store scale label with its weight, count or price, to the sale basket
+// @Tags         basket
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "sale_id"
+// @Param 		 scan body models.ScanBasket true "scan"
+// @Success      201  {object}  models.Basket
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ScanBasket(c *gin.Context) {
+	scan := models.ScanBasket{}
+
+	if err := c.ShouldBindJSON(&scan); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scan.SaleID = c.Param("id")
+	scan.Code = strings.TrimSpace(scan.Code)
+
+	if !h.checkSaleBranch(c, scan.SaleID) {
+		return
+	}
+
+	basket, err := h.scannedBasket(context.Background(), scan)
+	if err != nil {
+		handleBasketError(c, err)
+		return
+	}
+
+	responseBasket, err := h.addToBasket(basket)
+	if err != nil {
+		handleBasketError(c, err)
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, responseBasket)
+}
+
+// scannedBasket turns a scanned code into a basket item. Registered barcodes
+// win over scale labels, so an in-store code can still be assigned to a product.
+func (h Handler) scannedBasket(ctx context.Context, scan models.ScanBasket) (models.CreateBasket, error) {
+	basket := models.CreateBasket{SaleID: scan.SaleID, Quantity: scan.Quantity}
+	if basket.Quantity == 0 {
+		basket.Quantity = 1
+	}
+
+	lookup, err := h.lookupBarcode(ctx, scan.Code)
+	if err == nil {
+		basket.ProductID = lookup.Product.ID
+		if lookup.Variant != nil {
+			basket.VariantID = lookup.Variant.ID
+		}
+		return basket, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return scanFail(http.StatusInternalServerError, "error while finding barcode", err)
+	}
+
+	parser, err := scale.NewParser(h.cfg.ScaleBarcodes)
+	if err != nil {
+		return scanFail(http.StatusInternalServerError, "error while reading scale rules", err)
+	}
+
+	label, ok, err := parser.Parse(scan.Code)
+	if err != nil {
+		return scanFail(http.StatusBadRequest, "error while reading scale label", err)
+	}
+	if !ok {
+		return scanFail(http.StatusNotFound, "error while finding barcode", errors.New("barcode not found"))
+	}
+
+	product, err := h.storage.Product().GetByPLU(ctx, label.PLU)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return scanFail(http.StatusNotFound, "error while finding plu", errors.New("no product with plu "+label.PLU))
+	}
+	if err != nil {
+		return scanFail(http.StatusInternalServerError, "error while finding plu", err)
+	}
+	basket.ProductID = product.ID
+
+	switch label.Kind {
+	case scale.Weight:
+		if product.Unit == "piece" {
+			return scanFail(http.StatusBadRequest, "error while reading scale label", errors.New("product is sold by piece, not by weight"))
+		}
+		basket.Quantity = label.Value
+	case scale.Count:
+		basket.Quantity = label.Value
+	case scale.Price:
+		sale, err := h.storage.Sale().GetByID(ctx, scan.SaleID)
+		if err != nil {
+			return scanFail(http.StatusInternalServerError, "error is while getting sale by id", err)
+		}
+
+		unitPrice, err := h.branchPrice(ctx, sale.BranchID, product)
+		if err != nil {
+			return scanFail(http.StatusInternalServerError, "error is while getting branch price", err)
+		}
+		if unitPrice <= 0 {
+			return scanFail(http.StatusBadRequest, "error while reading scale label", errors.New("product has no price to take the quantity from"))
+		}
+
+		// the label price is charged as printed, the quantity only moves the stock
+		basket.Price = label.Value
+		basket.Quantity = math.Round(label.Value/float64(unitPrice)*1000) / 1000
+		if product.Unit == "piece" {
+			basket.Quantity = math.Max(1, math.Round(basket.Quantity))
+		}
+	}
+
+	return basket, nil
+}
+
+func scanFail(status int, message string, err error) (models.CreateBasket, error) {
+	return models.CreateBasket{}, basketError{status: status, message: message, err: err}
+}
@@ -4,8 +4,9 @@ import (
 	"context"
 	"net/http"
 	"sell/api/models"
-	"sell/pkg/check"
+	"sell/pkg/rbac"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -16,11 +17,13 @@ import (
 // @Summary      Create a new staff
 // @Description  create a new staff
 // @Tags         staff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 staff body models.CreateStaff false "staff"
 // @Success      200  {object}  models.Staff
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) CreateStaff(c *gin.Context) {
@@ -31,6 +34,15 @@ func (h Handler) CreateStaff(c *gin.Context) {
 		return
 	}
 
+	if !checkStaffType(c, staff.StaffType) || !checkBranch(c, staff.BranchID) {
+		return
+	}
+
+	if err := h.validateNewPassword(context.Background(), "", staff.Password); err != nil {
+		handleResponse(c, "password is weak", http.StatusBadRequest, err.Error())
+		return
+	}
+
 	id, err := h.storage.Staff().Create(context.Background(), staff)
 
 	if err != nil {
@@ -52,16 +64,22 @@ func (h Handler) CreateStaff(c *gin.Context) {
 // @Summary      Get staff by id
 // @Description  get staff by id
 // @Tags         staff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "staff_id"
 // @Success      200  {object}  models.Staff
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) GetStaff(c *gin.Context) {
 	uid := c.Param("id")
 
+	if !h.checkStaffAccess(c, uid, true) {
+		return
+	}
+
 	staffTarif, err := h.storage.Staff().StaffByID(context.Background(), models.PrimaryKey{ID: uid})
 	if err != nil {
 		handleResponse(c, "error while getting staff  by ID", http.StatusInternalServerError, err.Error())
@@ -76,13 +94,16 @@ func (h Handler) GetStaff(c *gin.Context) {
 // @Summary      Get staff list
 // @Description  get staff list
 // @Tags         staff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 page query string false "page"
 // @Param 		 limit query string false "limit"
 // @Param 		 search query string false "search"
+// @Param 		 branch_id query string false "branch_id, managers only list their own branch"
 // @Success      200  {object}  models.StaffsResponse
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) GetStaffList(c *gin.Context) {
@@ -107,10 +128,20 @@ func (h Handler) GetStaffList(c *gin.Context) {
 
 	search := c.Query("search")
 
-	response, err := h.storage.Staff().GetStaffTList(context.Background(), models.GetListRequest{
-		Page:   page,
-		Limit:  limit,
-		Search: search,
+	branchID := c.Query("branch_id")
+	if acting := actingStaff(c); branchID == "" && !rbac.AllBranches(acting.StaffType) {
+		branchID = acting.BranchID
+	}
+
+	if !validIDs(c, branchID) || !checkBranch(c, branchID) {
+		return
+	}
+
+	response, err := h.storage.Staff().GetStaffTList(context.Background(), models.StaffGetListRequest{
+		Page:     page,
+		Limit:    limit,
+		Search:   search,
+		BranchID: branchID,
 	})
 	if err != nil {
 		handleResponse(c, "error while getting staff list", http.StatusInternalServerError, err.Error())
@@ -123,14 +154,16 @@ func (h Handler) GetStaffList(c *gin.Context) {
 // UpdateStaff godoc
 // @Router       /staff/{id} [PUT]
 // @Summary      Update staff
-// @Description  get staff
+// @Description  update staff, a new branch or tariff is recorded in the assignment history from now on
 // @Tags         staff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "staff_id"
 // @Param 		 staff body models.UpdateStaff false "staff"
 // @Success      200  {object}  models.Staff
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateStaff(c *gin.Context) {
@@ -142,7 +175,12 @@ func (h Handler) UpdateStaff(c *gin.Context) {
 		return
 	}
 
+	if !h.checkStaffAccess(c, uid, false) || !checkStaffType(c, staff.StaffType) || !checkBranch(c, staff.BranchID) {
+		return
+	}
+
 	staff.ID = uid
+	staff.ChangedBy = actingStaff(c).ID
 	if _, err := h.storage.Staff().UpdateStaff(context.Background(), staff); err != nil {
 		handleResponse(c, "error while updating staff ", http.StatusInternalServerError, err.Error())
 		return
@@ -162,21 +200,32 @@ func (h Handler) UpdateStaff(c *gin.Context) {
 // @Summary      Delete staff
 // @Description  delete staff
 // @Tags         staff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "staff_id"
 // @Success      200  {object}  models.Response
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) DeleteStaff(c *gin.Context) {
 	uid := c.Param("id")
 
+	if !h.checkStaffAccess(c, uid, false) {
+		return
+	}
+
 	if err := h.storage.Staff().DeleteStaff(context.Background(), uid); err != nil {
 		handleResponse(c, "error while deleting staff ", http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if err := h.storage.RefreshToken().RevokeByStaff(context.Background(), uid); err != nil {
+		handleResponse(c, "error while revoking refresh tokens", http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	handleResponse(c, "", http.StatusOK, "staff deleted")
 }
 
@@ -185,13 +234,16 @@ func (h Handler) DeleteStaff(c *gin.Context) {
 // @Summary      Update staff password
 // @Description  update staff password
 // @Tags         staff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "staff_id"
 // @Param        staff body models.UpdateStaffPassword true "staff"
 // @Success      200  {object}  models.Response
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      423  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) UpdateStaffPassword(c *gin.Context) {
 	updateStaffPassword := models.UpdateStaffPassword{}
@@ -209,18 +261,21 @@ func (h Handler) UpdateStaffPassword(c *gin.Context) {
 
 	updateStaffPassword.ID = uid.String()
 
-	oldPassword, err := h.storage.Staff().GetPassword(context.Background(), updateStaffPassword.ID)
+	if !h.checkStaffAccess(c, updateStaffPassword.ID, true) {
+		return
+	}
+
+	credentials, err := h.storage.Staff().GetCredentials(context.Background(), updateStaffPassword.ID)
 	if err != nil {
 		handleResponse(c, "error while getting password by id", http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	if oldPassword != updateStaffPassword.OldPassword {
-		handleResponse(c, "old password is not correct", http.StatusBadRequest, "old password is not correct")
+	if !h.checkPassword(c, credentials, updateStaffPassword.OldPassword, http.StatusBadRequest, "old password is not correct") {
 		return
 	}
 
-	if err = check.ValidatePassword(updateStaffPassword.NewPassword); err != nil {
+	if err = h.validateNewPassword(context.Background(), updateStaffPassword.ID, updateStaffPassword.NewPassword); err != nil {
 		handleResponse(c, "new password is weak", http.StatusBadRequest, err.Error())
 		return
 	}
@@ -230,5 +285,105 @@ func (h Handler) UpdateStaffPassword(c *gin.Context) {
 		return
 	}
 
+	if err = h.storage.RefreshToken().RevokeByStaff(context.Background(), updateStaffPassword.ID); err != nil {
+		handleResponse(c, "error while revoking refresh tokens", http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	handleResponse(c, "", http.StatusOK, "password successfully updated")
 }
+
+// CreateStaffAssignment godoc
+// @Router       /staff/{id}/assignment [POST]
+// @Summary      Assign staff to a branch and tariff
+// @Description  record the branch and tariff of the staff from a day on, a past day corrects the history up to the next recorded change
+// @Tags         staff
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "staff_id"
+// @Param 		 assignment body models.CreateStaffAssignment true "assignment"
+// @Success      201  {array}   models.StaffAssignment
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateStaffAssignment(c *gin.Context) {
+	uid := c.Param("id")
+
+	assignment := models.CreateStaffAssignment{}
+	if err := c.ShouldBindJSON(&assignment); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from := time.Now()
+	if assignment.EffectiveFrom != "" {
+		day, err := time.ParseInLocation("2006-01-02", assignment.EffectiveFrom, time.Local)
+		if err != nil {
+			handleResponse(c, "error while parsing effective_from", http.StatusBadRequest, "effective_from should be in YYYY-MM-DD format")
+			return
+		}
+
+		if day.After(from) {
+			handleResponse(c, "error while checking effective_from", http.StatusBadRequest, "effective_from should not be in the future")
+			return
+		}
+		from = day
+	}
+
+	if !h.checkStaffAccess(c, uid, false) || !checkBranch(c, assignment.BranchID) {
+		return
+	}
+
+	if assignment.TariffID != "" {
+		if _, err := h.storage.StaffTariff().GetStaffTariffByID(context.Background(), models.PrimaryKey{ID: assignment.TariffID}); err != nil {
+			handleResponse(c, "error while getting staff tariff by ID", http.StatusNotFound, err.Error())
+			return
+		}
+	}
+
+	assignment.StaffID = uid
+	assignment.ChangedBy = actingStaff(c).ID
+	if err := h.storage.Staff().Assign(context.Background(), assignment, from); err != nil {
+		handleResponse(c, "error while assigning staff", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	assignments, err := h.storage.Staff().Assignments(context.Background(), uid)
+	if err != nil {
+		handleResponse(c, "error while getting staff assignments", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, assignments)
+}
+
+// GetStaffAssignments godoc
+// @Router       /staff/{id}/assignments [GET]
+// @Summary      Get staff assignment history
+// @Description  get the branches and tariffs of the staff over time, the latest first
+// @Tags         staff
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "staff_id"
+// @Success      200  {array}   models.StaffAssignment
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetStaffAssignments(c *gin.Context) {
+	uid := c.Param("id")
+
+	if !h.checkStaffAccess(c, uid, true) {
+		return
+	}
+
+	assignments, err := h.storage.Staff().Assignments(context.Background(), uid)
+	if err != nil {
+		handleResponse(c, "error while getting staff assignments", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, assignments)
+}
@@ -14,6 +14,7 @@ import (
 // @Summary      Create a new staff tariff
 // @Description  create a new staff tariff
 // @Tags         staff-tariff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 staffTariff body models.CreateStaffTariff false "staff-Tariff"
@@ -49,6 +50,7 @@ func (h Handler) CreateStaffTariff(c *gin.Context) {
 // @Summary      Get staff tariff by id
 // @Description  get staff tariff by id
 // @Tags         staff-tariff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "staff-tariff_id"
@@ -73,6 +75,7 @@ func (h Handler) GetStaffTariff(c *gin.Context) {
 // @Summary      Get staff tariff list
 // @Description  get staff tariff list
 // @Tags         staff-tariff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 page query string false "page"
@@ -122,6 +125,7 @@ func (h Handler) GetStaffTariffList(c *gin.Context) {
 // @Summary      Update staff tariff
 // @Description  get staff tariff
 // @Tags         staff-tariff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "staff-tariff_id"
@@ -159,6 +163,7 @@ func (h Handler) UpdateStaffTariff(c *gin.Context) {
 // @Summary      Delete staff tariff
 // @Description  delete staff tariff
 // @Tags         staff-tariff
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "staff-tariff_id"
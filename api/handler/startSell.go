@@ -12,11 +12,13 @@ import (
 // @Summary      sell
 // @Description  sell
 // @Tags         sell
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 sell body models.CreateSale false "sell"
 // @Success      200  {object}  models.Sale
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
 // @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) StartSell(c *gin.Context) {
@@ -27,6 +29,10 @@ func (h Handler) StartSell(c *gin.Context) {
 		return
 	}
 
+	if !checkBranch(c, sell.BranchID) {
+		return
+	}
+
 	saleID, err := h.storage.Sale().Create(context.Background(), sell)
 	if err != nil {
 		handleResponse(c, "error is while creating sale", http.StatusInternalServerError, err.Error())
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sell/api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetStockReconcile godoc
+// @Router       /stock/reconcile [GET]
+// @Summary      Get stock mismatches
+// @Description  compare repositories with the repository transactions ledger
+// @Tags         stock
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 branch_id query string false "branch_id"
+// @Success      200  {object}  models.StockReconcileResponse
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetStockReconcile(c *gin.Context) {
+	branchID := c.Query("branch_id")
+
+	// without a branch every branch is checked, only owners see them all
+	if !checkBranch(c, branchID) {
+		return
+	}
+
+	response, err := h.storage.Stock().Mismatches(context.Background(), branchID)
+	if err != nil {
+		handleResponse(c, "error while getting stock mismatches", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// ReconcileStock godoc
+// @Router       /stock/reconcile [POST]
+// @Summary      Reconcile stock
+// @Description  post corrective repository transactions for every stock mismatch
+// @Tags         stock
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 reconcile body models.StockReconcileRequest false "reconcile"
+// @Success      200  {object}  models.StockReconcileResponse
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ReconcileStock(c *gin.Context) {
+	request := models.StockReconcileRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !checkBranch(c, request.BranchID) {
+		return
+	}
+
+	if request.StaffID == "" {
+		request.StaffID = actingStaff(c).ID
+	}
+
+	response, err := h.storage.Stock().Reconcile(context.Background(), request)
+	if err != nil {
+		handleResponse(c, "error while reconciling stock", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
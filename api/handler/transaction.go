@@ -2,19 +2,21 @@ package handler
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"github.com/gin-gonic/gin"
-	"math"
+	"github.com/jackc/pgx/v5"
 	"net/http"
 	"sell/api/models"
+	"sell/storage"
 	"strconv"
 )
 
 // CreateTransaction godoc
 // @Router       /transaction [POST]
 // @Summary      Create a new transaction
-// @Description  create a new transaction
+// @Description  create a new transaction, it moves the staff balance by the amount
 // @Tags         transaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 transaction body models.CreateTransaction false "sale"
@@ -29,9 +31,14 @@ func (h Handler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
+	if trans.SourceType == "withdrawal" {
+		handleResponse(c, "error is while checking source type", http.StatusBadRequest, "withdrawals are paid out through withdrawal requests")
+		return
+	}
+
 	id, err := h.storage.Transaction().Create(context.Background(), trans)
 	if err != nil {
-		handleResponse(c, "error is while creating", http.StatusInternalServerError, err.Error())
+		transactionError(c, "error is while creating", err)
 		return
 	}
 
@@ -49,6 +56,7 @@ func (h Handler) CreateTransaction(c *gin.Context) {
 // @Summary      Get transaction by id
 // @Description  get transaction by id
 // @Tags         transaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "transaction_id"
@@ -71,14 +79,23 @@ func (h Handler) GetTransaction(c *gin.Context) {
 // GetTransactionList godoc
 // @Router       /transactions [GET]
 // @Summary      Get transaction list
-// @Description  get transaction list
+// @Description  get transaction list with totals over every transaction matching the filters
 // @Tags         transaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param		 page query string false "page"
 // @Param		 limit query string false "limit"
 // @Param		 from-amount query string false "from-amount"
 // @Param		 to-amount query string false "to-amount"
+// @Param		 staff_id query string false "staff_id"
+// @Param		 sale_id query string false "sale_id"
+// @Param		 transaction_type query string false "withdraw or topup"
+// @Param		 source_type query string false "bonus, sales, fine, salary, payroll, withdrawal or adjustment"
+// @Param		 from query string false "from date, 2006-01-02"
+// @Param		 to query string false "to date, 2006-01-02, inclusive"
+// @Param		 sort_by query string false "created_at or amount"
+// @Param		 sort_order query string false "asc or desc"
 // @Success      200  {object}  models.TransactionResponse
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
@@ -112,18 +129,65 @@ func (h Handler) GetTransactionList(c *gin.Context) {
 		return
 	}
 
-	toAmountStr := c.DefaultQuery("to-amount", fmt.Sprintf("%f", math.MaxFloat64))
+	toAmountStr := c.DefaultQuery("to-amount", "0")
 	toAmount, err = strconv.ParseFloat(toAmountStr, 64)
 	if err != nil {
 		handleResponse(c, "error is while converting to amount", http.StatusBadRequest, err.Error())
 		return
 	}
 
+	if toAmount != 0 && toAmount < fromAmount {
+		handleResponse(c, "error is while checking amounts", http.StatusBadRequest, "to-amount should not be less than from-amount")
+		return
+	}
+
+	if !validIDs(c, c.Query("staff_id"), c.Query("sale_id")) {
+		return
+	}
+
+	transactionType := c.Query("transaction_type")
+	if transactionType != "" && transactionType != "withdraw" && transactionType != "topup" {
+		handleResponse(c, "error is while checking transaction type", http.StatusBadRequest, "transaction_type should be withdraw or topup")
+		return
+	}
+
+	sourceType := c.Query("source_type")
+	switch sourceType {
+	case "", "bonus", "sales", "fine", "salary", "payroll", "withdrawal", "adjustment":
+	default:
+		handleResponse(c, "error is while checking source type", http.StatusBadRequest, "unknown source_type "+sourceType)
+		return
+	}
+
+	from, to, err := parsePeriod(c)
+	if err != nil {
+		handleResponse(c, "error is while parsing period", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sortBy, sortOrder := c.Query("sort_by"), c.Query("sort_order")
+	if sortBy != "" && sortBy != "created_at" && sortBy != "amount" {
+		handleResponse(c, "error is while checking sort", http.StatusBadRequest, "sort_by should be created_at or amount")
+		return
+	}
+	if sortOrder != "" && sortOrder != "asc" && sortOrder != "desc" {
+		handleResponse(c, "error is while checking sort", http.StatusBadRequest, "sort_order should be asc or desc")
+		return
+	}
+
 	transactions, err := h.storage.Transaction().GetList(context.Background(), models.TransactionGetListRequest{
-		Page:       page,
-		Limit:      limit,
-		FromAmount: fromAmount,
-		ToAmount:   toAmount,
+		Page:            page,
+		Limit:           limit,
+		FromAmount:      fromAmount,
+		ToAmount:        toAmount,
+		StaffID:         c.Query("staff_id"),
+		SaleID:          c.Query("sale_id"),
+		TransactionType: transactionType,
+		SourceType:      sourceType,
+		FromDate:        from,
+		ToDate:          to,
+		SortBy:          sortBy,
+		SortOrder:       sortOrder,
 	})
 
 	if err != nil {
@@ -137,8 +201,9 @@ func (h Handler) GetTransactionList(c *gin.Context) {
 // UpdateTransaction godoc
 // @Router       /transaction/{id} [PUT]
 // @Summary      Update transaction
-// @Description  update transaction
+// @Description  update transaction, the staff balance follows the change. Generated transactions (payroll, withdrawals, target payouts, commissions, opening balances) are refused, post a reversing transaction instead
 // @Tags         transaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "transaction_id"
@@ -156,11 +221,16 @@ func (h Handler) UpdateTransaction(c *gin.Context) {
 		return
 	}
 
+	if trans.SourceType == "withdrawal" {
+		handleResponse(c, "error is while checking source type", http.StatusBadRequest, "withdrawals are paid out through withdrawal requests")
+		return
+	}
+
 	trans.ID = uid
 
 	id, err := h.storage.Transaction().Update(context.Background(), trans)
 	if err != nil {
-		handleResponse(c, "error is while updating trans", http.StatusInternalServerError, err.Error())
+		transactionError(c, "error is while updating trans", err)
 		return
 	}
 
@@ -176,8 +246,9 @@ func (h Handler) UpdateTransaction(c *gin.Context) {
 // DeleteTransaction godoc
 // @Router       /transaction/{id} [DELETE]
 // @Summary      Delete transaction
-// @Description  delete transaction
+// @Description  delete transaction, its amount is taken back from the staff balance. Generated transactions are refused, post a reversing transaction instead
 // @Tags         transaction
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param 		 id path string true "transaction_id"
@@ -189,9 +260,63 @@ func (h Handler) DeleteTransaction(c *gin.Context) {
 	uid := c.Param("id")
 
 	if err := h.storage.Transaction().Delete(context.Background(), uid); err != nil {
-		handleResponse(c, "error is while deleting", http.StatusInternalServerError, err.Error())
+		transactionError(c, "error is while deleting", err)
 		return
 	}
 
 	handleResponse(c, "", http.StatusOK, "transaction deleted!")
 }
+
+// GetStaffStatement godoc
+// @Router       /staff/{id}/statement [GET]
+// @Summary      Get staff statement
+// @Description  get the opening balance, the transactions and the closing balance of the staff for a period
+// @Tags         transaction
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "staff_id"
+// @Param 		 from query string false "first day in YYYY-MM-DD format, the first transaction by default"
+// @Param 		 to query string false "last day in YYYY-MM-DD format, today by default"
+// @Success      200  {object}  models.StaffStatement
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetStaffStatement(c *gin.Context) {
+	staffID := c.Param("id")
+
+	if !h.checkStaffAccess(c, staffID, true) {
+		return
+	}
+
+	from, to, err := parsePeriod(c)
+	if err != nil {
+		handleResponse(c, "error while parsing period", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	statement, err := h.storage.Transaction().Statement(context.Background(), models.StaffStatementRequest{
+		StaffID: staffID,
+		From:    from,
+		To:      to,
+	})
+	if err != nil {
+		handleResponse(c, "error while getting staff statement", http.StatusInternalServerError, err.Error())
+		return
+	}
+	statement.From, statement.To = c.Query("from"), c.Query("to")
+
+	handleResponse(c, "", http.StatusOK, statement)
+}
+
+// transactionError answers 400 when the balance would go below zero and 404 for a missing transaction.
+func transactionError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, storage.ErrNotEnoughBalance), errors.Is(err, storage.ErrGeneratedTransaction):
+		handleResponse(c, message, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		handleResponse(c, message, http.StatusNotFound, "transaction not found")
+	default:
+		handleResponse(c, message, http.StatusInternalServerError, err.Error())
+	}
+}
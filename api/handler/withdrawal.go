@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/rbac"
+	"sell/storage"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateWithdrawalRequest godoc
+// @Router       /withdrawal-request [POST]
+// @Summary      Request a withdrawal
+// @Description  ask to cash out part of the own balance, the balance changes only after a manager approves it
+// @Tags         withdrawal
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 withdrawal body models.CreateWithdrawalRequest true "withdrawal"
+// @Success      201  {object}  models.WithdrawalRequest
+// @Failure      400  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateWithdrawalRequest(c *gin.Context) {
+	request := models.CreateWithdrawalRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if request.Amount <= 0 {
+		handleResponse(c, "error while validating withdrawal request", http.StatusBadRequest, "amount should be positive")
+		return
+	}
+	request.StaffID = actingStaff(c).ID
+
+	staff, err := h.storage.Staff().StaffByID(context.Background(), models.PrimaryKey{ID: request.StaffID})
+	if err != nil {
+		handleResponse(c, "error while getting staff by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if float64(request.Amount) > staff.Balance {
+		handleResponse(c, "error while checking balance", http.StatusBadRequest, "not enough balance")
+		return
+	}
+
+	id, err := h.storage.Withdrawal().Create(context.Background(), request)
+	if err != nil {
+		handleResponse(c, "error while creating withdrawal request", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdRequest, err := h.storage.Withdrawal().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting withdrawal request by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdRequest)
+}
+
+// GetWithdrawalRequest godoc
+// @Router       /withdrawal-request/{id} [GET]
+// @Summary      Get withdrawal request by id
+// @Description  get withdrawal request with its audit trail
+// @Tags         withdrawal
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "withdrawal_request_id"
+// @Success      200  {object}  models.WithdrawalRequest
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetWithdrawalRequest(c *gin.Context) {
+	request, ok := h.withdrawalRequest(c, true)
+	if !ok {
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, request)
+}
+
+// GetWithdrawalRequestList godoc
+// @Router       /withdrawal-requests [GET]
+// @Summary      Get withdrawal request list
+// @Description  get withdrawal requests, staff see their own requests and managers the requests of their branch
+// @Tags         withdrawal
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 staff_id query string false "staff_id"
+// @Param 		 branch_id query string false "branch_id"
+// @Param 		 status query string false "pending, approved, rejected or cancelled"
+// @Success      200  {object}  models.WithdrawalRequestsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetWithdrawalRequestList(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var (
+		acting   = actingStaff(c)
+		staffID  = c.Query("staff_id")
+		branchID = c.Query("branch_id")
+	)
+
+	switch {
+	case staffID != "":
+		if !h.checkStaffAccess(c, staffID, true) {
+			return
+		}
+	case !rbac.Allowed(acting.StaffType, rbac.ManageStaff):
+		staffID = acting.ID
+	default:
+		if branchID == "" && !rbac.AllBranches(acting.StaffType) {
+			branchID = acting.BranchID
+		}
+
+		if !checkBranch(c, branchID) {
+			return
+		}
+	}
+
+	response, err := h.storage.Withdrawal().GetList(context.Background(), models.WithdrawalRequestGetListRequest{
+		Page:     page,
+		Limit:    limit,
+		StaffID:  staffID,
+		BranchID: branchID,
+		Status:   c.Query("status"),
+	})
+	if err != nil {
+		handleResponse(c, "error while getting withdrawal request list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// ApproveWithdrawalRequest godoc
+// @Router       /withdrawal-request/{id}/approve [PUT]
+// @Summary      Approve withdrawal request
+// @Description  approve withdrawal request of another staff, it writes the withdraw transaction and takes the amount off the balance
+// @Tags         withdrawal
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "withdrawal_request_id"
+// @Param 		 decision body models.WithdrawalDecision false "decision"
+// @Success      200  {object}  models.WithdrawalRequest
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ApproveWithdrawalRequest(c *gin.Context) {
+	h.decideWithdrawal(c, "approve")
+}
+
+// RejectWithdrawalRequest godoc
+// @Router       /withdrawal-request/{id}/reject [PUT]
+// @Summary      Reject withdrawal request
+// @Description  reject withdrawal request of another staff, the balance stays as it is
+// @Tags         withdrawal
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "withdrawal_request_id"
+// @Param 		 decision body models.WithdrawalDecision false "decision"
+// @Success      200  {object}  models.WithdrawalRequest
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) RejectWithdrawalRequest(c *gin.Context) {
+	h.decideWithdrawal(c, "reject")
+}
+
+// CancelWithdrawalRequest godoc
+// @Router       /withdrawal-request/{id}/cancel [PUT]
+// @Summary      Cancel withdrawal request
+// @Description  cancel own pending withdrawal request, managers reject the requests of others instead
+// @Tags         withdrawal
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "withdrawal_request_id"
+// @Param 		 decision body models.WithdrawalDecision false "decision"
+// @Success      200  {object}  models.WithdrawalRequest
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CancelWithdrawalRequest(c *gin.Context) {
+	h.decideWithdrawal(c, "cancel")
+}
+
+// decideWithdrawal approves, rejects or cancels a pending request. Only the requester cancels
+// a request and only their managers, never the requester, approve or reject it.
+func (h Handler) decideWithdrawal(c *gin.Context, action string) {
+	decision := models.WithdrawalDecision{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&decision); err != nil {
+			handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	request, ok := h.withdrawalRequest(c, action == "cancel")
+	if !ok {
+		return
+	}
+
+	isOwn := request.StaffID == actingStaff(c).ID
+	if action == "cancel" && !isOwn {
+		handleResponse(c, "error while checking permission", http.StatusForbidden, "only the requester can cancel a withdrawal request")
+		return
+	}
+	if action != "cancel" && isOwn {
+		handleResponse(c, "error while checking permission", http.StatusForbidden, "staff can not decide on their own withdrawal request")
+		return
+	}
+
+	if request.Status != "pending" {
+		handleResponse(c, "error while checking withdrawal request", http.StatusBadRequest, "withdrawal request is already "+request.Status)
+		return
+	}
+
+	decision.ID = request.ID
+	decision.ActorID = actingStaff(c).ID
+
+	var err error
+	switch action {
+	case "approve":
+		var staff models.Staff
+		if staff, err = h.storage.Staff().StaffByID(context.Background(), models.PrimaryKey{ID: request.StaffID}); err != nil {
+			handleResponse(c, "error while getting staff by ID", http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if float64(request.Amount) > staff.Balance {
+			handleResponse(c, "error while checking balance", http.StatusBadRequest, "not enough balance")
+			return
+		}
+
+		err = h.storage.Withdrawal().Approve(context.Background(), decision)
+	case "reject":
+		err = h.storage.Withdrawal().Reject(context.Background(), decision)
+	default:
+		err = h.storage.Withdrawal().Cancel(context.Background(), decision)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrNotEnoughBalance) {
+			handleResponse(c, "error while deciding on withdrawal request", http.StatusBadRequest, err.Error())
+			return
+		}
+		handleResponse(c, "error while deciding on withdrawal request", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	decidedRequest, err := h.storage.Withdrawal().GetByID(context.Background(), models.PrimaryKey{ID: request.ID})
+	if err != nil {
+		handleResponse(c, "error while getting withdrawal request by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, decidedRequest)
+}
+
+// withdrawalRequest reads the request of the id param when the acting staff may reach its staff.
+func (h Handler) withdrawalRequest(c *gin.Context, allowSelf bool) (models.WithdrawalRequest, bool) {
+	request, err := h.storage.Withdrawal().GetByID(context.Background(), models.PrimaryKey{ID: c.Param("id")})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handleResponse(c, "error while getting withdrawal request by ID", http.StatusNotFound, "withdrawal request not found")
+			return models.WithdrawalRequest{}, false
+		}
+		handleResponse(c, "error while getting withdrawal request by ID", http.StatusInternalServerError, err.Error())
+		return models.WithdrawalRequest{}, false
+	}
+
+	if !h.checkStaffAccess(c, request.StaffID, allowSelf) {
+		return models.WithdrawalRequest{}, false
+	}
+
+	return request, true
+}
@@ -0,0 +1,333 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sell/api/models"
+	"sell/pkg/check"
+	"sell/storage"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWriteOff godoc
+// @Router       /write-off [POST]
+// @Summary      Create a new write off
+// @Description  create a new write off, it changes the stock only after approval
+// @Tags         write-off
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 write_off body models.CreateWriteOff false "write_off"
+// @Success      200  {object}  models.WriteOff
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) CreateWriteOff(c *gin.Context) {
+	writeOff := models.CreateWriteOff{}
+
+	if err := c.ShouldBindJSON(&writeOff); err != nil {
+		handleResponse(c, "error while reading body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !checkBranch(c, writeOff.BranchID) {
+		return
+	}
+
+	if writeOff.StaffID == "" {
+		writeOff.StaffID = actingStaff(c).ID
+	}
+
+	if err := validateWriteOff(writeOff); err != nil {
+		handleResponse(c, "error while validating write off", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, item := range writeOff.Items {
+		product, err := h.storage.Product().GetByID(context.Background(), item.ProductID)
+		if err != nil {
+			handleResponse(c, "error while getting product by id", http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if err = check.ValidateQuantity(product.Unit, item.Quantity); err != nil {
+			handleResponse(c, "error while validating quantity", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	id, err := h.storage.WriteOff().Create(context.Background(), writeOff)
+	if err != nil {
+		handleResponse(c, "error while creating write off", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	createdWriteOff, err := h.storage.WriteOff().GetByID(context.Background(), models.PrimaryKey{ID: id})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusCreated, createdWriteOff)
+}
+
+// GetWriteOff godoc
+// @Router       /write-off/{id} [GET]
+// @Summary      Get write off by id
+// @Description  get write off by id
+// @Tags         write-off
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "write_off_id"
+// @Success      200  {object}  models.WriteOff
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetWriteOff(c *gin.Context) {
+	uid := c.Param("id")
+
+	writeOff, err := h.storage.WriteOff().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting write off by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, writeOff)
+}
+
+// GetWriteOffList godoc
+// @Router       /write-offs [GET]
+// @Summary      Get write off list
+// @Description  get write off list
+// @Tags         write-off
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 page query string false "page"
+// @Param 		 limit query string false "limit"
+// @Param 		 branch_id query string false "branch_id"
+// @Param 		 status query string false "pending, approved or rejected"
+// @Success      200  {object}  models.WriteOffsResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetWriteOffList(c *gin.Context) {
+	var (
+		page, limit int
+		err         error
+	)
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err = strconv.Atoi(pageStr)
+	if err != nil {
+		handleResponse(c, "error while converting page", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		handleResponse(c, "error while converting limit", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch c.Query("status") {
+	case "", "pending", "approved", "rejected":
+	default:
+		handleResponse(c, "error while validating status", http.StatusBadRequest, "status should be pending, approved or rejected")
+		return
+	}
+
+	if !validIDs(c, c.Query("branch_id")) {
+		return
+	}
+
+	response, err := h.storage.WriteOff().GetList(context.Background(), models.WriteOffGetListRequest{
+		Page:     page,
+		Limit:    limit,
+		BranchID: c.Query("branch_id"),
+		Status:   c.Query("status"),
+	})
+	if err != nil {
+		handleResponse(c, "error while getting write off list", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, response)
+}
+
+// ApproveWriteOff godoc
+// @Router       /write-off/{id}/approve [PUT]
+// @Summary      Approve write off
+// @Description  approve write off by the branch manager and take the items out of the branch stock
+// @Tags         write-off
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "write_off_id"
+// @Success      200  {object}  models.WriteOff
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) ApproveWriteOff(c *gin.Context) {
+	h.decideWriteOff(c, true)
+}
+
+// RejectWriteOff godoc
+// @Router       /write-off/{id}/reject [PUT]
+// @Summary      Reject write off
+// @Description  reject write off by the branch manager
+// @Tags         write-off
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "write_off_id"
+// @Success      200  {object}  models.WriteOff
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) RejectWriteOff(c *gin.Context) {
+	h.decideWriteOff(c, false)
+}
+
+func (h Handler) decideWriteOff(c *gin.Context, approve bool) {
+	uid := c.Param("id")
+
+	decision := models.WriteOffDecision{
+		ID:        uid,
+		ManagerID: actingStaff(c).ID,
+	}
+
+	writeOff, err := h.storage.WriteOff().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting write off by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if writeOff.Status != "pending" {
+		handleResponse(c, "error while checking write off", http.StatusConflict, "write off is already "+writeOff.Status)
+		return
+	}
+
+	// the route is open to managers and owners, managers decide only for their branch
+	if !checkBranch(c, writeOff.BranchID) {
+		return
+	}
+
+	if approve {
+		err = h.storage.WriteOff().Approve(context.Background(), decision)
+	} else {
+		err = h.storage.WriteOff().Reject(context.Background(), decision)
+	}
+	switch {
+	case errors.Is(err, storage.ErrNotEnoughStock):
+		handleResponse(c, "error while deciding on write off", http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, storage.ErrAlreadyDecided):
+		handleResponse(c, "error while deciding on write off", http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		handleResponse(c, "error while deciding on write off", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	decidedWriteOff, err := h.storage.WriteOff().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, decidedWriteOff)
+}
+
+// DeleteWriteOff godoc
+// @Router       /write-off/{id} [DELETE]
+// @Summary      Delete write off
+// @Description  delete pending write off
+// @Tags         write-off
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "write_off_id"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) DeleteWriteOff(c *gin.Context) {
+	uid := c.Param("id")
+
+	writeOff, err := h.storage.WriteOff().GetByID(context.Background(), models.PrimaryKey{ID: uid})
+	if err != nil {
+		handleResponse(c, "error while getting write off by ID", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !checkBranch(c, writeOff.BranchID) {
+		return
+	}
+
+	if err := h.storage.WriteOff().Delete(context.Background(), uid); err != nil {
+		handleResponse(c, "error while deleting write off ", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, "write off deleted")
+}
+
+// GetWriteOffReport godoc
+// @Router       /write-offs/report [GET]
+// @Summary      Get monthly write off report
+// @Description  sum approved write offs of the month by branch and reason
+// @Tags         write-off
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param 		 month query string false "month in YYYY-MM format, current month by default"
+// @Success      200  {object}  models.WriteOffReportResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h Handler) GetWriteOffReport(c *gin.Context) {
+	monthStr := c.DefaultQuery("month", time.Now().Format("2006-01"))
+	month, err := time.Parse("2006-01", monthStr)
+	if err != nil {
+		handleResponse(c, "error while parsing month", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rows, err := h.storage.WriteOff().Report(context.Background(), month)
+	if err != nil {
+		handleResponse(c, "error while getting write off report", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, "", http.StatusOK, models.WriteOffReportResponse{
+		Month: monthStr,
+		Rows:  rows,
+	})
+}
+
+func validateWriteOff(writeOff models.CreateWriteOff) error {
+	switch writeOff.Reason {
+	case "damage", "expiry", "theft", "internal_use":
+	default:
+		return errors.New("reason should be one of damage, expiry, theft, internal_use")
+	}
+
+	if len(writeOff.Items) == 0 {
+		return errors.New("write off should have at least one item")
+	}
+
+	return nil
+}
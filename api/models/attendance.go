@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Attendance is one shift of a staff in a branch, from clock in to clock out.
+// Forgotten clock outs are closed by the worker with the auto_closed status.
+type Attendance struct {
+	ID            string            `json:"id"`
+	StaffID       string            `json:"staff_id"`
+	BranchID      string            `json:"branch_id"`
+	ClockIn       time.Time         `json:"clock_in"`
+	ClockOut      *time.Time        `json:"clock_out"`
+	Status        string            `json:"status"`
+	WorkedMinutes int               `json:"worked_minutes"`
+	BreakMinutes  int               `json:"break_minutes"`
+	Breaks        []AttendanceBreak `json:"breaks"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+type AttendanceBreak struct {
+	ID        string     `json:"id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+type ClockRequest struct {
+	BranchID string `json:"branch_id"`
+}
+
+// UpdateAttendance is a manager's correction of a shift, it closes the shift.
+type UpdateAttendance struct {
+	ID       string    `json:"-"`
+	ClockIn  time.Time `json:"clock_in"`
+	ClockOut time.Time `json:"clock_out"`
+}
+
+type AttendanceGetListRequest struct {
+	Page     int
+	Limit    int
+	StaffID  string
+	BranchID string
+	Status   string
+	From     time.Time
+	To       time.Time
+}
+
+type AttendancesResponse struct {
+	Attendances []Attendance `json:"attendances"`
+	Count       int          `json:"count"`
+}
+
+// Timesheet sums the closed shifts of a staff by day. Auto closed shifts are kept apart,
+// their clock out is a guess until a manager corrects it.
+type Timesheet struct {
+	StaffID           string         `json:"staff_id"`
+	From              string         `json:"from"`
+	To                string         `json:"to"`
+	Days              []TimesheetDay `json:"days"`
+	Shifts            int            `json:"shifts"`
+	WorkedMinutes     int            `json:"worked_minutes"`
+	BreakMinutes      int            `json:"break_minutes"`
+	AutoClosedShifts  int            `json:"auto_closed_shifts"`
+	AutoClosedMinutes int            `json:"auto_closed_minutes"`
+}
+
+type TimesheetDay struct {
+	Date              string `json:"date"`
+	Shifts            int    `json:"shifts"`
+	WorkedMinutes     int    `json:"worked_minutes"`
+	BreakMinutes      int    `json:"break_minutes"`
+	AutoClosedShifts  int    `json:"auto_closed_shifts"`
+	AutoClosedMinutes int    `json:"auto_closed_minutes"`
+}
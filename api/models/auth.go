@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+type LoginRequest struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	Staff        Staff  `json:"staff"`
+}
+
+// StaffCredentials is what login needs to know about a staff, the password is the bcrypt hash.
+type StaffCredentials struct {
+	ID                 string
+	BranchID           string
+	StaffType          string
+	Password           string
+	FailedLogins       int
+	LockedUntil        *time.Time
+	MustChangePassword bool
+}
+
+// ActingStaff is the staff making the request, taken from the access token.
+type ActingStaff struct {
+	ID        string `json:"id"`
+	BranchID  string `json:"branch_id"`
+	StaffType string `json:"staff_type"`
+}
+
+type RefreshToken struct {
+	ID        string     `json:"id"`
+	StaffID   string     `json:"staff_id"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type CreateRefreshToken struct {
+	StaffID   string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+type PasswordReset struct {
+	ID        string     `json:"id"`
+	StaffID   string     `json:"staff_id"`
+	CreatedBy string     `json:"created_by"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type CreatePasswordReset struct {
+	StaffID   string
+	CreatedBy string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// PasswordResetResponse holds the one time token the manager hands to the staff.
+type PasswordResetResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
@@ -6,26 +6,30 @@ type Basket struct {
 	ID        string     `json:"id"`
 	SaleID    string     `json:"sale_id"`
 	ProductID string     `json:"product_id"`
-	Quantity  int        `json:"quantity"`
-	Price     int        `json:"price"`
+	VariantID string     `json:"variant_id"`
+	Quantity  float64    `json:"quantity"`
+	Price     float64    `json:"price"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"-"`
 }
 
 type CreateBasket struct {
-	SaleID    string `json:"sale_id"`
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	Price     int    `json:"price"`
+	SaleID    string  `json:"sale_id"`
+	ProductID string  `json:"product_id"`
+	VariantID string  `json:"variant_id"`
+	UnitID    string  `json:"unit_id"`
+	Quantity  float64 `json:"quantity"`
+	Price     float64 `json:"price"`
 }
 
 type UpdateBasket struct {
-	ID        string `json:"-"`
-	SaleID    string `json:"sale_id"`
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	Price     int    `json:"price"`
+	ID        string  `json:"-"`
+	SaleID    string  `json:"sale_id"`
+	ProductID string  `json:"product_id"`
+	VariantID string  `json:"variant_id"`
+	Quantity  float64 `json:"quantity"`
+	Price     float64 `json:"price"`
 }
 
 type BasketsResponse struct {
@@ -39,3 +43,11 @@ type BasketGetListRequest struct {
 	SaleID    string
 	ProductID string
 }
+
+// ScanBasket adds a scanned code to the sale. Quantity is used for product
+// barcodes, scale labels carry their own weight, count or price.
+type ScanBasket struct {
+	SaleID   string  `json:"-"`
+	Code     string  `json:"code"`
+	Quantity float64 `json:"quantity"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Bundle turns a product into a kit of other products. Selling the bundle
+// consumes the stock of its items. With the "own" pricing type the bundle is sold
+// for its product price, with "components" for the sum of its items less Discount percent.
+type Bundle struct {
+	ID          string       `json:"id"`
+	ProductID   string       `json:"product_id"`
+	PricingType string       `json:"pricing_type"`
+	Discount    float64      `json:"discount"`
+	Items       []BundleItem `json:"items"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   *time.Time   `json:"-"`
+}
+
+type BundleItem struct {
+	ProductID string  `json:"product_id"`
+	Quantity  float64 `json:"quantity"`
+}
+
+type CreateBundle struct {
+	ProductID   string       `json:"product_id"`
+	PricingType string       `json:"pricing_type"`
+	Discount    float64      `json:"discount"`
+	Items       []BundleItem `json:"items"`
+}
+
+type UpdateBundle struct {
+	ID          string       `json:"-"`
+	PricingType string       `json:"pricing_type"`
+	Discount    float64      `json:"discount"`
+	Items       []BundleItem `json:"items"`
+}
+
+type BundlesResponse struct {
+	Bundles []Bundle `json:"bundles"`
+	Count   int      `json:"count"`
+}
@@ -26,3 +26,21 @@ type CategoryResponse struct {
 	Categories []Category
 	Count      int
 }
+
+// CategoryNode is a category with all of its descendants.
+type CategoryNode struct {
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	ParentID string          `json:"parent_id"`
+	Children []*CategoryNode `json:"children"`
+}
+
+type MoveCategory struct {
+	ID       string `json:"-"`
+	ParentID string `json:"parent_id"`
+}
+
+type CategoryUsage struct {
+	Children int `json:"children"`
+	Products int `json:"products"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// CommissionRule overrides the flat rate of a tariff. An empty CategoryID covers every
+// category, EffectiveTo is the last day of the rule and empty while it is open ended.
+type CommissionRule struct {
+	ID            string    `json:"id"`
+	TariffID      string    `json:"tariff_id"`
+	CategoryID    string    `json:"category_id"`
+	MinVolume     float64   `json:"min_volume"`
+	TariffType    string    `json:"tariff_type"`
+	AmountForCash float64   `json:"amount_for_cash"`
+	AmountForCard float64   `json:"amount_for_card"`
+	EffectiveFrom string    `json:"effective_from"`
+	EffectiveTo   string    `json:"effective_to"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type CreateCommissionRule struct {
+	TariffID      string  `json:"-"`
+	CategoryID    string  `json:"category_id"`
+	MinVolume     float64 `json:"min_volume"`
+	TariffType    string  `json:"tariff_type"`
+	AmountForCash float64 `json:"amount_for_cash"`
+	AmountForCard float64 `json:"amount_for_card"`
+	EffectiveFrom string  `json:"effective_from"`
+	EffectiveTo   string  `json:"effective_to"`
+}
+
+type UpdateCommissionRule struct {
+	ID            string  `json:"-"`
+	TariffID      string  `json:"-"`
+	CategoryID    string  `json:"category_id"`
+	MinVolume     float64 `json:"min_volume"`
+	TariffType    string  `json:"tariff_type"`
+	AmountForCash float64 `json:"amount_for_cash"`
+	AmountForCard float64 `json:"amount_for_card"`
+	EffectiveFrom string  `json:"effective_from"`
+	EffectiveTo   string  `json:"effective_to"`
+}
+
+// CommissionRequest is a sale line, either a basket or the product, line total, quantity and
+// payment type of a line that is not sold yet. SoldAt is now when empty.
+type CommissionRequest struct {
+	StaffID     string     `json:"staff_id"`
+	BasketID    string     `json:"basket_id"`
+	ProductID   string     `json:"product_id"`
+	Price       float64    `json:"price"`
+	Quantity    float64    `json:"quantity"`
+	PaymentType string     `json:"payment_type"`
+	SoldAt      *time.Time `json:"sold_at"`
+}
+
+// Commission is the commission of a sale line. RuleID is empty when the flat rate of the
+// tariff applies, Volume is the completed sales of the staff in the month up to the sale.
+// Percent rates are taken from the line total, fixed rates are paid per unit sold.
+type Commission struct {
+	StaffID     string    `json:"staff_id"`
+	TariffID    string    `json:"tariff_id"`
+	RuleID      string    `json:"rule_id"`
+	CategoryID  string    `json:"category_id"`
+	PaymentType string    `json:"payment_type"`
+	TariffType  string    `json:"tariff_type"`
+	Rate        float64   `json:"rate"`
+	Volume      float64   `json:"volume"`
+	Price       float64   `json:"price"`
+	Quantity    float64   `json:"quantity"`
+	SoldAt      time.Time `json:"sold_at"`
+	Amount      float64   `json:"amount"`
+}
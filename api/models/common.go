@@ -8,4 +8,4 @@ type GetListRequest struct {
 	Page   int
 	Limit  int
 	Search string
-}
\ No newline at end of file
+}
@@ -0,0 +1,157 @@
+package models
+
+import "time"
+
+// Account is an account of the general ledger. System accounts are the ones the sales,
+// refunds and staff transactions post to.
+type Account struct {
+	ID          string    `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	AccountType string    `json:"account_type"`
+	System      bool      `json:"system"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateAccount struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	AccountType string `json:"account_type"`
+}
+
+type AccountsResponse struct {
+	Accounts []Account `json:"accounts"`
+	Count    int       `json:"count"`
+}
+
+// JournalEntry is a balanced set of postings, SourceType and SourceID tell what it was
+// posted for. A reversal posts the same lines on the other side.
+type JournalEntry struct {
+	ID          string    `json:"id"`
+	EntryDate   time.Time `json:"entry_date"`
+	Description string    `json:"description"`
+	SourceType  string    `json:"source_type"`
+	SourceID    string    `json:"source_id"`
+	ReversalOf  string    `json:"reversal_of"`
+	ReversedBy  string    `json:"reversed_by"`
+	CreatedBy   string    `json:"created_by"`
+	Postings    []Posting `json:"postings"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Posting struct {
+	ID          string  `json:"id"`
+	AccountID   string  `json:"account_id"`
+	AccountCode string  `json:"account_code"`
+	AccountName string  `json:"account_name"`
+	StaffID     string  `json:"staff_id"`
+	BranchID    string  `json:"branch_id"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+}
+
+// CreateJournalEntry posts an entry, a zero EntryDate posts it now.
+type CreateJournalEntry struct {
+	EntryDate   time.Time       `json:"entry_date"`
+	Description string          `json:"description"`
+	SourceType  string          `json:"-"`
+	SourceID    string          `json:"-"`
+	ReversalOf  string          `json:"-"`
+	CreatedBy   string          `json:"-"`
+	Postings    []CreatePosting `json:"postings"`
+}
+
+// CreatePosting is one line of an entry, exactly one of Debit and Credit is positive.
+type CreatePosting struct {
+	AccountCode string  `json:"account_code"`
+	StaffID     string  `json:"staff_id"`
+	BranchID    string  `json:"branch_id"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+}
+
+type ReverseJournalEntry struct {
+	ID          string `json:"-"`
+	CreatedBy   string `json:"-"`
+	Description string `json:"description"`
+}
+
+type JournalEntryGetListRequest struct {
+	Page        int
+	Limit       int
+	SourceType  string
+	SourceID    string
+	AccountCode string
+	From        time.Time
+	To          time.Time
+}
+
+type JournalEntriesResponse struct {
+	JournalEntries []JournalEntry `json:"journal_entries"`
+	Count          int            `json:"count"`
+}
+
+// TrialBalanceRequest takes the entries before To, a zero To takes every entry.
+type TrialBalanceRequest struct {
+	BranchID string
+	To       time.Time
+}
+
+// TrialBalance lists the balance of every account. Debit and Credit are the totals of the
+// balance columns and are equal when the ledger is sound.
+type TrialBalance struct {
+	BranchID string             `json:"branch_id"`
+	Lines    []TrialBalanceLine `json:"lines"`
+	Debit    float64            `json:"debit"`
+	Credit   float64            `json:"credit"`
+}
+
+// TrialBalanceLine has the turnover of the account in TotalDebit and TotalCredit, and the
+// balance in whichever of Debit and Credit it falls on.
+type TrialBalanceLine struct {
+	AccountID   string  `json:"account_id"`
+	AccountCode string  `json:"account_code"`
+	AccountName string  `json:"account_name"`
+	AccountType string  `json:"account_type"`
+	TotalDebit  float64 `json:"total_debit"`
+	TotalCredit float64 `json:"total_credit"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+}
+
+// AccountStatementRequest takes the postings from From up to To of the account, a zero
+// From starts from the first posting and a zero To ends now. StaffID and BranchID narrow
+// it down to the postings of one staff or branch.
+type AccountStatementRequest struct {
+	AccountID string
+	StaffID   string
+	BranchID  string
+	From      time.Time
+	To        time.Time
+}
+
+// AccountStatement balances follow the side the account grows on, debit for assets and
+// expenses and credit for the others.
+type AccountStatement struct {
+	Account        Account                `json:"account"`
+	StaffID        string                 `json:"staff_id"`
+	BranchID       string                 `json:"branch_id"`
+	OpeningBalance float64                `json:"opening_balance"`
+	Debit          float64                `json:"debit"`
+	Credit         float64                `json:"credit"`
+	ClosingBalance float64                `json:"closing_balance"`
+	Lines          []AccountStatementLine `json:"lines"`
+}
+
+type AccountStatementLine struct {
+	EntryID     string    `json:"entry_id"`
+	EntryDate   time.Time `json:"entry_date"`
+	Description string    `json:"description"`
+	SourceType  string    `json:"source_type"`
+	SourceID    string    `json:"source_id"`
+	StaffID     string    `json:"staff_id"`
+	BranchID    string    `json:"branch_id"`
+	Debit       float64   `json:"debit"`
+	Credit      float64   `json:"credit"`
+	Balance     float64   `json:"balance"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// PayrollRun settles the earnings of a month. It is created as a draft for review and
+// approving it posts the salary top ups and the pay out withdraws.
+type PayrollRun struct {
+	ID         string        `json:"id"`
+	Month      string        `json:"month"`
+	BranchID   string        `json:"branch_id"`
+	Status     string        `json:"status"`
+	CreatedBy  string        `json:"created_by"`
+	ApprovedBy string        `json:"approved_by"`
+	ApprovedAt *time.Time    `json:"approved_at"`
+	Total      float64       `json:"total"`
+	Items      []PayrollItem `json:"items"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+// PayrollItem is the payslip of one staff, Net is BaseSalary + Commissions + Bonuses - Fines.
+// Paid is what the approval paid out, it is below Net when the balance could not cover it.
+type PayrollItem struct {
+	ID                  string  `json:"id"`
+	StaffID             string  `json:"staff_id"`
+	StaffName           string  `json:"staff_name"`
+	BaseSalary          float64 `json:"base_salary"`
+	Commissions         float64 `json:"commissions"`
+	Bonuses             float64 `json:"bonuses"`
+	Fines               float64 `json:"fines"`
+	Net                 float64 `json:"net"`
+	Paid                float64 `json:"paid"`
+	SalaryTransactionID string  `json:"salary_transaction_id"`
+	PayoutTransactionID string  `json:"payout_transaction_id"`
+}
+
+type CreatePayrollRun struct {
+	Month     string `json:"month"`
+	BranchID  string `json:"branch_id"`
+	CreatedBy string `json:"-"`
+}
+
+type PayrollRunGetListRequest struct {
+	Page     int
+	Limit    int
+	Status   string
+	BranchID string
+}
+
+type PayrollRunsResponse struct {
+	PayrollRuns []PayrollRun `json:"payroll_runs"`
+	Count       int          `json:"count"`
+}
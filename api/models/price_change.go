@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// PriceChange reprices ProductIDs, or every product of the CategoryID subtree,
+// at EffectiveAt. An absolute change adds Value to the price, a percent change
+// adds Value percent. Rounding is none, nearest, up or down to RoundingStep.
+type PriceChange struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	ChangeType   string     `json:"change_type"`
+	Value        float64    `json:"value"`
+	Rounding     string     `json:"rounding"`
+	RoundingStep int        `json:"rounding_step"`
+	CategoryID   string     `json:"category_id"`
+	ProductIDs   []string   `json:"product_ids"`
+	EffectiveAt  time.Time  `json:"effective_at"`
+	Status       string     `json:"status"`
+	AppliedAt    *time.Time `json:"applied_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type CreatePriceChange struct {
+	Name         string    `json:"name"`
+	ChangeType   string    `json:"change_type"`
+	Value        float64   `json:"value"`
+	Rounding     string    `json:"rounding"`
+	RoundingStep int       `json:"rounding_step"`
+	CategoryID   string    `json:"category_id"`
+	ProductIDs   []string  `json:"product_ids"`
+	EffectiveAt  time.Time `json:"effective_at"`
+}
+
+type PriceChangesResponse struct {
+	PriceChanges []PriceChange
+	Count        int
+}
+
+type PriceChangePreviewItem struct {
+	ProductID string `json:"product_id"`
+	Name      string `json:"name"`
+	OldPrice  int    `json:"old_price"`
+	NewPrice  int    `json:"new_price"`
+}
+
+type PriceChangePreview struct {
+	Items []PriceChangePreviewItem `json:"items"`
+	Count int                      `json:"count"`
+}
+
+type ProductPriceHistory struct {
+	ID            string    `json:"id"`
+	ProductID     string    `json:"product_id"`
+	OldPrice      int       `json:"old_price"`
+	NewPrice      int       `json:"new_price"`
+	PriceChangeID string    `json:"price_change_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ProductPriceHistoryResponse struct {
+	History []ProductPriceHistory
+	Count   int
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// PriceList overrides the base price of some products in the branches it is
+// assigned to. Products without an item keep their base price, variants with
+// their own price keep it.
+type PriceList struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Items     []PriceListItem `json:"items"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type CreatePriceList struct {
+	Name string `json:"name"`
+}
+
+type UpdatePriceList struct {
+	ID   string `json:"-"`
+	Name string `json:"name"`
+}
+
+type PriceListsResponse struct {
+	PriceLists []PriceList
+	Count      int
+}
+
+type PriceListItem struct {
+	ID          string    `json:"id"`
+	PriceListID string    `json:"price_list_id"`
+	ProductID   string    `json:"product_id"`
+	Price       int       `json:"price"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SetPriceListItem struct {
+	PriceListID string `json:"-"`
+	ProductID   string `json:"product_id"`
+	Price       int    `json:"price"`
+}
+
+// PriceListHistory is one change of a price list item. OldPrice is empty for
+// created items and NewPrice for removed ones.
+type PriceListHistory struct {
+	ID          string    `json:"id"`
+	PriceListID string    `json:"price_list_id"`
+	ProductID   string    `json:"product_id"`
+	Action      string    `json:"action"`
+	OldPrice    *int      `json:"old_price"`
+	NewPrice    *int      `json:"new_price"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PriceListHistoryRequest struct {
+	Page        int
+	Limit       int
+	PriceListID string
+	ProductID   string
+}
+
+type PriceListHistoryResponse struct {
+	History []PriceListHistory
+	Count   int
+}
+
+type AssignPriceList struct {
+	BranchID    string `json:"-"`
+	PriceListID string `json:"price_list_id"`
+}
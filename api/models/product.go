@@ -3,28 +3,37 @@ package models
 import "time"
 
 type Product struct {
-	ID         string    `json:"id"`
-	Name       string    `json:"name"`
-	Price      int       `json:"price"`
-	Barcode    int       `json:"barcode"`
-	CategoryID string    `json:"category_id"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	DeletedAt  time.Time `json:"-"`
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	Price      int              `json:"price"`
+	Barcode    string           `json:"barcode"`
+	Barcodes   []ProductBarcode `json:"barcodes"`
+	PLU        string           `json:"plu"`
+	CategoryID string           `json:"category_id"`
+	Unit       string           `json:"unit"`
+	Variants   []ProductVariant `json:"variants"`
+	Images     []ProductImage   `json:"images"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+	DeletedAt  time.Time        `json:"-"`
 }
 
 type CreateProduct struct {
 	Name       string `json:"name"`
 	Price      int    `json:"price"`
-	Barcode    int    `json:"barcode"`
+	Barcode    string `json:"barcode"`
+	PLU        string `json:"plu"`
 	CategoryID string `json:"category_id"`
+	Unit       string `json:"unit"`
 }
 
 type UpdateProduct struct {
 	ID         string `json:"-"`
 	Name       string `json:"name"`
 	Price      int    `json:"price"`
+	PLU        string `json:"plu"`
 	CategoryID string `json:"category_id"`
+	Unit       string `json:"unit"`
 }
 
 type ProductResponse struct {
@@ -33,8 +42,36 @@ type ProductResponse struct {
 }
 
 type ProductGetListRequest struct {
-	Page    int    `json:"page"`
-	Limit   int    `json:"limit"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Name       string `json:"name"`
+	Barcode    string `json:"barcode"`
+	CategoryID string `json:"category_id"`
+}
+
+type ProductSearchRequest struct {
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Query      string `json:"query"`
+	CategoryID string `json:"category_id"`
+	MinPrice   int    `json:"min_price"`
+	MaxPrice   int    `json:"max_price"`
+	BranchID   string `json:"branch_id"`
+}
+
+type ProductSearchResult struct {
+	Product
+	Rank float64 `json:"rank"`
+}
+
+type ProductSearchResponse struct {
+	Products []ProductSearchResult
+	Count    int
+}
+
+type ProductSuggestion struct {
+	ID      string `json:"id"`
 	Name    string `json:"name"`
-	Barcode int    `json:"barcode"`
+	Price   int    `json:"price"`
+	Barcode string `json:"barcode"`
 }
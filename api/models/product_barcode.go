@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// ProductBarcode is one of the codes a product or one of its variants is
+// scanned by. Kind is ean8, ean13, upca or internal.
+type ProductBarcode struct {
+	ID        string    `json:"id"`
+	ProductID string    `json:"product_id"`
+	VariantID string    `json:"variant_id"`
+	Code      string    `json:"code"`
+	Kind      string    `json:"kind"`
+	IsPrimary bool      `json:"is_primary"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateProductBarcode struct {
+	ProductID string `json:"-"`
+	VariantID string `json:"variant_id"`
+	Code      string `json:"code"`
+	Kind      string `json:"kind"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// BarcodeLookup is the product, and the variant when the code belongs to one,
+// found by a scanned code.
+type BarcodeLookup struct {
+	Barcode ProductBarcode  `json:"barcode"`
+	Product Product         `json:"product"`
+	Variant *ProductVariant `json:"variant"`
+}
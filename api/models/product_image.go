@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// ProductImage is one image of a product gallery, ordered by Position.
+// URL and ThumbnailURL are API paths that serve the files.
+type ProductImage struct {
+	ID           string    `json:"id"`
+	ProductID    string    `json:"product_id"`
+	Position     int       `json:"position"`
+	ContentType  string    `json:"content_type"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	OriginalKey  string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CreateProductImage struct {
+	ID           string `json:"-"`
+	ProductID    string `json:"-"`
+	ContentType  string `json:"-"`
+	OriginalKey  string `json:"-"`
+	ThumbnailKey string `json:"-"`
+}
+
+type ReorderProductImages struct {
+	ProductID string   `json:"-"`
+	ImageIDs  []string `json:"image_ids"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// ProductImportRow is one product of an import or export file.
+// Stock holds the initial stock by branch id.
+type ProductImportRow struct {
+	Line         int                `json:"line"`
+	Name         string             `json:"name"`
+	Price        int                `json:"price"`
+	Barcode      string             `json:"barcode"`
+	CategoryPath string             `json:"category_path"`
+	Unit         string             `json:"unit"`
+	Stock        map[string]float64 `json:"stock"`
+}
+
+type ProductImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type ProductImportReport struct {
+	DryRun            bool                 `json:"dry_run"`
+	Rows              int                  `json:"rows"`
+	Created           int                  `json:"created"`
+	Updated           int                  `json:"updated"`
+	CategoriesCreated int                  `json:"categories_created"`
+	Errors            []ProductImportError `json:"errors"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ProductUnit is an alternative unit a product can be sold in, e.g. a box of 12 pieces.
+// Factor is the number of the product's base units in one ProductUnit.
+type ProductUnit struct {
+	ID        string     `json:"id"`
+	ProductID string     `json:"product_id"`
+	Name      string     `json:"name"`
+	Factor    float64    `json:"factor"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"-"`
+}
+
+type CreateProductUnit struct {
+	ProductID string  `json:"product_id"`
+	Name      string  `json:"name"`
+	Factor    float64 `json:"factor"`
+}
+
+type UpdateProductUnit struct {
+	ID        string  `json:"-"`
+	ProductID string  `json:"product_id"`
+	Name      string  `json:"name"`
+	Factor    float64 `json:"factor"`
+}
+
+type ProductUnitsResponse struct {
+	ProductUnits []ProductUnit `json:"product_units"`
+	Count        int           `json:"count"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// ProductVariant is a sellable version of a product such as a size or a colour.
+// Price overrides the product price, the branch price list one included, when it is not 0.
+type ProductVariant struct {
+	ID         string            `json:"id"`
+	ProductID  string            `json:"product_id"`
+	Name       string            `json:"name"`
+	Attributes map[string]string `json:"attributes"`
+	Barcode    string            `json:"barcode"`
+	Price      int               `json:"price"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	DeletedAt  *time.Time        `json:"-"`
+}
+
+type CreateProductVariant struct {
+	ProductID  string            `json:"product_id"`
+	Name       string            `json:"name"`
+	Attributes map[string]string `json:"attributes"`
+	Barcode    string            `json:"barcode"`
+	Price      int               `json:"price"`
+}
+
+type UpdateProductVariant struct {
+	ID         string            `json:"-"`
+	ProductID  string            `json:"product_id"`
+	Name       string            `json:"name"`
+	Attributes map[string]string `json:"attributes"`
+	Barcode    string            `json:"barcode"`
+	Price      int               `json:"price"`
+}
+
+type ProductVariantsResponse struct {
+	ProductVariants []ProductVariant `json:"product_variants"`
+	Count           int              `json:"count"`
+}
@@ -5,24 +5,32 @@ import "time"
 type Repository struct {
 	ID        string     `json:"id"`
 	ProductID string     `json:"product_id"`
+	VariantID string     `json:"variant_id"`
 	BranchID  string     `json:"branch_id"`
-	Count     int        `json:"count"`
+	Count     float64    `json:"count"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"-"`
 }
 
+// CreateRepository takes stock in, StaffID is who records the plus repository transaction.
 type CreateRepository struct {
-	ProductID string `json:"product_id"`
-	BranchID  string `json:"branch_id"`
-	Count     int    `json:"count"`
+	ProductID string  `json:"product_id"`
+	VariantID string  `json:"variant_id"`
+	BranchID  string  `json:"branch_id"`
+	Count     float64 `json:"count"`
+	StaffID   string  `json:"-"`
 }
 
+// UpdateRepository corrects a stock row, the difference is recorded as repository transactions
+// by StaffID.
 type UpdateRepository struct {
-	ID        string `json:"-"`
-	ProductID string `json:"product_id"`
-	BranchID  string `json:"branch_id"`
-	Count     int    `json:"count"`
+	ID        string  `json:"-"`
+	ProductID string  `json:"product_id"`
+	VariantID string  `json:"variant_id"`
+	BranchID  string  `json:"branch_id"`
+	Count     float64 `json:"count"`
+	StaffID   string  `json:"-"`
 }
 
 type RepositoriesResponse struct {
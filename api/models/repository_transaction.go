@@ -4,31 +4,42 @@ import "time"
 
 type RepositoryTransaction struct {
 	ID                        string     `json:"id"`
+	BranchID                  string     `json:"branch_id"`
 	StaffID                   string     `json:"staff_id"`
 	ProductID                 string     `json:"product_id"`
+	VariantID                 string     `json:"variant_id"`
+	SaleID                    string     `json:"sale_id"`
 	RepositoryTransactionType string     `json:"repository_transaction_type"`
-	Price                     int        `json:"price"`
-	Quantity                  int        `json:"quantity"`
+	Price                     float64    `json:"price"`
+	Quantity                  float64    `json:"quantity"`
+	Reason                    string     `json:"reason"`
 	CreatedAt                 time.Time  `json:"created_at"`
 	UpdatedAt                 time.Time  `json:"updated_at"`
 	DeletedAt                 *time.Time `json:"-"`
 }
 
 type CreateRepositoryTransaction struct {
-	StaffID                   string `json:"staff_id"`
-	ProductID                 string `json:"product_id"`
-	RepositoryTransactionType string `json:"repository_transaction_type"`
-	Price                     int    `json:"price"`
-	Quantity                  int    `json:"quantity"`
+	BranchID                  string  `json:"branch_id"`
+	StaffID                   string  `json:"staff_id"`
+	ProductID                 string  `json:"product_id"`
+	VariantID                 string  `json:"variant_id"`
+	SaleID                    string  `json:"-"`
+	RepositoryTransactionType string  `json:"repository_transaction_type"`
+	Price                     float64 `json:"price"`
+	Quantity                  float64 `json:"quantity"`
+	Reason                    string  `json:"reason"`
 }
 
 type UpdateRepositoryTransaction struct {
-	ID                        string `json:"-"`
-	StaffID                   string `json:"staff_id"`
-	ProductID                 string `json:"product_id"`
-	RepositoryTransactionType string `json:"repository_transaction_type"`
-	Price                     int    `json:"price"`
-	Quantity                  int    `json:"quantity"`
+	ID                        string  `json:"-"`
+	BranchID                  string  `json:"branch_id"`
+	StaffID                   string  `json:"staff_id"`
+	ProductID                 string  `json:"product_id"`
+	VariantID                 string  `json:"variant_id"`
+	RepositoryTransactionType string  `json:"repository_transaction_type"`
+	Price                     float64 `json:"price"`
+	Quantity                  float64 `json:"quantity"`
+	Reason                    string  `json:"reason"`
 }
 
 type RepositoryTransactionsResponse struct {
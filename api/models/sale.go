@@ -15,25 +15,39 @@ type Sale struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// CreateSale starts a sale in process, its price and status are set by ending and refunding it.
 type CreateSale struct {
-	BranchID        string  `json:"branch_id"`
-	ShopAssistantID string  `json:"shop_assistant_id"`
-	CashierID       string  `json:"cashier_id"`
-	PaymentType     string  `json:"payment_type"`
-	Price           float32 `json:"price"`
-	Status          string  `json:"status"`
-	ClientName      string  `json:"client_name"`
+	BranchID        string `json:"branch_id"`
+	ShopAssistantID string `json:"shop_assistant_id"`
+	CashierID       string `json:"cashier_id"`
+	PaymentType     string `json:"payment_type"`
+	ClientName      string `json:"client_name"`
 }
 
+// UpdateSale changes the details of a sale in process.
 type UpdateSale struct {
-	ID              string  `json:"-"`
-	BranchID        string  `json:"branch_id"`
-	ShopAssistantID string  `json:"shop_assistant_id"`
-	CashierID       string  `json:"cashier_id"`
-	PaymentType     string  `json:"payment_type"`
-	Price           float32 `json:"price"`
-	Status          string  `json:"status"`
-	ClientName      string  `json:"client_name"`
+	ID              string `json:"-"`
+	BranchID        string `json:"branch_id"`
+	ShopAssistantID string `json:"shop_assistant_id"`
+	CashierID       string `json:"cashier_id"`
+	PaymentType     string `json:"payment_type"`
+	ClientName      string `json:"client_name"`
+}
+
+// EndSale finishes a sale at Price, Movements are the stock its baskets take.
+type EndSale struct {
+	ID        string
+	Price     float64
+	StaffID   string
+	Movements []CreateRepositoryTransaction
+}
+
+// RefundSale cancels a finished sale. The stock goes back by the repository transactions the
+// sale wrote when it ended, Movements only for sales ended before they carried the sale id.
+type RefundSale struct {
+	ID        string
+	StaffID   string
+	Movements []CreateRepositoryTransaction
 }
 
 type SaleResponse struct {
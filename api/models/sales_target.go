@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// SalesTarget is a monthly sales goal of a staff or of a branch. Every tier the completed
+// sales reach by the close of the month pays its bonus once, to the staff or to every staff
+// who was the cashier or the shop assistant of a completed sale of the branch.
+type SalesTarget struct {
+	ID        string            `json:"id"`
+	StaffID   string            `json:"staff_id"`
+	BranchID  string            `json:"branch_id"`
+	Month     string            `json:"month"`
+	CreatedBy string            `json:"created_by"`
+	Tiers     []SalesTargetTier `json:"tiers"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type SalesTargetTier struct {
+	ID        string  `json:"id"`
+	Threshold float64 `json:"threshold"`
+	Bonus     float64 `json:"bonus"`
+}
+
+type CreateSalesTarget struct {
+	StaffID   string            `json:"staff_id"`
+	BranchID  string            `json:"branch_id"`
+	Month     string            `json:"month"`
+	Tiers     []SalesTargetTier `json:"tiers"`
+	CreatedBy string            `json:"-"`
+}
+
+type SalesTargetGetListRequest struct {
+	Page     int
+	Limit    int
+	Month    string
+	StaffID  string
+	BranchID string
+}
+
+type SalesTargetsResponse struct {
+	SalesTargets []SalesTarget `json:"sales_targets"`
+	Count        int           `json:"count"`
+}
+
+// SalesTargetProgress is how far the completed sales of the month are from the tiers,
+// Remaining is what is left to the next tier.
+type SalesTargetProgress struct {
+	TargetID      string                    `json:"target_id"`
+	StaffID       string                    `json:"staff_id"`
+	BranchID      string                    `json:"branch_id"`
+	Month         string                    `json:"month"`
+	Sales         float64                   `json:"sales"`
+	NextThreshold float64                   `json:"next_threshold"`
+	Remaining     float64                   `json:"remaining"`
+	Tiers         []SalesTargetTierProgress `json:"tiers"`
+}
+
+type SalesTargetTierProgress struct {
+	Threshold float64 `json:"threshold"`
+	Bonus     float64 `json:"bonus"`
+	Reached   bool    `json:"reached"`
+	Paid      bool    `json:"paid"`
+}
+
+type SalesTargetProgressRequest struct {
+	Month    string
+	StaffID  string
+	BranchID string
+}
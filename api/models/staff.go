@@ -8,11 +8,10 @@ type Staff struct {
 	TariffID  string    `json:"tariff_id"`
 	StaffType string    `json:"staff_type"`
 	Name      string    `json:"name"`
-	Balance   uint      `json:"balance"`
+	Balance   float64   `json:"balance"`
 	Age       uint      `json:"age"`
 	BirthDate string    `json:"birth_date"`
 	Login     string    `json:"login"`
-	Password  string    `json:"password"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -22,7 +21,6 @@ type CreateStaff struct {
 	TariffID  string `json:"tariff_id"`
 	StaffType string `json:"staff_type"`
 	Name      string `json:"name"`
-	Balance   uint   `json:"balance"`
 	BirthDate string `json:"birth_date"`
 	Login     string `json:"login"`
 	Password  string `json:"password"`
@@ -34,8 +32,15 @@ type UpdateStaff struct {
 	TariffID  string `json:"tariff_id"`
 	StaffType string `json:"staff_type"`
 	Name      string `json:"name"`
-	Balance   uint   `json:"balance"`
 	Login     string `json:"login"`
+	ChangedBy string `json:"-"`
+}
+
+type StaffGetListRequest struct {
+	Page     int
+	Limit    int
+	Search   string
+	BranchID string
 }
 
 type StaffsResponse struct {
@@ -48,3 +53,26 @@ type UpdateStaffPassword struct {
 	NewPassword string `json:"new_password"`
 	OldPassword string `json:"old_password"`
 }
+
+// StaffAssignment is the branch and tariff of a staff for a period, EffectiveTo is nil for
+// the current one.
+type StaffAssignment struct {
+	ID            string     `json:"id"`
+	StaffID       string     `json:"staff_id"`
+	BranchID      string     `json:"branch_id"`
+	TariffID      string     `json:"tariff_id"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+	ChangedBy     string     `json:"changed_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// CreateStaffAssignment moves a staff to a branch and tariff from EffectiveFrom, a day in
+// YYYY-MM-DD format, on. It is now when empty.
+type CreateStaffAssignment struct {
+	StaffID       string `json:"-"`
+	BranchID      string `json:"branch_id"`
+	TariffID      string `json:"tariff_id"`
+	EffectiveFrom string `json:"effective_from"`
+	ChangedBy     string `json:"-"`
+}
@@ -3,14 +3,16 @@ package models
 import "time"
 
 type StaffTariff struct {
-	ID            string     `json:"id"`
-	Name          string     `json:"name"`
-	TariffType    string     `json:"tariff_type"`
-	AmountForCash int        `json:"amount_for_cash"`
-	AmountForCard int        `json:"amount_for_card"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
-	DeletedAt     *time.Time `json:"-"`
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	TariffType    string           `json:"tariff_type"`
+	AmountForCash int              `json:"amount_for_cash"`
+	AmountForCard int              `json:"amount_for_card"`
+	BaseSalary    int              `json:"base_salary"`
+	Rules         []CommissionRule `json:"rules"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+	DeletedAt     *time.Time       `json:"-"`
 }
 
 type CreateStaffTariff struct {
@@ -18,6 +20,7 @@ type CreateStaffTariff struct {
 	TariffType    string `json:"tariff_type"`
 	AmountForCash int    `json:"amount_for_cash"`
 	AmountForCard int    `json:"amount_for_card"`
+	BaseSalary    int    `json:"base_salary"`
 }
 
 type UpdateStaffTariff struct {
@@ -26,6 +29,7 @@ type UpdateStaffTariff struct {
 	TariffType    string `json:"tariff_type"`
 	AmountForCash int    `json:"amount_for_cash"`
 	AmountForCard int    `json:"amount_for_card"`
+	BaseSalary    int    `json:"base_salary"`
 }
 
 type StaffTariffResponse struct {
@@ -0,0 +1,23 @@
+package models
+
+// StockMismatch compares the stock a product should have by the repository transactions
+// ledger (Expected) with the count stored in repositories (Actual).
+type StockMismatch struct {
+	BranchID   string  `json:"branch_id"`
+	ProductID  string  `json:"product_id"`
+	VariantID  string  `json:"variant_id"`
+	Expected   float64 `json:"expected"`
+	Actual     float64 `json:"actual"`
+	Difference float64 `json:"difference"`
+}
+
+type StockReconcileRequest struct {
+	BranchID string `json:"branch_id"`
+	StaffID  string `json:"staff_id"`
+}
+
+type StockReconcileResponse struct {
+	Mismatches []StockMismatch `json:"mismatches"`
+	Count      int             `json:"count"`
+	Corrected  bool            `json:"corrected"`
+}
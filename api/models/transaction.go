@@ -39,11 +39,64 @@ type UpdateTransaction struct {
 type TransactionResponse struct {
 	Transactions []Transaction
 	Count        int
+	Totals       TransactionTotals
 }
 
+// TransactionTotals sums every transaction of the filters, not only the page.
+type TransactionTotals struct {
+	Topups    float64               `json:"topups"`
+	Withdraws float64               `json:"withdraws"`
+	Net       float64               `json:"net"`
+	BySource  []TransactionSubtotal `json:"by_source"`
+}
+
+type TransactionSubtotal struct {
+	TransactionType string  `json:"transaction_type"`
+	SourceType      string  `json:"source_type"`
+	Count           int     `json:"count"`
+	Amount          float64 `json:"amount"`
+}
+
+// TransactionGetListRequest filters transactions, zero amounts and times are no bound. ToDate is
+// exclusive, SortBy is created_at or amount and SortOrder asc or desc.
 type TransactionGetListRequest struct {
-	Page       int     `json:"page"`
-	Limit      int     `json:"limit"`
-	FromAmount float64 `json:"from_amount"`
-	ToAmount   float64 `json:"to_amount"`
+	Page            int       `json:"page"`
+	Limit           int       `json:"limit"`
+	FromAmount      float64   `json:"from_amount"`
+	ToAmount        float64   `json:"to_amount"`
+	StaffID         string    `json:"staff_id"`
+	SaleID          string    `json:"sale_id"`
+	TransactionType string    `json:"transaction_type"`
+	SourceType      string    `json:"source_type"`
+	FromDate        time.Time `json:"from_date"`
+	ToDate          time.Time `json:"to_date"`
+	SortBy          string    `json:"sort_by"`
+	SortOrder       string    `json:"sort_order"`
+}
+
+// StaffStatement is the ledger of a staff for a period, ClosingBalance is OpeningBalance
+// plus the top ups less the withdraws of the period.
+type StaffStatement struct {
+	StaffID        string        `json:"staff_id"`
+	From           string        `json:"from"`
+	To             string        `json:"to"`
+	OpeningBalance float64       `json:"opening_balance"`
+	Topups         float64       `json:"topups"`
+	Withdraws      float64       `json:"withdraws"`
+	ClosingBalance float64       `json:"closing_balance"`
+	Transactions   []Transaction `json:"transactions"`
+}
+
+type StaffStatementRequest struct {
+	StaffID string
+	From    time.Time
+	To      time.Time
+}
+
+// BalanceMismatch is a staff whose stored balance differs from the sum of the ledger.
+type BalanceMismatch struct {
+	StaffID    string  `json:"staff_id"`
+	Stored     float64 `json:"stored"`
+	Ledger     float64 `json:"ledger"`
+	Difference float64 `json:"difference"`
 }
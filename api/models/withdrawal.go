@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// WithdrawalRequest is a staff asking to cash out part of the balance. Approving it
+// writes the withdraw transaction and takes the amount off the balance.
+type WithdrawalRequest struct {
+	ID            string            `json:"id"`
+	StaffID       string            `json:"staff_id"`
+	Amount        int               `json:"amount"`
+	Status        string            `json:"status"`
+	Note          string            `json:"note"`
+	DecidedBy     string            `json:"decided_by"`
+	DecidedAt     *time.Time        `json:"decided_at"`
+	TransactionID string            `json:"transaction_id"`
+	Events        []WithdrawalEvent `json:"events"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// WithdrawalEvent is one step of the audit trail of a request, Balance is the staff
+// balance right after it.
+type WithdrawalEvent struct {
+	ID        string    `json:"id"`
+	ActorID   string    `json:"actor_id"`
+	Action    string    `json:"action"`
+	Note      string    `json:"note"`
+	Balance   float64   `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateWithdrawalRequest struct {
+	StaffID string `json:"-"`
+	Amount  int    `json:"amount"`
+	Note    string `json:"note"`
+}
+
+type WithdrawalDecision struct {
+	ID      string `json:"-"`
+	ActorID string `json:"-"`
+	Note    string `json:"note"`
+}
+
+type WithdrawalRequestGetListRequest struct {
+	Page     int
+	Limit    int
+	StaffID  string
+	BranchID string
+	Status   string
+}
+
+type WithdrawalRequestsResponse struct {
+	WithdrawalRequests []WithdrawalRequest `json:"withdrawal_requests"`
+	Count              int                 `json:"count"`
+}
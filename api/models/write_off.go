@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// WriteOff takes stock out of a branch without a sale. It changes the stock only
+// after a branch manager approves it.
+type WriteOff struct {
+	ID         string         `json:"id"`
+	BranchID   string         `json:"branch_id"`
+	StaffID    string         `json:"staff_id"`
+	Reason     string         `json:"reason"`
+	Status     string         `json:"status"`
+	Note       string         `json:"note"`
+	ApprovedBy string         `json:"approved_by"`
+	ApprovedAt *time.Time     `json:"approved_at"`
+	Items      []WriteOffItem `json:"items"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  *time.Time     `json:"-"`
+}
+
+type WriteOffItem struct {
+	ProductID string  `json:"product_id"`
+	VariantID string  `json:"variant_id"`
+	Quantity  float64 `json:"quantity"`
+}
+
+type CreateWriteOff struct {
+	BranchID string         `json:"branch_id"`
+	StaffID  string         `json:"staff_id"`
+	Reason   string         `json:"reason"`
+	Note     string         `json:"note"`
+	Items    []WriteOffItem `json:"items"`
+}
+
+type WriteOffDecision struct {
+	ID        string `json:"-"`
+	ManagerID string `json:"-"`
+}
+
+type WriteOffGetListRequest struct {
+	Page     int
+	Limit    int
+	BranchID string
+	Status   string
+}
+
+type WriteOffsResponse struct {
+	WriteOffs []WriteOff `json:"write_offs"`
+	Count     int        `json:"count"`
+}
+
+type WriteOffReportRow struct {
+	BranchID  string  `json:"branch_id"`
+	Reason    string  `json:"reason"`
+	WriteOffs int     `json:"write_offs"`
+	Quantity  float64 `json:"quantity"`
+	Amount    float64 `json:"amount"`
+}
+
+type WriteOffReportResponse struct {
+	Month string              `json:"month"`
+	Rows  []WriteOffReportRow `json:"rows"`
+}
@@ -3,6 +3,9 @@ package api
 import (
 	_ "sell/api/docs"
 	"sell/api/handler"
+	"sell/config"
+	"sell/pkg/blob"
+	"sell/pkg/rbac"
 	"sell/storage"
 
 	"github.com/gin-gonic/gin"
@@ -14,76 +17,218 @@ import (
 // @title           Swagger Example API
 // @version         1.0
 // @description     This is a sample server celler server.
-func New(storage storage.IStorage) *gin.Engine {
-	h := handler.New(storage)
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+// @description Bearer access token from /auth/login
+func New(cfg config.Config, storage storage.IStorage, blobStore blob.Store) *gin.Engine {
+	h := handler.New(cfg, storage, blobStore)
 
 	r := gin.New()
 
-	r.POST("/sell", h.StartSell)
-	r.PUT("/end-sell/:id", h.EndSell)
-
-	r.POST("/category", h.CreateCategory)
-	r.GET("/category/:id", h.GetCategory)
-	r.GET("/categories", h.GetCategoryList)
-	r.PUT("/category/:id", h.UpdateCategory)
-	r.DELETE("/category/:id", h.DeleteCategory)
-
-	r.POST("/product", h.CreateProduct)
-	r.GET("/product/:id", h.GetProduct)
-	r.GET("/products", h.GetProductList)
-	r.PUT("/product/:id", h.UpdateProduct)
-	r.DELETE("/product/:id", h.DeleteProduct)
-
-	r.POST("/branch", h.CreateBranch)
-	r.GET("/branch/:id", h.GetBranch)
-	r.GET("/branches", h.GetBranchList)
-	r.PUT("/branch/:id", h.UpdateBranch)
-	r.DELETE("/branch/:id", h.DeleteBranch)
-
-	r.POST("/repository", h.CreateRepository)
-	r.GET("/repository/:id", h.GetRepository)
-	r.GET("/repositories", h.GetRepositoryList)
-	r.PUT("/repository/:id", h.UpdateRepository)
-	r.DELETE("/repository/:id", h.DeleteRepository)
-
-	r.POST("/sale", h.CreateSale)
-	r.GET("/sale/:id", h.GetSale)
-	r.GET("/sales", h.GetSaleList)
-	r.PUT("/sale/:id", h.UpdateSale)
-	r.DELETE("/sale/:id", h.DeleteSale)
-
-	r.POST("/basket", h.CreateBasket)
-	r.GET("/basket/:id", h.GetBasket)
-	r.GET("/baskets", h.GetBasketList)
-	r.PUT("/basket/:id", h.UpdateBasket)
-	r.DELETE("/basket/:id", h.DeleteBasket)
-
-	r.POST("/staff-tariff", h.CreateStaffTariff)
-	r.GET("/staff-tariff/:id", h.GetStaffTariff)
-	r.GET("/staff-tariffs", h.GetStaffTariffList)
-	r.PUT("/staff-tariff/:id", h.UpdateStaffTariff)
-	r.DELETE("/staff-tariff/:id", h.DeleteStaffTariff)
-
-	r.POST("/staff", h.CreateStaff)
-	r.GET("/staff/:id", h.GetStaff)
-	r.GET("/staffs", h.GetStaffList)
-	r.PUT("/staff/:id", h.UpdateStaff)
-	r.PATCH("/staff/:id", h.UpdateStaffPassword)
-	r.DELETE("/staff/:id", h.DeleteStaff)
-
-	r.POST("/transaction", h.CreateTransaction)
-	r.GET("/transaction/:id", h.GetTransaction)
-	r.GET("/transactions", h.GetTransactionList)
-	r.PUT("/transaction/:id", h.UpdateTransaction)
-	r.DELETE("/transaction/:id", h.DeleteTransaction)
-
-	r.POST("/rtransaction", h.CreateRepositoryTransaction)
-	r.GET("/rtransaction/:id", h.GetRepositoryTransaction)
-	r.GET("/rtransactions", h.GetRepositoryTransactionList)
-	r.PUT("/rtransaction/:id", h.UpdateRepositoryTransaction)
-	r.DELETE("/rtransaction/:id", h.DeleteRepositoryTransaction)
-
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	r.POST("/auth/login", h.Login)
+	r.POST("/auth/refresh", h.RefreshToken)
+	r.POST("/auth/logout", h.Logout)
+	r.POST("/auth/reset-password", h.ResetPassword)
+
+	authorized := r.Group("/", h.AuthMiddleware())
+	view := authorized.Group("/", h.Require(rbac.ViewCatalog))
+	catalog := authorized.Group("/", h.Require(rbac.ManageCatalog))
+	sell := authorized.Group("/", h.Require(rbac.Sell))
+	report := authorized.Group("/", h.Require(rbac.ReportStock))
+	stock := authorized.Group("/", h.Require(rbac.ManageStock))
+	staff := authorized.Group("/", h.Require(rbac.ManageStaff))
+	branches := authorized.Group("/", h.Require(rbac.ManageBranches))
+	tariffs := authorized.Group("/", h.Require(rbac.ManageTariffs))
+	payroll := authorized.Group("/", h.Require(rbac.ManagePayroll))
+	approvals := authorized.Group("/", h.Require(rbac.ApprovePayroll))
+	refunds := authorized.Group("/", h.Require(rbac.RefundSales))
+	ledger := authorized.Group("/", h.Require(rbac.ManageLedger))
+
+	authorized.GET("/auth/me", h.GetMe)
+
+	sell.POST("/sell", h.StartSell)
+	sell.PUT("/end-sell/:id", h.EndSell)
+
+	catalog.POST("/category", h.CreateCategory)
+	view.GET("/category/:id", h.GetCategory)
+	view.GET("/categories", h.GetCategoryList)
+	catalog.PUT("/category/:id", h.UpdateCategory)
+	catalog.DELETE("/category/:id", h.DeleteCategory)
+	view.GET("/categories/tree", h.GetCategoryTree)
+	view.GET("/category/:id/subtree", h.GetCategorySubtree)
+	view.GET("/category/:id/breadcrumbs", h.GetCategoryBreadcrumbs)
+	view.GET("/category/:id/products", h.GetCategoryProducts)
+	catalog.PUT("/category/:id/move", h.MoveCategory)
+
+	catalog.POST("/product", h.CreateProduct)
+	view.GET("/product/:id", h.GetProduct)
+	view.GET("/products", h.GetProductList)
+	catalog.POST("/products/import", h.ImportProducts)
+	view.GET("/products/export", h.ExportProducts)
+	view.GET("/products/search", h.SearchProducts)
+	view.GET("/products/suggest", h.SuggestProducts)
+	catalog.PUT("/product/:id", h.UpdateProduct)
+	catalog.DELETE("/product/:id", h.DeleteProduct)
+	view.GET("/product/:id/price-history", h.GetProductPriceHistory)
+	catalog.POST("/product/:id/barcode", h.CreateProductBarcode)
+	view.GET("/product/:id/barcodes", h.GetProductBarcodes)
+	catalog.DELETE("/product-barcode/:id", h.DeleteProductBarcode)
+	view.GET("/barcode/:code", h.LookupBarcode)
+
+	catalog.POST("/product/:id/images", h.UploadProductImage)
+	view.GET("/product/:id/images", h.GetProductImages)
+	catalog.PUT("/product/:id/images/order", h.ReorderProductImages)
+	view.GET("/product-image/:id", h.GetProductImageFile)
+	view.GET("/product-image/:id/thumbnail", h.GetProductImageThumbnail)
+	catalog.DELETE("/product-image/:id", h.DeleteProductImage)
+
+	catalog.POST("/product-unit", h.CreateProductUnit)
+	view.GET("/product-unit/:id", h.GetProductUnit)
+	view.GET("/product-units", h.GetProductUnitList)
+	catalog.PUT("/product-unit/:id", h.UpdateProductUnit)
+	catalog.DELETE("/product-unit/:id", h.DeleteProductUnit)
+
+	catalog.POST("/product-variant", h.CreateProductVariant)
+	view.GET("/product-variant/:id", h.GetProductVariant)
+	view.GET("/product-variants", h.GetProductVariantList)
+	catalog.PUT("/product-variant/:id", h.UpdateProductVariant)
+	catalog.DELETE("/product-variant/:id", h.DeleteProductVariant)
+
+	catalog.POST("/bundle", h.CreateBundle)
+	view.GET("/bundle/:id", h.GetBundle)
+	view.GET("/bundles", h.GetBundleList)
+	catalog.PUT("/bundle/:id", h.UpdateBundle)
+	catalog.DELETE("/bundle/:id", h.DeleteBundle)
+
+	branches.POST("/branch", h.CreateBranch)
+	view.GET("/branch/:id", h.GetBranch)
+	view.GET("/branches", h.GetBranchList)
+	branches.PUT("/branch/:id", h.UpdateBranch)
+	branches.DELETE("/branch/:id", h.DeleteBranch)
+	branches.PUT("/branch/:id/price-list", h.AssignBranchPriceList)
+
+	branches.POST("/price-list", h.CreatePriceList)
+	view.GET("/price-list/:id", h.GetPriceList)
+	view.GET("/price-lists", h.GetPriceListList)
+	branches.PUT("/price-list/:id", h.UpdatePriceList)
+	branches.DELETE("/price-list/:id", h.DeletePriceList)
+	branches.PUT("/price-list/:id/item", h.SetPriceListItem)
+	branches.DELETE("/price-list/:id/item/:product_id", h.RemovePriceListItem)
+	view.GET("/price-list/:id/history", h.GetPriceListHistory)
+
+	branches.POST("/price-change", h.CreatePriceChange)
+	branches.POST("/price-change/preview", h.PreviewPriceChange)
+	view.GET("/price-change/:id", h.GetPriceChange)
+	view.GET("/price-change/:id/preview", h.GetScheduledPriceChangePreview)
+	view.GET("/price-changes", h.GetPriceChangeList)
+	branches.PUT("/price-change/:id/cancel", h.CancelPriceChange)
+
+	stock.POST("/repository", h.CreateRepository)
+	view.GET("/repository/:id", h.GetRepository)
+	view.GET("/repositories", h.GetRepositoryList)
+	stock.PUT("/repository/:id", h.UpdateRepository)
+	stock.DELETE("/repository/:id", h.DeleteRepository)
+
+	sell.POST("/sale", h.CreateSale)
+	view.GET("/sale/:id", h.GetSale)
+	view.GET("/sales", h.GetSaleList)
+	sell.PUT("/sale/:id", h.UpdateSale)
+	sell.DELETE("/sale/:id", h.DeleteSale)
+	sell.POST("/sale/:id/scan", h.ScanBasket)
+	refunds.PUT("/sale/:id/refund", h.RefundSale)
+
+	sell.POST("/basket", h.CreateBasket)
+	view.GET("/basket/:id", h.GetBasket)
+	view.GET("/baskets", h.GetBasketList)
+	sell.PUT("/basket/:id", h.UpdateBasket)
+	sell.DELETE("/basket/:id", h.DeleteBasket)
+
+	tariffs.POST("/staff-tariff", h.CreateStaffTariff)
+	tariffs.GET("/staff-tariff/:id", h.GetStaffTariff)
+	tariffs.GET("/staff-tariffs", h.GetStaffTariffList)
+	tariffs.PUT("/staff-tariff/:id", h.UpdateStaffTariff)
+	tariffs.DELETE("/staff-tariff/:id", h.DeleteStaffTariff)
+	tariffs.POST("/staff-tariff/:id/rule", h.CreateCommissionRule)
+	tariffs.PUT("/staff-tariff/:id/rule/:rule_id", h.UpdateCommissionRule)
+	tariffs.DELETE("/staff-tariff/:id/rule/:rule_id", h.DeleteCommissionRule)
+	payroll.POST("/staff-tariff/commission", h.CalculateCommission)
+
+	staff.POST("/staff", h.CreateStaff)
+	authorized.GET("/staff/:id", h.GetStaff)
+	staff.GET("/staffs", h.GetStaffList)
+	staff.PUT("/staff/:id", h.UpdateStaff)
+	authorized.PATCH("/staff/:id", h.UpdateStaffPassword)
+	staff.DELETE("/staff/:id", h.DeleteStaff)
+	staff.POST("/staff/:id/password-reset", h.CreatePasswordReset)
+	authorized.GET("/staff/:id/timesheet", h.GetStaffTimesheet)
+	authorized.GET("/staff/:id/statement", h.GetStaffStatement)
+	staff.POST("/staff/:id/assignment", h.CreateStaffAssignment)
+	authorized.GET("/staff/:id/assignments", h.GetStaffAssignments)
+
+	authorized.POST("/attendance/clock-in", h.ClockIn)
+	authorized.POST("/attendance/clock-out", h.ClockOut)
+	authorized.POST("/attendance/break-start", h.StartBreak)
+	authorized.POST("/attendance/break-end", h.EndBreak)
+	authorized.GET("/attendance/:id", h.GetAttendance)
+	staff.GET("/attendances", h.GetAttendanceList)
+	staff.PUT("/attendance/:id", h.UpdateAttendance)
+
+	payroll.POST("/payroll-run", h.CreatePayrollRun)
+	payroll.GET("/payroll-run/:id", h.GetPayrollRun)
+	payroll.GET("/payroll-runs", h.GetPayrollRunList)
+	payroll.DELETE("/payroll-run/:id", h.DeletePayrollRun)
+	approvals.PUT("/payroll-run/:id/approve", h.ApprovePayrollRun)
+	payroll.GET("/payroll-run/:id/payslips", h.DownloadPayslips)
+	authorized.GET("/payroll-run/:id/payslip/:staff_id", h.DownloadPayslip)
+
+	authorized.POST("/withdrawal-request", h.CreateWithdrawalRequest)
+	authorized.GET("/withdrawal-request/:id", h.GetWithdrawalRequest)
+	authorized.GET("/withdrawal-requests", h.GetWithdrawalRequestList)
+	authorized.PUT("/withdrawal-request/:id/approve", h.ApproveWithdrawalRequest)
+	authorized.PUT("/withdrawal-request/:id/reject", h.RejectWithdrawalRequest)
+	authorized.PUT("/withdrawal-request/:id/cancel", h.CancelWithdrawalRequest)
+
+	payroll.POST("/sales-target", h.CreateSalesTarget)
+	payroll.GET("/sales-target/:id", h.GetSalesTarget)
+	payroll.GET("/sales-targets", h.GetSalesTargetList)
+	payroll.DELETE("/sales-target/:id", h.DeleteSalesTarget)
+	authorized.GET("/sales-targets/progress", h.GetSalesTargetProgress)
+
+	tariffs.POST("/transaction", h.CreateTransaction)
+	tariffs.GET("/transaction/:id", h.GetTransaction)
+	tariffs.GET("/transactions", h.GetTransactionList)
+	tariffs.PUT("/transaction/:id", h.UpdateTransaction)
+	tariffs.DELETE("/transaction/:id", h.DeleteTransaction)
+
+	ledger.POST("/account", h.CreateAccount)
+	ledger.GET("/accounts", h.GetAccountList)
+	ledger.GET("/account/:id/statement", h.GetAccountStatement)
+	ledger.GET("/ledger/trial-balance", h.GetTrialBalance)
+	ledger.POST("/journal-entry", h.CreateJournalEntry)
+	ledger.GET("/journal-entry/:id", h.GetJournalEntry)
+	ledger.GET("/journal-entries", h.GetJournalEntryList)
+	ledger.PUT("/journal-entry/:id/reverse", h.ReverseJournalEntry)
+
+	stock.POST("/rtransaction", h.CreateRepositoryTransaction)
+	view.GET("/rtransaction/:id", h.GetRepositoryTransaction)
+	view.GET("/rtransactions", h.GetRepositoryTransactionList)
+	stock.PUT("/rtransaction/:id", h.UpdateRepositoryTransaction)
+	stock.DELETE("/rtransaction/:id", h.DeleteRepositoryTransaction)
+
+	stock.GET("/stock/reconcile", h.GetStockReconcile)
+	stock.POST("/stock/reconcile", h.ReconcileStock)
+
+	report.POST("/write-off", h.CreateWriteOff)
+	view.GET("/write-off/:id", h.GetWriteOff)
+	view.GET("/write-offs", h.GetWriteOffList)
+	view.GET("/write-offs/report", h.GetWriteOffReport)
+	stock.PUT("/write-off/:id/approve", h.ApproveWriteOff)
+	stock.PUT("/write-off/:id/reject", h.RejectWriteOff)
+	stock.DELETE("/write-off/:id", h.DeleteWriteOff)
+
 	r.Run(":8080")
 	return r
 }
@@ -6,11 +6,16 @@ import (
 	"log"
 	"sell/api"
 	"sell/config"
+	"sell/pkg/blob"
 	"sell/storage/postgres"
+	"sell/worker"
 )
 
 func main() {
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("error while checking config: %v", err)
+	}
 
 	store, err := postgres.New(context.Background(), cfg)
 	if err != nil {
@@ -18,7 +23,20 @@ func main() {
 	}
 	defer store.Close()
 
-	server := api.New(store)
+	if cfg.OwnerLogin != "" {
+		if err := store.Staff().EnsureOwner(context.Background(), cfg.OwnerLogin, cfg.OwnerPassword); err != nil {
+			log.Fatalf("error while creating owner: %v", err)
+		}
+	}
+
+	worker.New(cfg, store).Run(context.Background())
+
+	images, err := blob.NewLocal(cfg.ImageDir)
+	if err != nil {
+		log.Fatalf("error while opening image store: %v", err)
+	}
+
+	server := api.New(cfg, store, images)
 
 	if err := server.Run("localhost:8080"); err != nil {
 		fmt.Printf("error while running server: %v\n", err)
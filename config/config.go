@@ -1,10 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
 	"os"
+	"time"
 )
 
 type Config struct {
@@ -13,6 +15,36 @@ type Config struct {
 	PostgresUser     string
 	PostgresPassword string
 	PostgresDB       string
+
+	StockReconcileInterval time.Duration
+	StockReconcileFix      bool
+	PriceChangeInterval    time.Duration
+	AttendanceInterval     time.Duration
+	AttendanceAutoClose    time.Duration
+	SalesTargetInterval    time.Duration
+	BalanceCheckInterval   time.Duration
+	BalanceCheckFix        bool
+
+	ScaleBarcodes string
+
+	ImageDir       string
+	ThumbnailSize  int
+	MaxImageSize   int64
+	MaxImagePixels int
+
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	OwnerLogin    string
+	OwnerPassword string
+
+	PasswordMinLength  int
+	PasswordMinClasses int
+	PasswordHistory    int
+	LoginMaxAttempts   int
+	LoginLockout       time.Duration
+	PasswordResetTTL   time.Duration
 }
 
 func Load() Config {
@@ -26,9 +58,55 @@ func Load() Config {
 	cfg.PostgresUser = cast.ToString(getOrReturnDefault("POSTGRES_USER", "your user"))
 	cfg.PostgresPassword = cast.ToString(getOrReturnDefault("POSTGRES_PASSWORD", "your password"))
 	cfg.PostgresDB = cast.ToString(getOrReturnDefault("POSTGRES_DB", "your database"))
+
+	cfg.StockReconcileInterval = cast.ToDuration(getOrReturnDefault("STOCK_RECONCILE_INTERVAL", "24h"))
+	cfg.StockReconcileFix = cast.ToBool(getOrReturnDefault("STOCK_RECONCILE_FIX", false))
+	cfg.PriceChangeInterval = cast.ToDuration(getOrReturnDefault("PRICE_CHANGE_INTERVAL", "1m"))
+	cfg.AttendanceInterval = cast.ToDuration(getOrReturnDefault("ATTENDANCE_INTERVAL", "10m"))
+	cfg.AttendanceAutoClose = cast.ToDuration(getOrReturnDefault("ATTENDANCE_AUTO_CLOSE", "12h"))
+	cfg.SalesTargetInterval = cast.ToDuration(getOrReturnDefault("SALES_TARGET_INTERVAL", "1h"))
+	cfg.BalanceCheckInterval = cast.ToDuration(getOrReturnDefault("BALANCE_CHECK_INTERVAL", "24h"))
+	cfg.BalanceCheckFix = cast.ToBool(getOrReturnDefault("BALANCE_CHECK_FIX", false))
+
+	cfg.ScaleBarcodes = cast.ToString(getOrReturnDefault("SCALE_BARCODES", "20-29:weight:5:3"))
+
+	cfg.ImageDir = cast.ToString(getOrReturnDefault("IMAGE_DIR", "./images"))
+	cfg.ThumbnailSize = cast.ToInt(getOrReturnDefault("THUMBNAIL_SIZE", 200))
+	cfg.MaxImageSize = cast.ToInt64(getOrReturnDefault("MAX_IMAGE_SIZE", 5<<20))
+	cfg.MaxImagePixels = cast.ToInt(getOrReturnDefault("MAX_IMAGE_PIXELS", 40_000_000))
+
+	cfg.JWTSecret = cast.ToString(getOrReturnDefault("JWT_SECRET", ""))
+	cfg.AccessTokenTTL = cast.ToDuration(getOrReturnDefault("ACCESS_TOKEN_TTL", "15m"))
+	cfg.RefreshTokenTTL = cast.ToDuration(getOrReturnDefault("REFRESH_TOKEN_TTL", "720h"))
+
+	cfg.OwnerLogin = cast.ToString(getOrReturnDefault("OWNER_LOGIN", ""))
+	cfg.OwnerPassword = cast.ToString(getOrReturnDefault("OWNER_PASSWORD", ""))
+
+	cfg.PasswordMinLength = cast.ToInt(getOrReturnDefault("PASSWORD_MIN_LENGTH", 8))
+	cfg.PasswordMinClasses = cast.ToInt(getOrReturnDefault("PASSWORD_MIN_CLASSES", 3))
+	cfg.PasswordHistory = cast.ToInt(getOrReturnDefault("PASSWORD_HISTORY", 5))
+	cfg.LoginMaxAttempts = cast.ToInt(getOrReturnDefault("LOGIN_MAX_ATTEMPTS", 5))
+	cfg.LoginLockout = cast.ToDuration(getOrReturnDefault("LOGIN_LOCKOUT", "15m"))
+	cfg.PasswordResetTTL = cast.ToDuration(getOrReturnDefault("PASSWORD_RESET_TTL", "24h"))
 	return cfg
 }
 
+// minJWTSecretLength is the shortest JWT_SECRET accepted, tokens signed with a guessable secret
+// would let anyone act as an owner.
+const minJWTSecretLength = 32
+
+// Validate returns an error for settings the server should not start with.
+func (c Config) Validate() error {
+	switch {
+	case c.JWTSecret == "", c.JWTSecret == "your secret":
+		return errors.New("JWT_SECRET is not set")
+	case len(c.JWTSecret) < minJWTSecretLength:
+		return fmt.Errorf("JWT_SECRET should be at least %d characters long", minJWTSecretLength)
+	}
+
+	return nil
+}
+
 func getOrReturnDefault(key string, defaultValue interface{}) interface{} {
 	value := os.Getenv(key)
 	if value != "" {
@@ -0,0 +1,16 @@
+drop table if exists product_units;
+
+alter table repository_transactions
+    alter column quantity type int,
+    alter column price type int;
+
+alter table repositories
+    alter column count type int;
+
+alter table baskets
+    alter column quantity type int,
+    alter column price type int;
+
+alter table products drop column if exists unit;
+
+drop type if exists unit_enum;
@@ -0,0 +1,24 @@
+create type unit_enum as enum ('piece', 'kg', 'litre', 'metre');
+
+alter table products add column unit unit_enum default 'piece';
+
+alter table baskets
+    alter column quantity type numeric,
+    alter column price type numeric;
+
+alter table repositories
+    alter column count type numeric;
+
+alter table repository_transactions
+    alter column quantity type numeric,
+    alter column price type numeric;
+
+create table product_units(
+                              id uuid primary key not null ,
+                              product_id uuid references products(id),
+                              name varchar(30) not null ,
+                              factor numeric not null check ( factor > 0 ),
+                              created_at TIMESTAMP DEFAULT NOW(),
+                              updated_at TIMESTAMP DEFAULT NOW(),
+                              deleted_at TIMESTAMP DEFAULT NULL
+);
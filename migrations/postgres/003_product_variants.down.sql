@@ -0,0 +1,5 @@
+alter table repository_transactions drop column if exists variant_id;
+alter table baskets drop column if exists variant_id;
+alter table repositories drop column if exists variant_id;
+
+drop table if exists product_variants;
@@ -0,0 +1,15 @@
+create table product_variants(
+                                 id uuid primary key not null ,
+                                 product_id uuid references products(id),
+                                 name varchar(60),
+                                 attributes jsonb default '{}'::jsonb,
+                                 barcode varchar(30) unique ,
+                                 price int default null,
+                                 created_at TIMESTAMP DEFAULT NOW(),
+                                 updated_at TIMESTAMP DEFAULT NOW(),
+                                 deleted_at TIMESTAMP DEFAULT NULL
+);
+
+alter table repositories add column variant_id uuid references product_variants(id) default null;
+alter table baskets add column variant_id uuid references product_variants(id) default null;
+alter table repository_transactions add column variant_id uuid references product_variants(id) default null;
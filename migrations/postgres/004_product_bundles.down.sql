@@ -0,0 +1,4 @@
+drop table if exists bundle_items;
+drop table if exists bundles;
+
+drop type if exists bundle_pricing_type_enum;
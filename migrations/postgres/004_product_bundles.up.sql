@@ -0,0 +1,19 @@
+create type bundle_pricing_type_enum as enum ('own', 'components');
+
+create table bundles(
+                        id uuid primary key not null ,
+                        product_id uuid unique references products(id),
+                        pricing_type bundle_pricing_type_enum not null default 'own',
+                        discount numeric default 0 check ( discount >= 0 and discount <= 100 ),
+                        created_at TIMESTAMP DEFAULT NOW(),
+                        updated_at TIMESTAMP DEFAULT NOW(),
+                        deleted_at TIMESTAMP DEFAULT NULL
+);
+
+create table bundle_items(
+                             id uuid primary key not null ,
+                             bundle_id uuid references bundles(id),
+                             product_id uuid references products(id),
+                             quantity numeric not null check ( quantity > 0 ),
+                             created_at TIMESTAMP DEFAULT NOW()
+);
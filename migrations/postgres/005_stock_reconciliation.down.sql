@@ -0,0 +1 @@
+drop index if exists repository_transactions_branch_product_idx;
@@ -0,0 +1,8 @@
+update repository_transactions rt
+set branch_id = s.branch_id
+from staffs s
+where rt.branch_id is null
+  and rt.staff_id = s.id;
+
+create index if not exists repository_transactions_branch_product_idx
+    on repository_transactions (branch_id, product_id);
@@ -0,0 +1,7 @@
+alter table repository_transactions drop column if exists reason;
+
+drop table if exists write_off_items;
+drop table if exists write_offs;
+
+drop type if exists write_off_status_enum;
+drop type if exists write_off_reason_enum;
@@ -0,0 +1,27 @@
+create type write_off_reason_enum as enum ('damage', 'expiry', 'theft', 'internal_use');
+create type write_off_status_enum as enum ('pending', 'approved', 'rejected');
+
+create table write_offs(
+                           id uuid primary key not null ,
+                           branch_id uuid references branches(id),
+                           staff_id uuid references staffs(id),
+                           reason write_off_reason_enum not null,
+                           status write_off_status_enum not null default 'pending',
+                           note text,
+                           approved_by uuid references staffs(id) default null,
+                           approved_at TIMESTAMP DEFAULT NULL,
+                           created_at TIMESTAMP DEFAULT NOW(),
+                           updated_at TIMESTAMP DEFAULT NOW(),
+                           deleted_at TIMESTAMP DEFAULT NULL
+);
+
+create table write_off_items(
+                                id uuid primary key not null ,
+                                write_off_id uuid references write_offs(id),
+                                product_id uuid references products(id),
+                                variant_id uuid references product_variants(id) default null,
+                                quantity numeric not null check ( quantity > 0 ),
+                                created_at TIMESTAMP DEFAULT NOW()
+);
+
+alter table repository_transactions add column reason write_off_reason_enum default null;
@@ -0,0 +1,6 @@
+drop index if exists products_name_trgm_idx;
+drop index if exists products_search_vector_idx;
+
+alter table products drop column if exists search_vector;
+
+drop extension if exists pg_trgm;
@@ -0,0 +1,7 @@
+create extension if not exists pg_trgm;
+
+alter table products add column search_vector tsvector
+    generated always as (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(barcode::text, ''))) stored;
+
+create index if not exists products_search_vector_idx on products using gin (search_vector);
+create index if not exists products_name_trgm_idx on products using gin (name gin_trgm_ops);
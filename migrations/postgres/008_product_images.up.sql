@@ -0,0 +1,11 @@
+create table product_images(
+                               id uuid primary key not null ,
+                               product_id uuid references products(id) not null,
+                               original_key varchar(255) not null,
+                               thumbnail_key varchar(255) not null,
+                               content_type varchar(30) not null,
+                               position int not null default 0,
+                               created_at TIMESTAMP DEFAULT NOW()
+);
+
+create index if not exists product_images_product_id_idx on product_images (product_id, position);
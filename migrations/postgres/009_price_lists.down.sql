@@ -0,0 +1,7 @@
+alter table branches drop column if exists price_list_id;
+
+drop table if exists price_list_history;
+drop table if exists price_list_items;
+drop table if exists price_lists;
+
+drop type if exists price_list_action_enum;
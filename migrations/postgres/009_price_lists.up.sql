@@ -0,0 +1,33 @@
+create type price_list_action_enum as enum ('created', 'updated', 'removed');
+
+create table price_lists(
+                            id uuid primary key not null ,
+                            name varchar(30) not null,
+                            created_at TIMESTAMP DEFAULT NOW(),
+                            updated_at TIMESTAMP DEFAULT NOW(),
+                            deleted_at TIMESTAMP DEFAULT NULL
+);
+
+create table price_list_items(
+                                 id uuid primary key not null ,
+                                 price_list_id uuid references price_lists(id) not null,
+                                 product_id uuid references products(id) not null,
+                                 price int not null check (price >= 0),
+                                 created_at TIMESTAMP DEFAULT NOW(),
+                                 updated_at TIMESTAMP DEFAULT NOW(),
+                                 unique (price_list_id, product_id)
+);
+
+create table price_list_history(
+                                   id uuid primary key not null ,
+                                   price_list_id uuid references price_lists(id) not null,
+                                   product_id uuid references products(id) not null,
+                                   action price_list_action_enum not null,
+                                   old_price int,
+                                   new_price int,
+                                   created_at TIMESTAMP DEFAULT NOW()
+);
+
+create index if not exists price_list_history_price_list_id_idx on price_list_history (price_list_id, created_at);
+
+alter table branches add column price_list_id uuid references price_lists(id) default null;
@@ -0,0 +1,7 @@
+drop table if exists product_price_history;
+drop table if exists price_change_products;
+drop table if exists price_changes;
+
+drop type if exists price_change_status_enum;
+drop type if exists price_rounding_enum;
+drop type if exists price_change_type_enum;
@@ -0,0 +1,37 @@
+create type price_change_type_enum as enum ('absolute', 'percent');
+create type price_rounding_enum as enum ('none', 'nearest', 'up', 'down');
+create type price_change_status_enum as enum ('scheduled', 'applied', 'cancelled');
+
+create table price_changes(
+                              id uuid primary key not null ,
+                              name varchar(50) not null,
+                              change_type price_change_type_enum not null,
+                              value numeric not null,
+                              rounding price_rounding_enum not null default 'none',
+                              rounding_step int not null default 1 check (rounding_step > 0),
+                              category_id varchar(40) references categories(id) default null,
+                              effective_at TIMESTAMP not null,
+                              status price_change_status_enum not null default 'scheduled',
+                              applied_at TIMESTAMP DEFAULT NULL,
+                              created_at TIMESTAMP DEFAULT NOW(),
+                              updated_at TIMESTAMP DEFAULT NOW(),
+                              deleted_at TIMESTAMP DEFAULT NULL
+);
+
+create table price_change_products(
+                                      price_change_id uuid references price_changes(id) not null,
+                                      product_id uuid references products(id) not null,
+                                      primary key (price_change_id, product_id)
+);
+
+create table product_price_history(
+                                      id uuid primary key not null ,
+                                      product_id uuid references products(id) not null,
+                                      old_price int not null,
+                                      new_price int not null,
+                                      price_change_id uuid references price_changes(id) default null,
+                                      created_at TIMESTAMP DEFAULT NOW()
+);
+
+create index if not exists price_changes_due_idx on price_changes (effective_at) where status = 'scheduled';
+create index if not exists product_price_history_product_id_idx on product_price_history (product_id, created_at);
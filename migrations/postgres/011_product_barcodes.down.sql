@@ -0,0 +1,20 @@
+alter table product_variants add column barcode varchar(30) unique;
+alter table products add column barcode int unique;
+
+update product_variants v set barcode = b.code
+from product_barcodes b where b.variant_id = v.id and b.is_primary and length(b.code) <= 30;
+
+-- only codes that fit the old int column go back
+update products p set barcode = b.code::int
+from product_barcodes b
+where b.product_id = p.id and b.variant_id is null and b.is_primary and b.code ~ '^[0-9]{1,9}$';
+
+drop index if exists products_search_vector_idx;
+alter table products drop column if exists search_vector;
+alter table products add column search_vector tsvector
+    generated always as (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(barcode::text, ''))) stored;
+create index if not exists products_search_vector_idx on products using gin (search_vector);
+
+drop table if exists product_barcodes;
+
+drop type if exists barcode_kind_enum;
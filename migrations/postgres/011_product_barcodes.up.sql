@@ -0,0 +1,81 @@
+create type barcode_kind_enum as enum ('ean8', 'ean13', 'upca', 'internal');
+
+create table product_barcodes(
+                                 id uuid primary key not null ,
+                                 product_id uuid references products(id) not null,
+                                 variant_id uuid references product_variants(id) default null,
+                                 code varchar(32) not null unique,
+                                 kind barcode_kind_enum not null,
+                                 is_primary boolean not null default false,
+                                 created_at TIMESTAMP DEFAULT NOW()
+);
+
+create index if not exists product_barcodes_product_id_idx on product_barcodes (product_id);
+create index if not exists product_barcodes_code_prefix_idx on product_barcodes (code varchar_pattern_ops);
+
+-- the int column dropped leading zeros, a code that fails its check digit is
+-- retried with the zeros padded back before it is kept as an internal code
+create function pg_temp.barcode_check_digit(code text) returns boolean as $$
+declare
+    total int := 0;
+    i int;
+begin
+    if code !~ '^[0-9]+$' or length(code) < 2 then
+        return false;
+    end if;
+
+    for i in 1..length(code) - 1 loop
+        total := total + substr(code, i, 1)::int * (case when (length(code) - 1 - i) % 2 = 0 then 3 else 1 end);
+    end loop;
+
+    return (10 - total % 10) % 10 = substr(code, length(code), 1)::int;
+end;
+$$ language plpgsql;
+
+create function pg_temp.barcode_fix(code text) returns text as $$
+declare
+    size int;
+begin
+    foreach size in array array[8, 12, 13] loop
+        if length(code) <= size and pg_temp.barcode_check_digit(lpad(code, size, '0')) then
+            return lpad(code, size, '0');
+        end if;
+    end loop;
+
+    return code;
+end;
+$$ language plpgsql;
+
+create function pg_temp.barcode_kind(code text) returns barcode_kind_enum as $$
+begin
+    if pg_temp.barcode_check_digit(code) then
+        case length(code)
+            when 8 then return 'ean8';
+            when 12 then return 'upca';
+            when 13 then return 'ean13';
+            else return 'internal';
+        end case;
+    end if;
+
+    return 'internal';
+end;
+$$ language plpgsql;
+
+insert into product_barcodes (id, product_id, code, kind, is_primary)
+select gen_random_uuid(), id, pg_temp.barcode_fix(barcode::text), pg_temp.barcode_kind(pg_temp.barcode_fix(barcode::text)), true
+from products where barcode is not null
+on conflict (code) do nothing;
+
+insert into product_barcodes (id, product_id, variant_id, code, kind, is_primary)
+select gen_random_uuid(), product_id, id, barcode, pg_temp.barcode_kind(barcode), true
+from product_variants where barcode is not null
+on conflict (code) do nothing;
+
+drop index if exists products_search_vector_idx;
+alter table products drop column if exists search_vector;
+alter table products drop column if exists barcode;
+alter table products add column search_vector tsvector
+    generated always as (to_tsvector('simple', coalesce(name, ''))) stored;
+create index if not exists products_search_vector_idx on products using gin (search_vector);
+
+alter table product_variants drop column if exists barcode;
@@ -0,0 +1,3 @@
+drop index if exists products_plu_idx;
+
+alter table products drop column if exists plu;
@@ -0,0 +1,4 @@
+alter table products add column plu varchar(6) default null;
+
+-- scale labels pad the plu with zeros, so "00123" and "123" are the same plu
+create unique index if not exists products_plu_idx on products (ltrim(plu, '0')) where plu is not null and deleted_at is null;
@@ -0,0 +1,5 @@
+drop table if exists refresh_tokens;
+
+drop index if exists staffs_login_idx;
+
+-- bcrypt hashes can not be turned back into plain text passwords, they stay hashed
@@ -0,0 +1,34 @@
+create extension if not exists pgcrypto;
+
+-- passwords were stored in plain text, hash them with bcrypt ($2a$ hashes are read by golang.org/x/crypto/bcrypt)
+update staffs set password = crypt(password, gen_salt('bf', 10))
+    where password is not null and password !~ '^\$2[aby]\$';
+
+-- logins were never unique before, stop with the duplicates named instead of the bare
+-- unique violation of the index so they can be renamed or deleted first
+do $$
+declare
+    duplicates text;
+begin
+    select string_agg(login, ', ') into duplicates from (
+        select login from staffs where deleted_at is null and login is not null
+            group by login having count(1) > 1
+    ) d;
+
+    if duplicates is not null then
+        raise exception 'staff logins must be unique before 013 runs, duplicated: %', duplicates;
+    end if;
+end $$;
+
+create unique index if not exists staffs_login_idx on staffs (login) where deleted_at is null;
+
+create table refresh_tokens(
+                               id uuid primary key not null ,
+                               staff_id uuid references staffs(id) not null,
+                               token_hash varchar(64) unique not null,
+                               expires_at timestamp not null,
+                               revoked_at timestamp default null,
+                               created_at timestamp default now()
+);
+
+create index if not exists refresh_tokens_staff_id_idx on refresh_tokens (staff_id);
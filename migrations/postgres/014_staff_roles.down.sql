@@ -0,0 +1 @@
+-- postgres can not drop a value from an enum, branch_manager and owner stay in staff_type_enum
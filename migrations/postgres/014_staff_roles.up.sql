@@ -0,0 +1,2 @@
+alter type staff_type_enum add value if not exists 'branch_manager';
+alter type staff_type_enum add value if not exists 'owner';
@@ -0,0 +1,6 @@
+drop table if exists password_resets;
+drop table if exists staff_password_history;
+
+alter table staffs drop column if exists must_change_password;
+alter table staffs drop column if exists locked_until;
+alter table staffs drop column if exists failed_logins;
@@ -0,0 +1,28 @@
+alter table staffs add column if not exists failed_logins int not null default 0;
+alter table staffs add column if not exists locked_until timestamp default null;
+alter table staffs add column if not exists must_change_password boolean not null default false;
+
+create table staff_password_history(
+                                       id uuid primary key not null ,
+                                       staff_id uuid references staffs(id) not null,
+                                       password varchar(100) not null,
+                                       created_at timestamp default now()
+);
+
+create index if not exists staff_password_history_staff_id_idx on staff_password_history (staff_id, created_at);
+
+-- the current passwords are the first entries of the history
+insert into staff_password_history (id, staff_id, password)
+    select gen_random_uuid(), id, password from staffs where password is not null;
+
+create table password_resets(
+                                id uuid primary key not null ,
+                                staff_id uuid references staffs(id) not null,
+                                created_by uuid references staffs(id) not null,
+                                token_hash varchar(64) unique not null,
+                                expires_at timestamp not null,
+                                used_at timestamp default null,
+                                created_at timestamp default now()
+);
+
+create index if not exists password_resets_staff_id_idx on password_resets (staff_id);
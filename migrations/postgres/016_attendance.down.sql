@@ -0,0 +1,4 @@
+drop table if exists attendance_breaks;
+drop table if exists attendances;
+
+drop type if exists attendance_status_enum;
@@ -0,0 +1,26 @@
+create type attendance_status_enum as enum ('open', 'closed', 'auto_closed');
+
+create table attendances(
+                            id uuid primary key not null ,
+                            staff_id uuid references staffs(id) not null,
+                            branch_id uuid references branches(id) not null,
+                            clock_in timestamp not null,
+                            clock_out timestamp default null,
+                            status attendance_status_enum not null default 'open',
+                            created_at timestamp default now(),
+                            updated_at timestamp default now()
+);
+
+-- a staff can have only one open attendance
+create unique index if not exists attendances_open_idx on attendances (staff_id) where clock_out is null;
+create index if not exists attendances_staff_id_idx on attendances (staff_id, clock_in);
+create index if not exists attendances_branch_id_idx on attendances (branch_id, clock_in);
+
+create table attendance_breaks(
+                                  id uuid primary key not null ,
+                                  attendance_id uuid references attendances(id) on delete cascade not null,
+                                  started_at timestamp not null,
+                                  ended_at timestamp default null
+);
+
+create unique index if not exists attendance_breaks_open_idx on attendance_breaks (attendance_id) where ended_at is null;
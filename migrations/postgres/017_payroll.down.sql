@@ -0,0 +1,8 @@
+drop table if exists payroll_run_items;
+drop table if exists payroll_runs;
+
+drop type if exists payroll_status_enum;
+
+alter table staff_tariffs drop column if exists base_salary;
+
+-- postgres can not drop a value from an enum, fine, salary and payroll stay in source_type_enum
@@ -0,0 +1,38 @@
+-- fines are withdraws, salary is the base salary a payroll run tops up, payroll is the pay out
+alter type source_type_enum add value if not exists 'fine';
+alter type source_type_enum add value if not exists 'salary';
+alter type source_type_enum add value if not exists 'payroll';
+
+alter table staff_tariffs add column if not exists base_salary int not null default 0;
+
+create type payroll_status_enum as enum ('draft', 'approved');
+
+create table payroll_runs(
+                             id uuid primary key not null ,
+                             month date not null,
+                             branch_id uuid references branches(id) default null,
+                             status payroll_status_enum not null default 'draft',
+                             created_by uuid references staffs(id) not null,
+                             approved_by uuid references staffs(id) default null,
+                             approved_at timestamp default null,
+                             created_at timestamp default now(),
+                             updated_at timestamp default now()
+);
+
+create table payroll_run_items(
+                                  id uuid primary key not null ,
+                                  payroll_run_id uuid references payroll_runs(id) on delete cascade not null,
+                                  staff_id uuid references staffs(id) not null,
+                                  month date not null,
+                                  base_salary numeric not null default 0,
+                                  commissions numeric not null default 0,
+                                  bonuses numeric not null default 0,
+                                  fines numeric not null default 0,
+                                  net numeric not null default 0,
+                                  salary_transaction_id uuid references transactions(id) default null,
+                                  payout_transaction_id uuid references transactions(id) default null
+);
+
+-- a staff is paid once a month
+create unique index if not exists payroll_run_items_staff_month_idx on payroll_run_items (staff_id, month);
+create index if not exists payroll_run_items_run_idx on payroll_run_items (payroll_run_id);
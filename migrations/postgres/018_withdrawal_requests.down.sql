@@ -0,0 +1,9 @@
+drop table if exists withdrawal_request_events;
+drop table if exists withdrawal_requests;
+
+drop type if exists withdrawal_action_enum;
+drop type if exists withdrawal_status_enum;
+
+alter table staffs drop constraint if exists staffs_balance_non_negative;
+
+-- postgres can not drop a value from an enum, withdrawal and adjustment stay in source_type_enum
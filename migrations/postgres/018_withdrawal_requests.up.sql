@@ -0,0 +1,39 @@
+-- withdrawal is the pay out of an approved withdrawal request
+alter type source_type_enum add value if not exists 'withdrawal';
+-- adjustment opens the ledger of 020 with the balances set before it, a new enum value
+-- can not be used in the migration that adds it
+alter type source_type_enum add value if not exists 'adjustment';
+
+-- not valid keeps old rows as they are, every new write is checked
+alter table staffs add constraint staffs_balance_non_negative check ( balance >= 0 ) not valid;
+
+create type withdrawal_status_enum as enum ('pending', 'approved', 'rejected', 'cancelled');
+create type withdrawal_action_enum as enum ('requested', 'approved', 'rejected', 'cancelled');
+
+create table withdrawal_requests(
+                                    id uuid primary key not null ,
+                                    staff_id uuid references staffs(id) not null,
+                                    amount int not null check ( amount > 0 ),
+                                    status withdrawal_status_enum not null default 'pending',
+                                    note text,
+                                    decided_by uuid references staffs(id) default null,
+                                    decided_at timestamp default null,
+                                    transaction_id uuid references transactions(id) default null,
+                                    created_at timestamp default now(),
+                                    updated_at timestamp default now()
+);
+
+create index if not exists withdrawal_requests_staff_idx on withdrawal_requests (staff_id, created_at);
+
+-- every step of a request with the staff balance right after it
+create table withdrawal_request_events(
+                                          id uuid primary key not null ,
+                                          withdrawal_request_id uuid references withdrawal_requests(id) not null,
+                                          actor_id uuid references staffs(id) not null,
+                                          action withdrawal_action_enum not null,
+                                          note text,
+                                          balance int not null,
+                                          created_at timestamp default now()
+);
+
+create index if not exists withdrawal_request_events_request_idx on withdrawal_request_events (withdrawal_request_id, created_at);
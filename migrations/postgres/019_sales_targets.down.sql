@@ -0,0 +1,3 @@
+drop table if exists sales_target_payouts;
+drop table if exists sales_target_tiers;
+drop table if exists sales_targets;
@@ -0,0 +1,35 @@
+-- a target belongs to one staff or to a whole branch for a month
+create table sales_targets(
+                              id uuid primary key not null ,
+                              staff_id uuid references staffs(id) default null,
+                              branch_id uuid references branches(id) default null,
+                              month date not null,
+                              created_by uuid references staffs(id) not null,
+                              created_at timestamp default now(),
+                              updated_at timestamp default now(),
+                              deleted_at timestamp default null,
+                              check ( (staff_id is null) <> (branch_id is null) )
+);
+
+create unique index if not exists sales_targets_staff_month_idx on sales_targets (staff_id, month) where deleted_at is null;
+create unique index if not exists sales_targets_branch_month_idx on sales_targets (branch_id, month) where deleted_at is null;
+
+-- every tier reached pays its own bonus
+create table sales_target_tiers(
+                                   id uuid primary key not null ,
+                                   sales_target_id uuid references sales_targets(id) on delete cascade not null,
+                                   threshold numeric not null check ( threshold > 0 ),
+                                   bonus numeric not null check ( bonus > 0 ),
+                                   unique (sales_target_id, threshold)
+);
+
+create table sales_target_payouts(
+                                     id uuid primary key not null ,
+                                     sales_target_id uuid references sales_targets(id) not null,
+                                     tier_id uuid references sales_target_tiers(id) not null,
+                                     staff_id uuid references staffs(id) not null,
+                                     amount numeric not null,
+                                     transaction_id uuid references transactions(id) default null,
+                                     created_at timestamp default now(),
+                                     unique (tier_id, staff_id)
+);
@@ -0,0 +1,10 @@
+drop index if exists transactions_staff_created_idx;
+
+delete from transactions where source_type = 'adjustment' and description = 'opening balance';
+
+alter table withdrawal_request_events alter column balance type int using round(balance)::int;
+
+alter table staffs drop constraint if exists staffs_balance_non_negative;
+alter table staffs alter column balance drop not null;
+alter table staffs alter column balance type int using round(balance)::int;
+alter table staffs add constraint staffs_balance_non_negative check ( balance >= 0 ) not valid;
@@ -0,0 +1,30 @@
+-- the balance follows the transactions ledger, commissions are not whole numbers. The check
+-- is added back not valid as 018 has it, old negative balances stay as they are
+alter table staffs drop constraint if exists staffs_balance_non_negative;
+update staffs set balance = 0 where balance is null;
+alter table staffs alter column balance type numeric using balance::numeric;
+alter table staffs alter column balance set default 0;
+alter table staffs alter column balance set not null;
+alter table staffs add constraint staffs_balance_non_negative check ( balance >= 0 ) not valid;
+
+alter table withdrawal_request_events alter column balance type numeric using balance::numeric;
+
+-- balances set by hand before the ledger are kept, the difference to the ledger
+-- becomes one opening balance adjustment per staff
+insert into transactions (id, staff_id, transaction_type, source_type, amount, description)
+    select gen_random_uuid(), d.id, case when d.difference > 0 then 'topup' else 'withdraw' end::transaction_type_enum,
+           'adjustment', abs(d.difference), 'opening balance'
+    from (select s.id, s.balance - coalesce((
+        select sum(case when t.transaction_type = 'topup' then t.amount else -t.amount end)
+            from transactions t where t.staff_id = s.id and t.deleted_at is null), 0) as difference
+        from staffs s) d
+    where d.difference <> 0;
+
+-- a no op after the adjustments, only rows that differ are written so that the
+-- staffs_balance_non_negative check does not trip on old negative balances
+update staffs s set balance = l.balance from (
+    select t.staff_id, sum(case when t.transaction_type = 'topup' then t.amount else -t.amount end) as balance
+        from transactions t where t.deleted_at is null group by t.staff_id) l
+where l.staff_id = s.id and s.balance <> l.balance;
+
+create index if not exists transactions_staff_created_idx on transactions (staff_id, created_at) where deleted_at is null;
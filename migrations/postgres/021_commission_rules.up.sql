@@ -0,0 +1,19 @@
+-- a rule overrides the flat rate of its tariff for a category and its subcategories, from a
+-- monthly sales volume on, while its dates are in effect
+create table commission_rules(
+                                 id uuid primary key not null ,
+                                 tariff_id uuid references staff_tariffs(id) not null,
+                                 category_id varchar(40) references categories(id) default null,
+                                 min_volume numeric not null default 0 check ( min_volume >= 0 ),
+                                 tariff_type tariff_type_enum not null,
+                                 amount_for_cash numeric not null default 0 check ( amount_for_cash >= 0 ),
+                                 amount_for_card numeric not null default 0 check ( amount_for_card >= 0 ),
+                                 effective_from date not null,
+                                 effective_to date default null,
+                                 created_at timestamp default now(),
+                                 updated_at timestamp default now(),
+                                 deleted_at timestamp default null,
+                                 check ( effective_to is null or effective_to >= effective_from )
+);
+
+create index if not exists commission_rules_tariff_idx on commission_rules (tariff_id, effective_from) where deleted_at is null;
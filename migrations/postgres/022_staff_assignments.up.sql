@@ -0,0 +1,19 @@
+-- the branch and tariff of a staff from effective_from up to effective_to, the open one is current
+create table staff_assignments(
+                                  id uuid primary key not null ,
+                                  staff_id uuid references staffs(id) not null,
+                                  branch_id uuid references branches(id) default null,
+                                  tariff_id uuid references staff_tariffs(id) default null,
+                                  effective_from timestamp not null,
+                                  effective_to timestamp default null,
+                                  changed_by uuid references staffs(id) default null,
+                                  created_at timestamp default now(),
+                                  check ( effective_to is null or effective_to > effective_from )
+);
+
+create unique index if not exists staff_assignments_staff_from_idx on staff_assignments (staff_id, effective_from);
+create unique index if not exists staff_assignments_open_idx on staff_assignments (staff_id) where effective_to is null;
+
+-- the history starts with what the staffs have today
+insert into staff_assignments (id, staff_id, branch_id, tariff_id, effective_from)
+    select gen_random_uuid(), id, branch_id, tariff_id, coalesce(created_at, now()) from staffs;
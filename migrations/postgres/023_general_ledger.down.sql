@@ -0,0 +1,5 @@
+drop table if exists postings;
+drop function if exists journal_entry_balanced();
+drop table if exists journal_entries;
+drop table if exists accounts;
+drop type if exists account_type_enum;
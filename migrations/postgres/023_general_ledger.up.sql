@@ -0,0 +1,112 @@
+create type account_type_enum as enum ('asset', 'liability', 'equity', 'revenue', 'expense');
+
+create table accounts(
+                         id uuid primary key not null ,
+                         code varchar(20) not null unique,
+                         name varchar(60) not null,
+                         account_type account_type_enum not null,
+                         system boolean not null default false,
+                         created_at timestamp default now()
+);
+
+-- the accounts the automatic entries post to, the code looks them up by code
+insert into accounts (id, code, name, account_type, system) values
+    (gen_random_uuid(), '1000', 'Cash', 'asset', true),
+    (gen_random_uuid(), '1100', 'Card receivables', 'asset', true),
+    (gen_random_uuid(), '2000', 'Staff payables', 'liability', true),
+    (gen_random_uuid(), '4000', 'Sales revenue', 'revenue', true),
+    (gen_random_uuid(), '4100', 'Sales returns', 'revenue', true),
+    (gen_random_uuid(), '4200', 'Fine income', 'revenue', true),
+    (gen_random_uuid(), '5000', 'Staff commissions', 'expense', true),
+    (gen_random_uuid(), '5100', 'Salaries', 'expense', true);
+
+-- source_type and source_id point to what the entry was posted for, a reversal
+-- has the same source and reversal_of set, the reversed entry gets reversed_by
+create table journal_entries(
+                                id uuid primary key not null ,
+                                entry_date timestamp not null default now(),
+                                description varchar(200) not null default '',
+                                source_type varchar(20) not null,
+                                source_id uuid default null,
+                                reversal_of uuid references journal_entries(id) default null,
+                                reversed_by uuid references journal_entries(id) default null,
+                                created_by uuid references staffs(id) default null,
+                                created_at timestamp default now()
+);
+
+create unique index if not exists journal_entries_source_idx on journal_entries (source_type, source_id)
+    where source_id is not null and reversal_of is null and reversed_by is null;
+create index if not exists journal_entries_entry_date_idx on journal_entries (entry_date);
+
+create table postings(
+                         id uuid primary key not null ,
+                         entry_id uuid references journal_entries(id) not null,
+                         account_id uuid references accounts(id) not null,
+                         staff_id uuid references staffs(id) default null,
+                         branch_id uuid references branches(id) default null,
+                         debit numeric not null default 0,
+                         credit numeric not null default 0,
+                         check ( debit >= 0 and credit >= 0 and (debit = 0) <> (credit = 0) )
+);
+
+create index if not exists postings_entry_id_idx on postings (entry_id);
+create index if not exists postings_account_id_idx on postings (account_id, staff_id);
+
+-- the history starts with the finished sales and the transactions kept so far
+insert into journal_entries (id, entry_date, description, source_type, source_id)
+    select gen_random_uuid(), coalesce(updated_at, created_at, now()), 'sale', 'sale', id from sales
+    where status = 'success' and deleted_at is null and price > 0;
+
+insert into postings (id, entry_id, account_id, branch_id, debit, credit)
+    select gen_random_uuid(), e.id, a.id, s.branch_id, s.price, 0
+    from journal_entries e
+        join sales s on s.id = e.source_id
+        join accounts a on a.code = case when s.payment_type = 'card' then '1100' else '1000' end
+    where e.source_type = 'sale'
+    union all
+    select gen_random_uuid(), e.id, a.id, s.branch_id, 0, s.price
+    from journal_entries e
+        join sales s on s.id = e.source_id
+        join accounts a on a.code = '4000'
+    where e.source_type = 'sale';
+
+insert into journal_entries (id, entry_date, description, source_type, source_id)
+    select gen_random_uuid(), coalesce(created_at, now()), left(coalesce(nullif(description, ''), source_type::text, 'transaction'), 200),
+           'transaction', id from transactions
+    where deleted_at is null and amount <> 0;
+
+insert into postings (id, entry_id, account_id, staff_id, branch_id, debit, credit)
+    select gen_random_uuid(), e.id, a.id, null, st.branch_id, greatest(m.delta, 0), greatest(-m.delta, 0)
+    from journal_entries e
+        join transactions t on t.id = e.source_id
+        left join staffs st on st.id = t.staff_id
+        cross join lateral (select case when t.transaction_type = 'withdraw' then -t.amount else t.amount end as delta) m
+        join accounts a on a.code = case t.source_type::text
+            when 'salary' then '5100'
+            when 'fine' then '4200'
+            when 'payroll' then '1000'
+            when 'withdrawal' then '1000'
+            else '5000' end
+    where e.source_type = 'transaction'
+    union all
+    select gen_random_uuid(), e.id, a.id, t.staff_id, st.branch_id, greatest(-m.delta, 0), greatest(m.delta, 0)
+    from journal_entries e
+        join transactions t on t.id = e.source_id
+        left join staffs st on st.id = t.staff_id
+        cross join lateral (select case when t.transaction_type = 'withdraw' then -t.amount else t.amount end as delta) m
+        join accounts a on a.code = '2000'
+    where e.source_type = 'transaction';
+
+-- checked when the transaction commits, so the postings of an entry can be added one by one
+create function journal_entry_balanced() returns trigger as $$
+begin
+    if (select sum(debit) <> sum(credit) from postings where entry_id = new.entry_id) then
+        raise exception 'journal entry % is not balanced', new.entry_id;
+    end if;
+
+    return null;
+end;
+$$ language plpgsql;
+
+create constraint trigger postings_balanced after insert on postings
+    deferrable initially deferred for each row execute procedure journal_entry_balanced();
@@ -0,0 +1 @@
+drop index if exists repositories_branch_product_variant_idx;
@@ -0,0 +1,18 @@
+-- one stock row per branch, product and variant. Duplicates are merged into the oldest
+-- row with the count the stock reports showed, the sum of the rows
+update repositories r set count = d.total, updated_at = now()
+from (select (array_agg(id order by coalesce(created_at, '-infinity'), id))[1] as id, sum(count) as total
+        from repositories where deleted_at is null
+        group by branch_id, product_id, coalesce(variant_id::text, '') having count(1) > 1) d
+where r.id = d.id;
+
+update repositories r set deleted_at = now()
+where r.deleted_at is null and exists (
+    select 1 from repositories o
+    where o.deleted_at is null and o.id <> r.id
+      and o.branch_id is not distinct from r.branch_id and o.product_id is not distinct from r.product_id
+      and coalesce(o.variant_id::text, '') = coalesce(r.variant_id::text, '')
+      and (coalesce(o.created_at, '-infinity'), o.id) < (coalesce(r.created_at, '-infinity'), r.id));
+
+create unique index if not exists repositories_branch_product_variant_idx
+    on repositories (branch_id, product_id, coalesce(variant_id::text, '')) where deleted_at is null;
@@ -0,0 +1 @@
+alter table payroll_run_items drop column if exists paid;
@@ -0,0 +1,6 @@
+-- paid is what a payroll run paid out, less than net when the staff had already
+-- withdrawn part of the balance
+alter table payroll_run_items add column if not exists paid numeric not null default 0;
+
+update payroll_run_items i set paid = t.amount
+from transactions t where t.id = i.payout_transaction_id;
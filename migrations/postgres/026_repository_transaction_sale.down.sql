@@ -0,0 +1,3 @@
+drop index if exists repository_transactions_sale_idx;
+
+alter table repository_transactions drop column if exists sale_id;
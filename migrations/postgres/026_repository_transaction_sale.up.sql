@@ -0,0 +1,5 @@
+-- sale_id ties the minus rows a sale wrote when it ended to the sale, a refund puts
+-- back exactly those rows even when a bundle changed in between
+alter table repository_transactions add column if not exists sale_id uuid references sales(id) default null;
+
+create index if not exists repository_transactions_sale_idx on repository_transactions (sale_id);
@@ -0,0 +1 @@
+-- the check and the adjustment source belong to 018, they stay in place
@@ -0,0 +1,7 @@
+-- 018 and 020 were changed after they were first written, a database that ran the first
+-- versions gets the adjustment source and the non-negative balance check from here. The
+-- manual balances the first 020 replaced with the ledger can not be brought back
+alter type source_type_enum add value if not exists 'adjustment';
+
+alter table staffs drop constraint if exists staffs_balance_non_negative;
+alter table staffs add constraint staffs_balance_non_negative check ( balance >= 0 ) not valid;
@@ -0,0 +1,4 @@
+update postings p set account_id = (select id from accounts where code = '5000')
+where p.account_id = (select id from accounts where code = '3000');
+
+delete from accounts where code = '3000';
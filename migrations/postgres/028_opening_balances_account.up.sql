@@ -0,0 +1,11 @@
+-- opening balance adjustments post against their own equity account instead of the
+-- staff commissions expense 023 put them on
+insert into accounts (id, code, name, account_type, system) values
+    (gen_random_uuid(), '3000', 'Opening balances', 'equity', true)
+on conflict (code) do nothing;
+
+update postings p set account_id = (select id from accounts where code = '3000')
+from journal_entries e, transactions t
+where p.entry_id = e.id and e.source_type = 'transaction' and t.id = e.source_id
+  and t.source_type = 'adjustment' and p.staff_id is null
+  and p.account_id = (select id from accounts where code = '5000');
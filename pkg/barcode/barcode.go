@@ -0,0 +1,96 @@
+package barcode
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+const (
+	EAN8     = "ean8"
+	EAN13    = "ean13"
+	UPCA     = "upca"
+	Internal = "internal"
+)
+
+var (
+	ErrCheckDigit = errors.New("barcode check digit is wrong")
+	ErrInternal   = errors.New("internal code should be 1 to 32 letters, digits, '-', '_' or '.'")
+	ErrKind       = errors.New("barcode kind should be one of ean8, ean13, upca, internal")
+
+	digits   = regexp.MustCompile(`^[0-9]+$`)
+	internal = regexp.MustCompile(`^[A-Za-z0-9._-]{1,32}$`)
+)
+
+// Validate checks the code and returns its kind. Without a kind, codes of 8,
+// 12 or 13 digits are taken as EAN-8, UPC-A or EAN-13 and need a correct check
+// digit, other codes are internal. Internal codes skip the check digit.
+func Validate(code, kind string) (string, error) {
+	if kind == "" {
+		kind = Detect(code)
+	}
+
+	switch kind {
+	case Internal:
+		if !internal.MatchString(code) {
+			return "", ErrInternal
+		}
+		return Internal, nil
+	case EAN8, EAN13, UPCA:
+		if !digits.MatchString(code) || len(code) != length(kind) {
+			return "", errors.New(kind + " barcode should have " + strconv.Itoa(length(kind)) + " digits")
+		}
+		if !CheckDigit(code) {
+			return "", ErrCheckDigit
+		}
+		return kind, nil
+	}
+
+	return "", ErrKind
+}
+
+// Detect returns the kind of the code by its length.
+func Detect(code string) string {
+	if digits.MatchString(code) {
+		switch len(code) {
+		case 8:
+			return EAN8
+		case 12:
+			return UPCA
+		case 13:
+			return EAN13
+		}
+	}
+
+	return Internal
+}
+
+// CheckDigit tells whether the last digit of an EAN-8, UPC-A or EAN-13 code is
+// right. Digits are weighted 3 and 1 starting next to the check digit.
+func CheckDigit(code string) bool {
+	if len(code) < 2 || !digits.MatchString(code) {
+		return false
+	}
+
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
+
+func length(kind string) int {
+	switch kind {
+	case EAN8:
+		return 8
+	case UPCA:
+		return 12
+	}
+
+	return 13
+}
@@ -0,0 +1,57 @@
+package barcode
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"4006381333931", true},
+		{"4006381333932", false},
+		{"96385074", true},
+		{"96385075", false},
+		{"036000291452", true},
+		{"036000291453", false},
+		{"0000000000000", true},
+		{"7", false},
+		{"40063813339a1", false},
+	}
+
+	for _, tt := range tests {
+		if got := CheckDigit(tt.code); got != tt.want {
+			t.Errorf("CheckDigit(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		code, kind string
+		want       string
+		err        error
+	}{
+		{"4006381333931", "", EAN13, nil},
+		{"96385074", "", EAN8, nil},
+		{"036000291452", "", UPCA, nil},
+		{"4006381333932", "", "", ErrCheckDigit},
+		{"SKU-001", "", Internal, nil},
+		{"4006381333932", Internal, Internal, nil},
+		{"sku 001", Internal, "", ErrInternal},
+		{"96385074", "isbn", "", ErrKind},
+	}
+
+	for _, tt := range tests {
+		got, err := Validate(tt.code, tt.kind)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("Validate(%q, %q) = %q, %v, want %q, %v", tt.code, tt.kind, got, err, tt.want, tt.err)
+		}
+	}
+
+	if _, err := Validate("9638507", EAN8); err == nil {
+		t.Error("Validate of a 7 digit ean8 should fail")
+	}
+}
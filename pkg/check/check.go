@@ -1,12 +1,71 @@
 package check
 
-import "errors"
+import (
+	"errors"
+	"math"
+	"strings"
+)
 
+func ValidateUnit(unit string) error {
+	switch unit {
+	case "piece", "kg", "litre", "metre":
+		return nil
+	}
+
+	return errors.New("unit should be one of piece, kg, litre, metre")
+}
+
+// ValidateQuantity checks a quantity given in the product's base unit.
+// Pieces can not be split, the other units accept decimal quantities.
+func ValidateQuantity(unit string, quantity float64) error {
+	if quantity <= 0 {
+		return errors.New("quantity should be more than 0")
+	}
+
+	if unit == "piece" && quantity != math.Trunc(quantity) {
+		return errors.New("quantity of pieces should be a whole number")
+	}
+
+	return nil
+}
+
+// ValidatePriceChange checks the type, rounding and targets of a price change.
+// It needs either products or a category.
+func ValidatePriceChange(changeType, rounding string, step int, productIDs []string, categoryID string) error {
+	if changeType != "absolute" && changeType != "percent" {
+		return errors.New("change_type should be absolute or percent")
+	}
+
+	switch rounding {
+	case "none", "nearest", "up", "down":
+	default:
+		return errors.New("rounding should be one of none, nearest, up, down")
+	}
+
+	if step <= 0 {
+		return errors.New("rounding_step should be more than 0")
+	}
+
+	if len(productIDs) == 0 && categoryID == "" {
+		return errors.New("price change needs product_ids or category_id")
+	}
+
+	if len(productIDs) != 0 && categoryID != "" {
+		return errors.New("price change takes either product_ids or category_id")
+	}
+
+	return nil
+}
+
+// ValidatePLU checks the product code scales print on their labels.
+func ValidatePLU(plu string) error {
+	if len(plu) == 0 || len(plu) > 6 || strings.Trim(plu, "0123456789") != "" {
+		return errors.New("plu should be 1 to 6 digits")
+	}
 
-func ValidatePassword(password string) error {
-	if len(password) < 6 {
-		return errors.New("password length should be more than 6")
+	if strings.Trim(plu, "0") == "" {
+		return errors.New("plu should not be zero")
 	}
 
 	return nil
-}
\ No newline at end of file
+}
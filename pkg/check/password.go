@@ -0,0 +1,78 @@
+package check
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = func() map[string]bool {
+	passwords := map[string]bool{}
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			passwords[line] = true
+		}
+	}
+
+	return passwords
+}()
+
+// PasswordPolicy is what a new password has to satisfy.
+// MinClasses counts the classes used out of lower case, upper case, digits and symbols.
+type PasswordPolicy struct {
+	MinLength  int
+	MinClasses int
+}
+
+// Validate checks the length, the character classes and the common password list.
+// Reuse of old passwords needs their hashes and is checked by the caller.
+func (p PasswordPolicy) Validate(password string) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("password should be at least %d characters", p.MinLength)
+	}
+
+	if classes := passwordClasses(password); classes < p.MinClasses {
+		return fmt.Errorf("password should use at least %d of lower case, upper case, digits and symbols", p.MinClasses)
+	}
+
+	if IsCommonPassword(password) {
+		return errors.New("password is too common")
+	}
+
+	return nil
+}
+
+// IsCommonPassword reports whether the password is on the list of common and breached passwords.
+func IsCommonPassword(password string) bool {
+	return commonPasswords[strings.ToLower(password)]
+}
+
+func passwordClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, used := range []bool{lower, upper, digit, symbol} {
+		if used {
+			classes++
+		}
+	}
+
+	return classes
+}
@@ -0,0 +1,64 @@
+package check
+
+import "testing"
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 8, MinClasses: 3}
+
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{"Sunflower-42", false},
+		{"Зелёный-чай7", false},
+		{"Ab1!", true},
+		{"sunflowerfield", true},
+		{"sunflower42", true},
+		{"SUNFLOWER-42", false},
+		{"Password1", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		if err := policy.Validate(tt.password); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, want error %v", tt.password, err, tt.wantErr)
+		}
+	}
+}
+
+func TestIsCommonPassword(t *testing.T) {
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{"123456", true},
+		{"PASSWORD", true},
+		{"Sunflower-42", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsCommonPassword(tt.password); got != tt.want {
+			t.Errorf("IsCommonPassword(%q) = %v, want %v", tt.password, got, tt.want)
+		}
+	}
+}
+
+func TestPasswordClasses(t *testing.T) {
+	tests := []struct {
+		password string
+		want     int
+	}{
+		{"", 0},
+		{"abc", 1},
+		{"abcDEF", 2},
+		{"abcDEF123", 3},
+		{"abcDEF123!", 4},
+		{"  ", 1},
+	}
+
+	for _, tt := range tests {
+		if got := passwordClasses(tt.password); got != tt.want {
+			t.Errorf("passwordClasses(%q) = %d, want %d", tt.password, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package pricing
+
+import "math"
+
+// Change returns the new price after an absolute change, which adds value to
+// the price, or a percent change, which adds value percent of it. The result
+// is rounded to a multiple of step, or to a whole price for rounding "none",
+// and never goes below zero.
+func Change(price int, changeType string, value float64, rounding string, step int) int {
+	result := float64(price)
+	switch changeType {
+	case "absolute":
+		result += value
+	case "percent":
+		result += result * value / 100
+	}
+
+	if step <= 0 || rounding == "none" {
+		step = 1
+	}
+
+	units := result / float64(step)
+	switch rounding {
+	case "up":
+		units = math.Ceil(units)
+	case "down":
+		units = math.Floor(units)
+	default:
+		units = math.Round(units)
+	}
+
+	if units < 0 {
+		return 0
+	}
+
+	return int(units) * step
+}
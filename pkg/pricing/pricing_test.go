@@ -0,0 +1,36 @@
+package pricing
+
+import "testing"
+
+func TestChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		price      int
+		changeType string
+		value      float64
+		rounding   string
+		step       int
+		want       int
+	}{
+		{"absolute up", 1000, "absolute", 250, "none", 1, 1250},
+		{"absolute down", 1000, "absolute", -250, "none", 1, 750},
+		{"percent", 1000, "percent", 10, "none", 1, 1100},
+		{"percent cut", 1000, "percent", -15, "none", 1, 850},
+		{"none rounds to whole", 999, "percent", 5, "none", 100, 1049},
+		{"nearest step", 1040, "absolute", 0, "nearest", 100, 1000},
+		{"nearest step half", 1050, "absolute", 0, "nearest", 100, 1100},
+		{"up step", 1001, "absolute", 0, "up", 100, 1100},
+		{"down step", 1099, "absolute", 0, "down", 100, 1000},
+		{"zero step", 1099, "absolute", 0, "down", 0, 1099},
+		{"never below zero", 100, "absolute", -500, "none", 1, 0},
+		{"percent below zero", 100, "percent", -150, "up", 10, 0},
+		{"unknown type keeps price", 1000, "other", 50, "none", 1, 1000},
+	}
+
+	for _, tt := range tests {
+		if got := Change(tt.price, tt.changeType, tt.value, tt.rounding, tt.step); got != tt.want {
+			t.Errorf("%s: Change(%d, %q, %g, %q, %d) = %d, want %d",
+				tt.name, tt.price, tt.changeType, tt.value, tt.rounding, tt.step, got, tt.want)
+		}
+	}
+}
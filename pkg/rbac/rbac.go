@@ -0,0 +1,82 @@
+package rbac
+
+// Roles are the values of staff_type_enum.
+const (
+	Owner         = "owner"
+	BranchManager = "branch_manager"
+	Cashier       = "cashier"
+	ShopAssistant = "shop_assistant"
+)
+
+type Permission string
+
+const (
+	// ViewCatalog covers reading products, categories, branches, stock and sales.
+	ViewCatalog Permission = "catalog:view"
+	// ManageCatalog covers creating and changing products, categories, units, variants and bundles.
+	ManageCatalog Permission = "catalog:manage"
+	// Sell covers sales and baskets, limited to the staff's own branch.
+	Sell Permission = "sell"
+	// RefundSales covers refunding finished sales of the staff's own branch.
+	RefundSales Permission = "sales:refund"
+	// ReportStock covers reporting damaged, expired or lost stock of the staff's own branch.
+	ReportStock Permission = "stock:report"
+	// ManageStock covers repositories, stock movements, reconciliation and write off decisions
+	// of the staff's own branch.
+	ManageStock Permission = "stock:manage"
+	// ManageStaff covers the staff of the manager's own branch.
+	ManageStaff Permission = "staff:manage"
+	// ManageBranches covers branches, price lists and price changes.
+	ManageBranches Permission = "branches:manage"
+	// ManageTariffs covers staff tariffs and the money transactions of the staff.
+	ManageTariffs Permission = "tariffs:manage"
+	// ManagePayroll covers calculating commissions and payroll runs of the staff's own branch
+	// and reviewing the runs.
+	ManagePayroll Permission = "payroll:manage"
+	// ApprovePayroll covers approving payroll runs, which pays them out.
+	ApprovePayroll Permission = "payroll:approve"
+	// ManageLedger covers the accounts, journal entries and reports of the general ledger.
+	ManageLedger Permission = "ledger:manage"
+)
+
+// matrix lists the roles that have each permission.
+var matrix = map[Permission][]string{
+	ViewCatalog:    {Owner, BranchManager, Cashier, ShopAssistant},
+	ManageCatalog:  {Owner, BranchManager},
+	Sell:           {Owner, BranchManager, Cashier},
+	RefundSales:    {Owner, BranchManager},
+	ReportStock:    {Owner, BranchManager, Cashier, ShopAssistant},
+	ManageStock:    {Owner, BranchManager},
+	ManageStaff:    {Owner, BranchManager},
+	ManageBranches: {Owner},
+	ManageTariffs:  {Owner},
+	ManagePayroll:  {Owner, BranchManager},
+	ApprovePayroll: {Owner},
+	ManageLedger:   {Owner},
+}
+
+// Allowed reports whether the role has the permission.
+func Allowed(role string, permission Permission) bool {
+	for _, r := range matrix[permission] {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllBranches reports whether the role works across branches instead of its own one.
+func AllBranches(role string) bool {
+	return role == Owner
+}
+
+// Valid reports whether the role is one of the known staff types.
+func Valid(role string) bool {
+	switch role {
+	case Owner, BranchManager, Cashier, ShopAssistant:
+		return true
+	}
+
+	return false
+}
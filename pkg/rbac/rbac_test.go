@@ -0,0 +1,66 @@
+package rbac
+
+import "testing"
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		permission                               Permission
+		owner, branchManager, cashier, assistant bool
+	}{
+		{ViewCatalog, true, true, true, true},
+		{ManageCatalog, true, true, false, false},
+		{Sell, true, true, true, false},
+		{RefundSales, true, true, false, false},
+		{ReportStock, true, true, true, true},
+		{ManageStock, true, true, false, false},
+		{ManageStaff, true, true, false, false},
+		{ManageBranches, true, false, false, false},
+		{ManageTariffs, true, false, false, false},
+		{ManagePayroll, true, true, false, false},
+		{ApprovePayroll, true, false, false, false},
+		{ManageLedger, true, false, false, false},
+		{Permission("unknown"), false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		for role, want := range map[string]bool{
+			Owner:         tt.owner,
+			BranchManager: tt.branchManager,
+			Cashier:       tt.cashier,
+			ShopAssistant: tt.assistant,
+			"":            false,
+		} {
+			if got := Allowed(role, tt.permission); got != want {
+				t.Errorf("Allowed(%q, %q) = %v, want %v", role, tt.permission, got, want)
+			}
+		}
+	}
+}
+
+func TestMatrixRoles(t *testing.T) {
+	for permission, roles := range matrix {
+		if len(roles) == 0 {
+			t.Errorf("%q has no roles", permission)
+		}
+		for _, role := range roles {
+			if !Valid(role) {
+				t.Errorf("%q lists unknown role %q", permission, role)
+			}
+		}
+	}
+}
+
+func TestAllBranches(t *testing.T) {
+	tests := map[string]bool{
+		Owner:         true,
+		BranchManager: false,
+		Cashier:       false,
+		ShopAssistant: false,
+	}
+
+	for role, want := range tests {
+		if got := AllBranches(role); got != want {
+			t.Errorf("AllBranches(%q) = %v, want %v", role, got, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package scale
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"sell/pkg/barcode"
+)
+
+const (
+	Weight = "weight"
+	Price  = "price"
+	Count  = "count"
+)
+
+// Rule describes the EAN-13 labels of an in-store scale. Codes starting with
+// a prefix between From and To carry a PLU of PLULength digits right after
+// the prefix, then the value up to the check digit with Decimals decimals.
+type Rule struct {
+	From      string
+	To        string
+	Kind      string
+	PLULength int
+	Decimals  int
+}
+
+// Label is a decoded scale label. Value is the weight in the product unit,
+// the total price or the count of pieces, depending on Kind.
+type Label struct {
+	PLU   string
+	Kind  string
+	Value float64
+}
+
+type Parser struct {
+	rules []Rule
+}
+
+// NewParser reads rules written as "prefix:kind:plu_length:decimals" separated
+// by commas, where prefix is a code start like "20" or a range like "20-24",
+// e.g. "20-24:weight:5:3,25-29:price:5:0".
+func NewParser(config string) (Parser, error) {
+	parser := Parser{}
+
+	for _, part := range strings.Split(config, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		fields := strings.Split(part, ":")
+		if len(fields) != 4 {
+			return Parser{}, fmt.Errorf("scale rule %q should be prefix:kind:plu_length:decimals", part)
+		}
+
+		rule := Rule{Kind: fields[1]}
+		rule.From, rule.To, _ = strings.Cut(fields[0], "-")
+		if rule.To == "" {
+			rule.To = rule.From
+		}
+
+		if !isDigits(rule.From) || len(rule.From) != len(rule.To) || rule.From > rule.To {
+			return Parser{}, fmt.Errorf("scale rule %q has a wrong prefix", part)
+		}
+
+		if rule.Kind != Weight && rule.Kind != Price && rule.Kind != Count {
+			return Parser{}, fmt.Errorf("scale rule %q kind should be weight, price or count", part)
+		}
+
+		var err error
+		if rule.PLULength, err = strconv.Atoi(fields[2]); err != nil || rule.PLULength <= 0 {
+			return Parser{}, fmt.Errorf("scale rule %q has a wrong plu length", part)
+		}
+
+		if rule.Decimals, err = strconv.Atoi(fields[3]); err != nil || rule.Decimals < 0 {
+			return Parser{}, fmt.Errorf("scale rule %q has wrong decimals", part)
+		}
+
+		if len(rule.From)+rule.PLULength >= 12 {
+			return Parser{}, fmt.Errorf("scale rule %q leaves no digits for the value", part)
+		}
+
+		parser.rules = append(parser.rules, rule)
+	}
+
+	return parser, nil
+}
+
+// Parse decodes the code when it is a 13 digit code matching one of the rules.
+// ok is false for other codes, which are looked up as usual.
+func (p Parser) Parse(code string) (Label, bool, error) {
+	if len(code) != 13 || !isDigits(code) {
+		return Label{}, false, nil
+	}
+
+	for _, rule := range p.rules {
+		prefix := code[:len(rule.From)]
+		if prefix < rule.From || prefix > rule.To {
+			continue
+		}
+
+		if !barcode.CheckDigit(code) {
+			return Label{}, true, errors.New("scale label check digit is wrong")
+		}
+
+		pluEnd := len(rule.From) + rule.PLULength
+		value, _ := strconv.Atoi(code[pluEnd:12])
+
+		return Label{
+			PLU:   code[len(rule.From):pluEnd],
+			Kind:  rule.Kind,
+			Value: float64(value) / math.Pow10(rule.Decimals),
+		}, true, nil
+	}
+
+	return Label{}, false, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
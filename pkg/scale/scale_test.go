@@ -0,0 +1,62 @@
+package scale
+
+import "testing"
+
+func TestNewParser(t *testing.T) {
+	tests := []struct {
+		config  string
+		rules   int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"20-24:weight:5:3,25-29:price:5:0", 2, false},
+		{" 21:count:5:0 ,", 1, false},
+		{"20:weight:5", 0, true},
+		{"2a:weight:5:3", 0, true},
+		{"24-20:weight:5:3", 0, true},
+		{"20-245:weight:5:3", 0, true},
+		{"20:volume:5:3", 0, true},
+		{"20:weight:0:3", 0, true},
+		{"20:weight:5:-1", 0, true},
+		{"20:weight:10:3", 0, true},
+	}
+
+	for _, tt := range tests {
+		parser, err := NewParser(tt.config)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewParser(%q) error = %v, want error %v", tt.config, err, tt.wantErr)
+			continue
+		}
+		if len(parser.rules) != tt.rules {
+			t.Errorf("NewParser(%q) has %d rules, want %d", tt.config, len(parser.rules), tt.rules)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	parser, err := NewParser("20-24:weight:5:3,25-29:price:5:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		code    string
+		label   Label
+		ok      bool
+		wantErr bool
+	}{
+		{"2112345012346", Label{PLU: "12345", Kind: Weight, Value: 1.234}, true, false},
+		{"2600042001508", Label{PLU: "00042", Kind: Price, Value: 1.5}, true, false},
+		{"2112345012345", Label{}, true, true},
+		{"4006381333931", Label{}, false, false},
+		{"96385074", Label{}, false, false},
+		{"21123450123a6", Label{}, false, false},
+	}
+
+	for _, tt := range tests {
+		label, ok, err := parser.Parse(tt.code)
+		if (err != nil) != tt.wantErr || ok != tt.ok || label != tt.label {
+			t.Errorf("Parse(%q) = %+v, %v, %v, want %+v, %v, error %v", tt.code, label, ok, err, tt.label, tt.ok, tt.wantErr)
+		}
+	}
+}
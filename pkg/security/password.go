@@ -0,0 +1,27 @@
+package security
+
+import "golang.org/x/crypto/bcrypt"
+
+// HashPassword returns the bcrypt hash that is stored instead of the password.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// CompareHashAndPassword reports whether password matches the stored hash.
+func CompareHashAndPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// dummyHash is a bcrypt hash of the default cost that no password is checked against for real.
+const dummyHash = "$2a$10$NZxg30IM7EeLpL36tMT.v.nvogijEzjAXzkXBeteRpEPVnwMG/pgK"
+
+// CompareDummyPassword takes as long as CompareHashAndPassword, it is called for logins that
+// do not exist so the response time does not tell them apart from wrong passwords.
+func CompareDummyPassword(password string) {
+	bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(password))
+}
@@ -0,0 +1,65 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims is what an access token says about the staff member holding it.
+type Claims struct {
+	StaffID   string `json:"staff_id"`
+	StaffType string `json:"staff_type"`
+	BranchID  string `json:"branch_id"`
+	jwt.RegisteredClaims
+}
+
+// NewAccessToken signs a short lived access token for the claims.
+func NewAccessToken(claims Claims, secret string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims.Subject = claims.StaffID
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+// ParseAccessToken checks the signature and expiry of an access token.
+func ParseAccessToken(token, secret string) (Claims, error) {
+	claims := Claims{}
+
+	parsed, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return Claims{}, err
+	}
+
+	if !parsed.Valid || claims.StaffID == "" {
+		return Claims{}, errors.New("token is not valid")
+	}
+
+	return claims, nil
+}
+
+// NewToken returns a random opaque token for refresh and password reset tokens.
+// Only its hash is kept in the database.
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex sha256 of an opaque token.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
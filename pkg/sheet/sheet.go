@@ -0,0 +1,82 @@
+package sheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("format should be csv or xlsx")
+
+// Read returns all rows of a csv file or of the first sheet of a xlsx file.
+func Read(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case CSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case XLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return file.GetRows(file.GetSheetName(0))
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// Write writes the rows as a csv file or as the only sheet of a xlsx file.
+func Write(w io.Writer, format string, rows [][]string) error {
+	switch format {
+	case CSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case XLSX:
+		file := excelize.NewFile()
+		defer file.Close()
+
+		sheet := file.GetSheetName(0)
+		for i, row := range rows {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+
+			values := make([]interface{}, len(row))
+			for j := range row {
+				values[j] = row[j]
+			}
+
+			if err = file.SetSheetRow(sheet, cell, &values); err != nil {
+				return err
+			}
+		}
+
+		return file.Write(w)
+	}
+
+	return ErrUnknownFormat
+}
+
+// ContentType returns the mime type of the format.
+func ContentType(format string) string {
+	if format == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv"
+}
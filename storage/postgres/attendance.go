@@ -0,0 +1,360 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type attendanceRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewAttendanceRepo(DB *pgxpool.Pool) storage.IAttendanceRepo {
+	return &attendanceRepo{
+		DB: DB,
+	}
+}
+
+func (a *attendanceRepo) ClockIn(ctx context.Context, staffID, branchID string, at time.Time) (string, error) {
+	id := uuid.New().String()
+
+	if _, err := a.DB.Exec(ctx, `insert into attendances (id, staff_id, branch_id, clock_in) values ($1, $2, $3, $4)`,
+		id,
+		staffID,
+		branchID,
+		at,
+	); err != nil {
+		log.Println("Error while inserting attendance", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Open returns the attendance the staff has not clocked out of, pgx.ErrNoRows when there is none.
+func (a *attendanceRepo) Open(ctx context.Context, staffID string) (models.Attendance, error) {
+	attendance, err := a.get(ctx, `a.staff_id = $1 and a.clock_out is null`, staffID)
+	if err != nil {
+		return models.Attendance{}, err
+	}
+
+	return attendance, nil
+}
+
+// ClockOut closes the attendance together with a break that is still running.
+func (a *attendanceRepo) ClockOut(ctx context.Context, id string, at time.Time) error {
+	tx, err := a.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `update attendance_breaks set ended_at = $2 
+		where attendance_id = $1 and ended_at is null`, id, at); err != nil {
+		log.Println("Error while ending break", err)
+		return err
+	}
+
+	result, err := tx.Exec(ctx, `update attendances set clock_out = $2, status = 'closed', updated_at = now() 
+		where id = $1 and clock_out is null`, id, at)
+	if err != nil {
+		log.Println("Error while clocking out", err)
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (a *attendanceRepo) StartBreak(ctx context.Context, attendanceID string, at time.Time) error {
+	if _, err := a.DB.Exec(ctx, `insert into attendance_breaks (id, attendance_id, started_at) values ($1, $2, $3)`,
+		uuid.New().String(),
+		attendanceID,
+		at,
+	); err != nil {
+		log.Println("Error while starting break", err)
+		return err
+	}
+
+	return nil
+}
+
+// EndBreak ends the running break of the attendance, pgx.ErrNoRows when no break is running.
+func (a *attendanceRepo) EndBreak(ctx context.Context, attendanceID string, at time.Time) error {
+	result, err := a.DB.Exec(ctx, `update attendance_breaks set ended_at = $2 
+		where attendance_id = $1 and ended_at is null`, attendanceID, at)
+	if err != nil {
+		log.Println("Error while ending break", err)
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (a *attendanceRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.Attendance, error) {
+	attendance, err := a.get(ctx, `a.id = $1`, key.ID)
+	if err != nil {
+		return models.Attendance{}, err
+	}
+
+	return attendance, nil
+}
+
+func (a *attendanceRepo) GetList(ctx context.Context, request models.AttendanceGetListRequest) (models.AttendancesResponse, error) {
+	var (
+		attendances = []models.Attendance{}
+		count       int
+		filter      = ` where true`
+		args        = []interface{}{}
+	)
+
+	if request.StaffID != "" {
+		args = append(args, request.StaffID)
+		filter += fmt.Sprintf(` and a.staff_id = $%d`, len(args))
+	}
+
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and a.branch_id = $%d`, len(args))
+	}
+
+	if request.Status != "" {
+		args = append(args, request.Status)
+		filter += fmt.Sprintf(` and a.status = $%d`, len(args))
+	}
+
+	if !request.From.IsZero() {
+		args = append(args, request.From)
+		filter += fmt.Sprintf(` and a.clock_in >= $%d`, len(args))
+	}
+
+	if !request.To.IsZero() {
+		args = append(args, request.To)
+		filter += fmt.Sprintf(` and a.clock_in < $%d`, len(args))
+	}
+
+	if err := a.DB.QueryRow(ctx, `select count(*) from attendances a`+filter, args...).Scan(&count); err != nil {
+		log.Println("Error while counting attendances", err)
+		return models.AttendancesResponse{}, err
+	}
+
+	args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	rows, err := a.DB.Query(ctx, `select a.id, a.staff_id, a.branch_id, a.clock_in, a.clock_out, a.status, a.created_at, a.updated_at 
+		from attendances a`+filter+fmt.Sprintf(` order by a.clock_in desc limit $%d offset $%d`, len(args)-1, len(args)), args...)
+	if err != nil {
+		log.Println("Error while selecting attendances", err)
+		return models.AttendancesResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attendance := models.Attendance{}
+		if err := rows.Scan(
+			&attendance.ID,
+			&attendance.StaffID,
+			&attendance.BranchID,
+			&attendance.ClockIn,
+			&attendance.ClockOut,
+			&attendance.Status,
+			&attendance.CreatedAt,
+			&attendance.UpdatedAt,
+		); err != nil {
+			log.Println("Error while scanning attendance", err)
+			return models.AttendancesResponse{}, err
+		}
+		attendances = append(attendances, attendance)
+	}
+	rows.Close()
+
+	for i := range attendances {
+		if err := a.loadBreaks(ctx, &attendances[i]); err != nil {
+			return models.AttendancesResponse{}, err
+		}
+	}
+
+	return models.AttendancesResponse{
+		Attendances: attendances,
+		Count:       count,
+	}, nil
+}
+
+// Update sets the times a manager corrected and closes the attendance.
+func (a *attendanceRepo) Update(ctx context.Context, attendance models.UpdateAttendance) error {
+	tx, err := a.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `update attendances set clock_in = $2, clock_out = $3, status = 'closed', updated_at = now() 
+		where id = $1`, attendance.ID, attendance.ClockIn, attendance.ClockOut); err != nil {
+		log.Println("Error while updating attendance", err)
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `update attendance_breaks set ended_at = $2 
+		where attendance_id = $1 and ended_at is null`, attendance.ID, attendance.ClockOut); err != nil {
+		log.Println("Error while ending break", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// AutoClose closes the attendances opened before openedBefore, their clock out is set shift after the clock in.
+func (a *attendanceRepo) AutoClose(ctx context.Context, openedBefore time.Time, shift time.Duration) (int64, error) {
+	var count int64
+
+	if err := a.DB.QueryRow(ctx, `with closed as (
+			update attendances set clock_out = clock_in + make_interval(secs => $2), status = 'auto_closed', updated_at = now()
+				where clock_out is null and clock_in < $1
+			returning id, clock_out
+		), breaks as (
+			update attendance_breaks ab set ended_at = greatest(ab.started_at, closed.clock_out)
+				from closed where ab.attendance_id = closed.id and ab.ended_at is null
+			returning ab.id
+		)
+		select count(*) from closed`, openedBefore, shift.Seconds()).Scan(&count); err != nil {
+		log.Println("Error while auto closing attendances", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Timesheet sums the closed attendances of the staff that started in [from, to) by day.
+func (a *attendanceRepo) Timesheet(ctx context.Context, staffID string, from, to time.Time) (models.Timesheet, error) {
+	timesheet := models.Timesheet{
+		StaffID: staffID,
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		Days:    []models.TimesheetDay{},
+	}
+
+	rows, err := a.DB.Query(ctx, `
+		select a.clock_in::date::text,
+		       count(*) filter (where a.status = 'closed'),
+		       coalesce(sum(extract(epoch from a.clock_out - a.clock_in) - b.seconds) filter (where a.status = 'closed'), 0)::int / 60,
+		       coalesce(sum(b.seconds) filter (where a.status = 'closed'), 0)::int / 60,
+		       count(*) filter (where a.status = 'auto_closed'),
+		       coalesce(sum(extract(epoch from a.clock_out - a.clock_in) - b.seconds) filter (where a.status = 'auto_closed'), 0)::int / 60
+			from attendances a
+			cross join lateral (
+				select coalesce(sum(extract(epoch from least(coalesce(ab.ended_at, a.clock_out), a.clock_out) - ab.started_at)), 0) as seconds
+					from attendance_breaks ab where ab.attendance_id = a.id
+			) b
+				where a.staff_id = $1 and a.clock_out is not null and a.clock_in >= $2 and a.clock_in < $3
+			group by 1 order by 1`, staffID, from, to)
+	if err != nil {
+		log.Println("Error while selecting timesheet", err)
+		return models.Timesheet{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		day := models.TimesheetDay{}
+		if err := rows.Scan(
+			&day.Date,
+			&day.Shifts,
+			&day.WorkedMinutes,
+			&day.BreakMinutes,
+			&day.AutoClosedShifts,
+			&day.AutoClosedMinutes,
+		); err != nil {
+			log.Println("Error while scanning timesheet", err)
+			return models.Timesheet{}, err
+		}
+
+		timesheet.Days = append(timesheet.Days, day)
+		timesheet.Shifts += day.Shifts
+		timesheet.WorkedMinutes += day.WorkedMinutes
+		timesheet.BreakMinutes += day.BreakMinutes
+		timesheet.AutoClosedShifts += day.AutoClosedShifts
+		timesheet.AutoClosedMinutes += day.AutoClosedMinutes
+	}
+
+	return timesheet, rows.Err()
+}
+
+func (a *attendanceRepo) get(ctx context.Context, where string, arg string) (models.Attendance, error) {
+	attendance := models.Attendance{}
+
+	if err := a.DB.QueryRow(ctx, `select a.id, a.staff_id, a.branch_id, a.clock_in, a.clock_out, a.status, a.created_at, a.updated_at 
+		from attendances a where `+where, arg).Scan(
+		&attendance.ID,
+		&attendance.StaffID,
+		&attendance.BranchID,
+		&attendance.ClockIn,
+		&attendance.ClockOut,
+		&attendance.Status,
+		&attendance.CreatedAt,
+		&attendance.UpdatedAt,
+	); err != nil {
+		return models.Attendance{}, err
+	}
+
+	if err := a.loadBreaks(ctx, &attendance); err != nil {
+		return models.Attendance{}, err
+	}
+
+	return attendance, nil
+}
+
+// loadBreaks reads the breaks of the attendance and counts its worked and break minutes,
+// an open attendance or break is counted up to now.
+func (a *attendanceRepo) loadBreaks(ctx context.Context, attendance *models.Attendance) error {
+	rows, err := a.DB.Query(ctx, `select id, started_at, ended_at from attendance_breaks 
+		where attendance_id = $1 order by started_at`, attendance.ID)
+	if err != nil {
+		log.Println("Error while selecting attendance breaks", err)
+		return err
+	}
+	defer rows.Close()
+
+	end := time.Now()
+	if attendance.ClockOut != nil {
+		end = *attendance.ClockOut
+	}
+
+	attendance.Breaks = []models.AttendanceBreak{}
+	breaks := time.Duration(0)
+	for rows.Next() {
+		b := models.AttendanceBreak{}
+		if err := rows.Scan(&b.ID, &b.StartedAt, &b.EndedAt); err != nil {
+			log.Println("Error while scanning attendance break", err)
+			return err
+		}
+
+		breakEnd := end
+		if b.EndedAt != nil && b.EndedAt.Before(end) {
+			breakEnd = *b.EndedAt
+		}
+		if breakEnd.After(b.StartedAt) {
+			breaks += breakEnd.Sub(b.StartedAt)
+		}
+
+		attendance.Breaks = append(attendance.Breaks, b)
+	}
+
+	attendance.BreakMinutes = int(breaks.Minutes())
+	attendance.WorkedMinutes = int((end.Sub(attendance.ClockIn) - breaks).Minutes())
+
+	return rows.Err()
+}
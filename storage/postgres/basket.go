@@ -25,11 +25,12 @@ func (s *basketRepo) Create(ctx context.Context, basket models.CreateBasket) (st
 	id := uuid.New().String()
 
 	if _, err := s.DB.Exec(ctx, `INSERT INTO baskets 
-		(id, sale_id, product_id, price, quantity)
-			VALUES($1, $2, $3, $4, $5) `,
+		(id, sale_id, product_id, variant_id, price, quantity)
+			VALUES($1, $2, $3, nullif($4, '')::uuid, $5, $6) `,
 		id,
 		basket.SaleID,
 		basket.ProductID,
+		basket.VariantID,
 		basket.Price,
 		basket.Quantity,
 	); err != nil {
@@ -42,12 +43,13 @@ func (s *basketRepo) Create(ctx context.Context, basket models.CreateBasket) (st
 
 func (s *basketRepo) GetByID(ctx context.Context, id models.PrimaryKey) (models.Basket, error) {
 	basket := models.Basket{}
-	query := `SELECT id, sale_id, product_id, quantity, price, created_at, updated_at
+	query := `SELECT id, sale_id, product_id, coalesce(variant_id::text, ''), quantity, price, created_at, updated_at
 				FROM baskets WHERE id = $1 and  deleted_at is null`
 	err := s.DB.QueryRow(ctx, query, id.ID).Scan(
 		&basket.ID,
 		&basket.SaleID,
 		&basket.ProductID,
+		&basket.VariantID,
 		&basket.Quantity,
 		&basket.Price,
 		&basket.CreatedAt,
@@ -77,7 +79,7 @@ func (s *basketRepo) GetList(ctx context.Context, request models.GetListRequest)
 		return models.BasketsResponse{}, err
 	}
 
-	query := `SELECT id, sale_id, product_id, quantity, price, created_at, updated_at
+	query := `SELECT id, sale_id, product_id, coalesce(variant_id::text, ''), quantity, price, created_at, updated_at
 						FROM baskets where deleted_at is null`
 	if request.Search != "" {
 		query += fmt.Sprintf(` and sale_id = '%s' `, request.Search)
@@ -97,6 +99,7 @@ func (s *basketRepo) GetList(ctx context.Context, request models.GetListRequest)
 			&basket.ID,
 			&basket.SaleID,
 			&basket.ProductID,
+			&basket.VariantID,
 			&basket.Quantity,
 			&basket.Price,
 			&basket.CreatedAt,
@@ -115,12 +118,47 @@ func (s *basketRepo) GetList(ctx context.Context, request models.GetListRequest)
 	}, nil
 }
 
+// GetBySaleID returns every basket of the sale, oldest first.
+func (s *basketRepo) GetBySaleID(ctx context.Context, saleID string) ([]models.Basket, error) {
+	baskets := []models.Basket{}
+
+	rows, err := s.DB.Query(ctx, `SELECT id, sale_id, product_id, coalesce(variant_id::text, ''), quantity, price, created_at, updated_at
+						FROM baskets where sale_id = $1 and deleted_at is null order by created_at`, saleID)
+	if err != nil {
+		log.Println("Error while querying baskets of sale:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		basket := models.Basket{}
+		if err := rows.Scan(
+			&basket.ID,
+			&basket.SaleID,
+			&basket.ProductID,
+			&basket.VariantID,
+			&basket.Quantity,
+			&basket.Price,
+			&basket.CreatedAt,
+			&basket.UpdatedAt,
+		); err != nil {
+			log.Println("Error while scanning row of baskets:", err)
+			return nil, err
+		}
+		baskets = append(baskets, basket)
+	}
+
+	return baskets, nil
+}
+
 func (s *basketRepo) Update(ctx context.Context, basket models.UpdateBasket) (string, error) {
-	query := `UPDATE baskets SET sale_id = $1, product_id = $2, quantity = $3, price = $4, updated_at = NOW() WHERE id = $5`
+	query := `UPDATE baskets SET sale_id = $1, product_id = $2, variant_id = nullif($3, '')::uuid, quantity = $4, price = $5, 
+                   updated_at = NOW() WHERE id = $6`
 
 	_, err := s.DB.Exec(ctx, query,
 		&basket.SaleID,
 		&basket.ProductID,
+		&basket.VariantID,
 		&basket.Quantity,
 		&basket.Price,
 		&basket.ID,
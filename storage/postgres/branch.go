@@ -35,11 +35,13 @@ func (b branchRepo) Create(ctx context.Context, branch models.CreateBranch) (str
 
 func (b branchRepo) GetByID(ctx context.Context, id string) (models.Branch, error) {
 	branch := models.Branch{}
-	query := `select id, name, address, created_at, updated_at from branches where id = $1 and deleted_at is null`
+	query := `select id, name, address, coalesce(price_list_id::text, ''), created_at, updated_at 
+							from branches where id = $1 and deleted_at is null`
 	if err := b.db.QueryRow(ctx, query, id).Scan(
 		&branch.ID,
 		&branch.Name,
 		&branch.Address,
+		&branch.PriceListID,
 		&branch.CreatedAt,
 		&branch.UpdatedAt); err != nil {
 		fmt.Println("error is while selecting by id", err.Error())
@@ -69,7 +71,8 @@ func (b branchRepo) GetList(ctx context.Context, request models.GetListRequest)
 		return models.BranchResponse{}, err
 	}
 
-	query = `select id, name, address, created_at, updated_at from branches where deleted_at is NULL  `
+	query = `select id, name, address, coalesce(price_list_id::text, ''), created_at, updated_at 
+							from branches where deleted_at is NULL  `
 	if search != "" {
 		query += fmt.Sprintf(` and name ilike '%s' `, search)
 	}
@@ -87,6 +90,7 @@ func (b branchRepo) GetList(ctx context.Context, request models.GetListRequest)
 			&branch.ID,
 			&branch.Name,
 			&branch.Address,
+			&branch.PriceListID,
 			&branch.CreatedAt,
 			&branch.UpdatedAt); err != nil {
 			fmt.Println("error is while scanning branch", err.Error())
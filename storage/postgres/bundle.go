@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type bundleRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewBundleRepo(DB *pgxpool.Pool) storage.IBundleRepo {
+	return &bundleRepo{
+		DB: DB,
+	}
+}
+
+func (s *bundleRepo) Create(ctx context.Context, bundle models.CreateBundle) (string, error) {
+	id := uuid.New().String()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction:", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `INSERT INTO bundles 
+		(id, product_id, pricing_type, discount)
+			VALUES($1, $2, $3, $4)`,
+		id,
+		bundle.ProductID,
+		bundle.PricingType,
+		bundle.Discount,
+	); err != nil {
+		log.Println("Error while inserting data:", err)
+		return "", err
+	}
+
+	if err = insertBundleItems(ctx, tx, id, bundle.Items); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing bundle:", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *bundleRepo) GetByID(ctx context.Context, id models.PrimaryKey) (models.Bundle, error) {
+	return s.getBundle(ctx, `id = $1`, id.ID)
+}
+
+// GetByProductID returns an empty bundle when the product is not a bundle.
+func (s *bundleRepo) GetByProductID(ctx context.Context, productID string) (models.Bundle, error) {
+	bundle, err := s.getBundle(ctx, `product_id = $1`, productID)
+	if err == pgx.ErrNoRows {
+		return models.Bundle{}, nil
+	}
+	return bundle, err
+}
+
+func (s *bundleRepo) getBundle(ctx context.Context, condition string, arg string) (models.Bundle, error) {
+	bundle := models.Bundle{}
+	query := `SELECT id, product_id, pricing_type, discount, created_at, updated_at
+				FROM bundles WHERE ` + condition + ` and deleted_at is null`
+
+	if err := s.DB.QueryRow(ctx, query, arg).Scan(
+		&bundle.ID,
+		&bundle.ProductID,
+		&bundle.PricingType,
+		&bundle.Discount,
+		&bundle.CreatedAt,
+		&bundle.UpdatedAt,
+	); err != nil {
+		if err != pgx.ErrNoRows {
+			log.Println("Error while selecting bundle:", err)
+		}
+		return models.Bundle{}, err
+	}
+
+	items, err := s.getItems(ctx, bundle.ID)
+	if err != nil {
+		return models.Bundle{}, err
+	}
+	bundle.Items = items
+
+	return bundle, nil
+}
+
+func (s *bundleRepo) GetList(ctx context.Context, request models.GetListRequest) (models.BundlesResponse, error) {
+	var (
+		bundles = []models.Bundle{}
+		count   int
+	)
+
+	if err := s.DB.QueryRow(ctx, `SELECT COUNT(*) FROM bundles where deleted_at is null`).Scan(&count); err != nil {
+		log.Println("Error while scanning count of bundles:", err)
+		return models.BundlesResponse{}, err
+	}
+
+	query := `SELECT id, product_id, pricing_type, discount, created_at, updated_at
+						FROM bundles where deleted_at is null order by created_at desc LIMIT $1 OFFSET $2 `
+
+	rows, err := s.DB.Query(ctx, query, request.Limit, (request.Page-1)*request.Limit)
+	if err != nil {
+		log.Println("Error while querying bundles:", err)
+		return models.BundlesResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		bundle := models.Bundle{}
+		if err := rows.Scan(
+			&bundle.ID,
+			&bundle.ProductID,
+			&bundle.PricingType,
+			&bundle.Discount,
+			&bundle.CreatedAt,
+			&bundle.UpdatedAt,
+		); err != nil {
+			log.Println("Error while scanning row of bundles:", err)
+			return models.BundlesResponse{}, err
+		}
+		bundles = append(bundles, bundle)
+	}
+	rows.Close()
+
+	for i := range bundles {
+		if bundles[i].Items, err = s.getItems(ctx, bundles[i].ID); err != nil {
+			return models.BundlesResponse{}, err
+		}
+	}
+
+	return models.BundlesResponse{
+		Bundles: bundles,
+		Count:   count,
+	}, nil
+}
+
+func (s *bundleRepo) Update(ctx context.Context, bundle models.UpdateBundle) (string, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction:", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `UPDATE bundles SET pricing_type = $1, discount = $2, updated_at = NOW() WHERE id = $3`,
+		bundle.PricingType,
+		bundle.Discount,
+		bundle.ID,
+	); err != nil {
+		log.Println("Error while updating bundle :", err)
+		return "", err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM bundle_items WHERE bundle_id = $1`, bundle.ID); err != nil {
+		log.Println("Error while deleting bundle items :", err)
+		return "", err
+	}
+
+	if err = insertBundleItems(ctx, tx, bundle.ID, bundle.Items); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing bundle:", err)
+		return "", err
+	}
+
+	return bundle.ID, nil
+}
+
+func (s *bundleRepo) Delete(ctx context.Context, id string) error {
+	query := `UPDATE bundles SET deleted_at = NOW() WHERE id = $1`
+
+	if _, err := s.DB.Exec(ctx, query, id); err != nil {
+		log.Println("Error while deleting bundle :", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *bundleRepo) getItems(ctx context.Context, bundleID string) ([]models.BundleItem, error) {
+	items := []models.BundleItem{}
+
+	rows, err := s.DB.Query(ctx, `SELECT product_id, quantity FROM bundle_items WHERE bundle_id = $1 order by created_at`, bundleID)
+	if err != nil {
+		log.Println("Error while querying bundle items:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.BundleItem{}
+		if err := rows.Scan(&item.ProductID, &item.Quantity); err != nil {
+			log.Println("Error while scanning row of bundle items:", err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func insertBundleItems(ctx context.Context, tx pgx.Tx, bundleID string, items []models.BundleItem) error {
+	for _, item := range items {
+		if _, err := tx.Exec(ctx, `INSERT INTO bundle_items (id, bundle_id, product_id, quantity) VALUES ($1, $2, $3, $4)`,
+			uuid.New(),
+			bundleID,
+			item.ProductID,
+			item.Quantity,
+		); err != nil {
+			log.Println("Error while inserting bundle item:", err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"sell/api/models"
 	"sell/storage"
@@ -19,7 +20,7 @@ func NewCategoryRepo(db *pgxpool.Pool) storage.ICategory {
 
 func (c categoryRepo) Create(ctx context.Context, category models.CreateCategory) (string, error) {
 	id := uuid.New()
-	query := `insert into categories (id, name, parent_id) values($1, $2, $3)`
+	query := `insert into categories (id, name, parent_id) values($1, $2, nullif($3, ''))`
 	if _, err := c.db.Exec(ctx, query, id, category.Name, category.ParentID); err != nil {
 		fmt.Println("error is while inserting data", err.Error())
 		return "", err
@@ -29,7 +30,7 @@ func (c categoryRepo) Create(ctx context.Context, category models.CreateCategory
 
 func (c categoryRepo) GetByID(ctx context.Context, id string) (models.Category, error) {
 	category := models.Category{}
-	query := `select id, name, parent_id, created_at, updated_at from categories where id = $1 and deleted_at is null`
+	query := `select id, name, coalesce(parent_id, ''), created_at, updated_at from categories where id = $1 and deleted_at is null`
 	if err := c.db.QueryRow(ctx, query, id).Scan(
 		&category.ID,
 		&category.Name,
@@ -60,7 +61,7 @@ func (c categoryRepo) GetList(ctx context.Context, request models.GetListRequest
 		return models.CategoryResponse{}, err
 	}
 
-	query = `select id, name, parent_id, created_at, updated_at from categories where deleted_at is null `
+	query = `select id, name, coalesce(parent_id, ''), created_at, updated_at from categories where deleted_at is null `
 	if search != "" {
 		query += fmt.Sprintf(` and name ilike '%%%s%%'`, search)
 	}
@@ -92,11 +93,27 @@ func (c categoryRepo) GetList(ctx context.Context, request models.GetListRequest
 }
 
 func (c categoryRepo) Update(ctx context.Context, category models.UpdateCategory) (string, error) {
-	query := `update categories set name = $1, parent_id = $2, updated_at = now() where id = $3`
-	if _, err := c.db.Exec(ctx, query, &category.Name, &category.ParentID, &category.ID); err != nil {
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCategoryParent(ctx, tx, category.ID, category.ParentID); err != nil {
+		return "", err
+	}
+
+	query := `update categories set name = $1, parent_id = nullif($2, ''), updated_at = now() where id = $3`
+	if _, err = tx.Exec(ctx, query, &category.Name, &category.ParentID, &category.ID); err != nil {
 		fmt.Println("error is while updating", err.Error())
 		return "", err
 	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing transaction", err.Error())
+		return "", err
+	}
 	return category.ID, nil
 }
 
@@ -108,3 +125,182 @@ func (c categoryRepo) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// GetTree returns the subtree of the category, or the whole tree when id is empty.
+func (c categoryRepo) GetTree(ctx context.Context, id string) ([]*models.CategoryNode, error) {
+	var (
+		roots = []*models.CategoryNode{}
+		nodes = map[string]*models.CategoryNode{}
+	)
+
+	query := `with recursive tree as (
+					select id, name, parent_id, 0 as depth from categories 
+						where deleted_at is null and (case when $1 = '' then parent_id is null else id = $1 end)
+					union all
+					select c.id, c.name, c.parent_id, t.depth + 1 from categories c 
+						join tree t on c.parent_id = t.id where c.deleted_at is null
+				)
+				select id, name, coalesce(parent_id, '') from tree order by depth, name`
+	rows, err := c.db.Query(ctx, query, id)
+	if err != nil {
+		fmt.Println("error is while selecting tree", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		node := &models.CategoryNode{Children: []*models.CategoryNode{}}
+		if err = rows.Scan(&node.ID, &node.Name, &node.ParentID); err != nil {
+			fmt.Println("error is while scanning tree", err.Error())
+			return nil, err
+		}
+		nodes[node.ID] = node
+
+		if parent, ok := nodes[node.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	return roots, nil
+}
+
+// GetBreadcrumbs returns the path from the root category down to the given one.
+func (c categoryRepo) GetBreadcrumbs(ctx context.Context, id string) ([]models.Category, error) {
+	categories := []models.Category{}
+
+	query := `with recursive path as (
+					select id, name, parent_id, created_at, updated_at, 0 as depth from categories 
+						where id = $1 and deleted_at is null
+					union all
+					select c.id, c.name, c.parent_id, c.created_at, c.updated_at, p.depth + 1 from categories c 
+						join path p on c.id = p.parent_id where c.deleted_at is null
+				)
+				select id, name, coalesce(parent_id, ''), created_at, updated_at from path order by depth desc`
+	rows, err := c.db.Query(ctx, query, id)
+	if err != nil {
+		fmt.Println("error is while selecting breadcrumbs", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		category := models.Category{}
+		if err = rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.ParentID,
+			&category.CreatedAt,
+			&category.UpdatedAt); err != nil {
+			fmt.Println("error is while scanning breadcrumbs", err.Error())
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+// Move puts the category under another parent. It returns pgx.ErrNoRows when the parent does
+// not exist and storage.ErrCategoryCycle when the parent is the category or its descendant.
+func (c categoryRepo) Move(ctx context.Context, category models.MoveCategory) (string, error) {
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockCategoryParent(ctx, tx, category.ID, category.ParentID); err != nil {
+		return "", err
+	}
+
+	query := `update categories set parent_id = nullif($1, ''), updated_at = now() where id = $2`
+	if _, err = tx.Exec(ctx, query, category.ParentID, category.ID); err != nil {
+		fmt.Println("error is while moving", err.Error())
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing transaction", err.Error())
+		return "", err
+	}
+	return category.ID, nil
+}
+
+// lockCategoryParent locks the category and every ancestor of the new parent, then checks the
+// category is not among them. Two moves that would close a cycle together share a locked row,
+// so the second one sees the first and is refused.
+func lockCategoryParent(ctx context.Context, tx pgx.Tx, id, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+
+	query := `with recursive path as (
+					select id, parent_id from categories where id = $1 and deleted_at is null
+					union all
+					select c.id, c.parent_id from categories c 
+						join path p on c.id = p.parent_id where c.deleted_at is null
+				)
+				select id from categories where id = $2 or id in (select id from path) order by id for update`
+	rows, err := tx.Query(ctx, query, parentID, id)
+	if err != nil {
+		fmt.Println("error is while locking categories", err.Error())
+		return err
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		fmt.Println("error is while locking categories", err.Error())
+		return err
+	}
+
+	ancestors := []string{}
+	rows, err = tx.Query(ctx, `with recursive path as (
+					select id, parent_id from categories where id = $1 and deleted_at is null
+					union all
+					select c.id, c.parent_id from categories c 
+						join path p on c.id = p.parent_id where c.deleted_at is null
+				)
+				select id from path`, parentID)
+	if err != nil {
+		fmt.Println("error is while selecting category path", err.Error())
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ancestorID string
+		if err = rows.Scan(&ancestorID); err != nil {
+			fmt.Println("error is while scanning category path", err.Error())
+			return err
+		}
+		ancestors = append(ancestors, ancestorID)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	if len(ancestors) == 0 {
+		return pgx.ErrNoRows
+	}
+
+	for _, ancestorID := range ancestors {
+		if ancestorID == id {
+			return storage.ErrCategoryCycle
+		}
+	}
+	return nil
+}
+
+func (c categoryRepo) GetUsage(ctx context.Context, id string) (models.CategoryUsage, error) {
+	usage := models.CategoryUsage{}
+	query := `select 
+					(select count(1) from categories where parent_id = $1 and deleted_at is null),
+					(select count(1) from products where category_id = $1 and deleted_at is null)`
+	if err := c.db.QueryRow(ctx, query, id).Scan(&usage.Children, &usage.Products); err != nil {
+		fmt.Println("error is while selecting usage", err.Error())
+		return models.CategoryUsage{}, err
+	}
+	return usage, nil
+}
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"sell/api/models"
+	"sell/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type commissionRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewCommissionRepo(DB *pgxpool.Pool) storage.ICommissionRepo {
+	return &commissionRepo{
+		DB: DB,
+	}
+}
+
+func (c *commissionRepo) Create(ctx context.Context, rule models.CreateCommissionRule) (string, error) {
+	id := uuid.New().String()
+
+	if _, err := c.DB.Exec(ctx, `insert into commission_rules 
+		(id, tariff_id, category_id, min_volume, tariff_type, amount_for_cash, amount_for_card, effective_from, effective_to) 
+			values ($1, $2, nullif($3, ''), $4, $5, $6, $7, $8::date, nullif($9, '')::date)`,
+		id,
+		rule.TariffID,
+		rule.CategoryID,
+		rule.MinVolume,
+		rule.TariffType,
+		rule.AmountForCash,
+		rule.AmountForCard,
+		rule.EffectiveFrom,
+		rule.EffectiveTo,
+	); err != nil {
+		log.Println("Error while inserting commission rule", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (c *commissionRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.CommissionRule, error) {
+	rule := models.CommissionRule{}
+
+	if err := c.DB.QueryRow(ctx, `select `+commissionRuleColumns+` from commission_rules 
+		where id = $1 and deleted_at is null`, key.ID).Scan(
+		&rule.ID,
+		&rule.TariffID,
+		&rule.CategoryID,
+		&rule.MinVolume,
+		&rule.TariffType,
+		&rule.AmountForCash,
+		&rule.AmountForCard,
+		&rule.EffectiveFrom,
+		&rule.EffectiveTo,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	); err != nil {
+		log.Println("Error while selecting commission rule", err)
+		return models.CommissionRule{}, err
+	}
+
+	return rule, nil
+}
+
+func (c *commissionRepo) GetList(ctx context.Context, tariffID string) ([]models.CommissionRule, error) {
+	return commissionRules(ctx, c.DB, tariffID)
+}
+
+func (c *commissionRepo) Update(ctx context.Context, rule models.UpdateCommissionRule) (string, error) {
+	result, err := c.DB.Exec(ctx, `update commission_rules set category_id = nullif($3, ''), min_volume = $4, 
+		tariff_type = $5, amount_for_cash = $6, amount_for_card = $7, effective_from = $8::date, 
+		effective_to = nullif($9, '')::date, updated_at = now() 
+			where id = $1 and tariff_id = $2 and deleted_at is null`,
+		rule.ID,
+		rule.TariffID,
+		rule.CategoryID,
+		rule.MinVolume,
+		rule.TariffType,
+		rule.AmountForCash,
+		rule.AmountForCard,
+		rule.EffectiveFrom,
+		rule.EffectiveTo,
+	)
+	if err != nil {
+		log.Println("Error while updating commission rule", err)
+		return "", err
+	}
+
+	if result.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
+
+	return rule.ID, nil
+}
+
+func (c *commissionRepo) Delete(ctx context.Context, tariffID, id string) error {
+	result, err := c.DB.Exec(ctx, `update commission_rules set deleted_at = now() 
+		where id = $1 and tariff_id = $2 and deleted_at is null`, id, tariffID)
+	if err != nil {
+		log.Println("Error while deleting commission rule", err)
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// Calculate finds the rule of the tariff the staff had at the sale for the line and works out the commission.
+// The rule of the nearest category of the product wins over the rules of its parents and over
+// the rules for every category, among them the highest volume tier the staff reached. The flat
+// rate of the tariff applies when no rule matches.
+func (c *commissionRepo) Calculate(ctx context.Context, request models.CommissionRequest) (models.Commission, error) {
+	return calculateCommission(ctx, c.DB, request)
+}
+
+// calculateCommission is Calculate on db, ending a sale calculates the commissions of its
+// lines in its transaction.
+func calculateCommission(ctx context.Context, db querier, request models.CommissionRequest) (models.Commission, error) {
+	commission := models.Commission{
+		StaffID:     request.StaffID,
+		PaymentType: request.PaymentType,
+		Price:       request.Price,
+		Quantity:    request.Quantity,
+		SoldAt:      time.Now(),
+	}
+	if request.SoldAt != nil {
+		commission.SoldAt = *request.SoldAt
+	}
+
+	productID := request.ProductID
+	if request.BasketID != "" {
+		if err := db.QueryRow(ctx, `select b.product_id, b.price, b.quantity, coalesce(s.payment_type::text, ''), s.created_at 
+			from baskets b join sales s on s.id = b.sale_id where b.id = $1 and b.deleted_at is null`, request.BasketID).Scan(
+			&productID,
+			&commission.Price,
+			&commission.Quantity,
+			&commission.PaymentType,
+			&commission.SoldAt,
+		); err != nil {
+			log.Println("Error while selecting sale line", err)
+			return models.Commission{}, err
+		}
+	}
+
+	if err := db.QueryRow(ctx, `select coalesce(category_id, '') from products where id = $1`, productID).Scan(&commission.CategoryID); err != nil {
+		log.Println("Error while selecting product category", err)
+		return models.Commission{}, err
+	}
+
+	var flatCash, flatCard float64
+	// the tariff the staff had when the line was sold
+	if err := db.QueryRow(ctx, `select t.id, t.tariff_type, coalesce(t.amount_for_cash, 0), coalesce(t.amount_for_card, 0) 
+		from staffs s `+staffTariffAt+` where s.id = $1`, request.StaffID, commission.SoldAt).Scan(
+		&commission.TariffID,
+		&commission.TariffType,
+		&flatCash,
+		&flatCard,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Commission{}, storage.ErrNoTariff
+		}
+		log.Println("Error while selecting staff tariff", err)
+		return models.Commission{}, err
+	}
+
+	month := time.Date(commission.SoldAt.Year(), commission.SoldAt.Month(), 1, 0, 0, 0, 0, commission.SoldAt.Location())
+	if err := db.QueryRow(ctx, `select coalesce(sum(price), 0) from sales 
+		where status = 'success' and deleted_at is null and created_at >= $2 and created_at <= $3 
+		  and (cashier_id = $1 or shop_assistant_id = $1::text)`, request.StaffID, month, commission.SoldAt).Scan(&commission.Volume); err != nil {
+		log.Println("Error while selecting sales volume", err)
+		return models.Commission{}, err
+	}
+
+	var ruleCash, ruleCard float64
+	err := db.QueryRow(ctx, `
+		with recursive ancestors as (
+			select id, parent_id, 0 as depth from categories where id = $2
+			union all
+			select c.id, c.parent_id, a.depth + 1 from categories c join ancestors a on c.id = a.parent_id where a.depth < 32
+		)
+		select r.id, r.tariff_type, r.amount_for_cash, r.amount_for_card from commission_rules r 
+			left join ancestors a on a.id = r.category_id
+				where r.tariff_id = $1 and r.deleted_at is null and (r.category_id is null or a.id is not null) 
+				  and r.effective_from <= $3::date and (r.effective_to is null or r.effective_to >= $3::date) 
+				  and r.min_volume <= $4
+				order by a.depth nulls last, r.min_volume desc, r.effective_from desc limit 1`,
+		commission.TariffID, commission.CategoryID, commission.SoldAt, commission.Volume).Scan(
+		&commission.RuleID,
+		&commission.TariffType,
+		&ruleCash,
+		&ruleCard,
+	)
+	switch {
+	case err == nil:
+		flatCash, flatCard = ruleCash, ruleCard
+	case !errors.Is(err, pgx.ErrNoRows):
+		log.Println("Error while selecting commission rule", err)
+		return models.Commission{}, err
+	}
+
+	commission.Rate = flatCash
+	if commission.PaymentType == "card" {
+		commission.Rate = flatCard
+	}
+
+	if commission.TariffType == "percent" {
+		commission.Amount = commission.Price * commission.Rate / 100
+	} else {
+		commission.Amount = commission.Rate * commission.Quantity
+	}
+	commission.Amount = math.Round(commission.Amount*100) / 100
+
+	return commission, nil
+}
+
+// commissionRules returns the rules of the tariff, the latest first.
+func commissionRules(ctx context.Context, db querier, tariffID string) ([]models.CommissionRule, error) {
+	rules := []models.CommissionRule{}
+
+	rows, err := db.Query(ctx, `select `+commissionRuleColumns+` from commission_rules 
+		where tariff_id = $1 and deleted_at is null 
+		order by effective_from desc, category_id nulls last, min_volume`, tariffID)
+	if err != nil {
+		log.Println("Error while selecting commission rules", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rule := models.CommissionRule{}
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.TariffID,
+			&rule.CategoryID,
+			&rule.MinVolume,
+			&rule.TariffType,
+			&rule.AmountForCash,
+			&rule.AmountForCard,
+			&rule.EffectiveFrom,
+			&rule.EffectiveTo,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		); err != nil {
+			log.Println("Error while scanning commission rule", err)
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+const commissionRuleColumns = `id, tariff_id, coalesce(category_id, ''), min_volume, tariff_type, amount_for_cash, amount_for_card, 
+	effective_from::text, coalesce(effective_to::text, ''), created_at, updated_at`
@@ -0,0 +1,700 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sell/api/models"
+	"sell/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// the system accounts seeded by 023_general_ledger
+const (
+	accountCash          = "1000"
+	accountCard          = "1100"
+	accountStaffPayables = "2000"
+	accountOpening       = "3000"
+	accountSales         = "4000"
+	accountSalesReturns  = "4100"
+	accountFineIncome    = "4200"
+	accountCommissions   = "5000"
+	accountSalaries      = "5100"
+)
+
+type ledgerRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewLedgerRepo(DB *pgxpool.Pool) storage.ILedgerRepo {
+	return &ledgerRepo{
+		DB: DB,
+	}
+}
+
+func (l *ledgerRepo) CreateAccount(ctx context.Context, account models.CreateAccount) (string, error) {
+	id := uuid.New().String()
+
+	if _, err := l.DB.Exec(ctx, `insert into accounts (id, code, name, account_type) values ($1, $2, $3, $4)`,
+		id,
+		account.Code,
+		account.Name,
+		account.AccountType,
+	); err != nil {
+		log.Println("Error while inserting account", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (l *ledgerRepo) GetAccountByID(ctx context.Context, key models.PrimaryKey) (models.Account, error) {
+	account := models.Account{}
+
+	if err := l.DB.QueryRow(ctx, `select id, code, name, account_type, system, created_at from accounts where id = $1`,
+		key.ID).Scan(
+		&account.ID,
+		&account.Code,
+		&account.Name,
+		&account.AccountType,
+		&account.System,
+		&account.CreatedAt,
+	); err != nil {
+		log.Println("Error while selecting account", err)
+		return models.Account{}, err
+	}
+
+	return account, nil
+}
+
+func (l *ledgerRepo) GetAccountList(ctx context.Context) (models.AccountsResponse, error) {
+	accounts := []models.Account{}
+
+	rows, err := l.DB.Query(ctx, `select id, code, name, account_type, system, created_at from accounts order by code`)
+	if err != nil {
+		log.Println("Error while selecting accounts", err)
+		return models.AccountsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		account := models.Account{}
+		if err := rows.Scan(
+			&account.ID,
+			&account.Code,
+			&account.Name,
+			&account.AccountType,
+			&account.System,
+			&account.CreatedAt,
+		); err != nil {
+			log.Println("Error while scanning account", err)
+			return models.AccountsResponse{}, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return models.AccountsResponse{
+		Accounts: accounts,
+		Count:    len(accounts),
+	}, rows.Err()
+}
+
+func (l *ledgerRepo) CreateEntry(ctx context.Context, entry models.CreateJournalEntry) (string, error) {
+	tx, err := l.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := postEntry(ctx, tx, entry)
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing journal entry", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (l *ledgerRepo) GetEntryByID(ctx context.Context, key models.PrimaryKey) (models.JournalEntry, error) {
+	entry := models.JournalEntry{}
+
+	if err := l.DB.QueryRow(ctx, `select `+journalEntryColumns+` from journal_entries e where e.id = $1`, key.ID).Scan(
+		&entry.ID,
+		&entry.EntryDate,
+		&entry.Description,
+		&entry.SourceType,
+		&entry.SourceID,
+		&entry.ReversalOf,
+		&entry.ReversedBy,
+		&entry.CreatedBy,
+		&entry.CreatedAt,
+	); err != nil {
+		log.Println("Error while selecting journal entry", err)
+		return models.JournalEntry{}, err
+	}
+
+	postings, err := entryPostings(ctx, l.DB, entry.ID)
+	if err != nil {
+		return models.JournalEntry{}, err
+	}
+	entry.Postings = postings
+
+	return entry, nil
+}
+
+func (l *ledgerRepo) GetEntryList(ctx context.Context, request models.JournalEntryGetListRequest) (models.JournalEntriesResponse, error) {
+	var (
+		entries = []models.JournalEntry{}
+		count   int
+		filter  = ` where true`
+		args    = []interface{}{}
+	)
+
+	if request.SourceType != "" {
+		args = append(args, request.SourceType)
+		filter += fmt.Sprintf(` and e.source_type = $%d`, len(args))
+	}
+
+	if request.SourceID != "" {
+		args = append(args, request.SourceID)
+		filter += fmt.Sprintf(` and e.source_id = $%d`, len(args))
+	}
+
+	if request.AccountCode != "" {
+		args = append(args, request.AccountCode)
+		filter += fmt.Sprintf(` and exists (select 1 from postings p join accounts a on a.id = p.account_id
+			where p.entry_id = e.id and a.code = $%d)`, len(args))
+	}
+
+	if !request.From.IsZero() {
+		args = append(args, request.From)
+		filter += fmt.Sprintf(` and e.entry_date >= $%d`, len(args))
+	}
+
+	if !request.To.IsZero() {
+		args = append(args, request.To)
+		filter += fmt.Sprintf(` and e.entry_date < $%d`, len(args))
+	}
+
+	if err := l.DB.QueryRow(ctx, `select count(1) from journal_entries e`+filter, args...).Scan(&count); err != nil {
+		log.Println("Error while counting journal entries", err)
+		return models.JournalEntriesResponse{}, err
+	}
+
+	args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	rows, err := l.DB.Query(ctx, `select `+journalEntryColumns+` from journal_entries e`+filter+
+		fmt.Sprintf(` order by e.entry_date desc, e.created_at desc limit $%d offset $%d`, len(args)-1, len(args)), args...)
+	if err != nil {
+		log.Println("Error while selecting journal entries", err)
+		return models.JournalEntriesResponse{}, err
+	}
+
+	for rows.Next() {
+		entry := models.JournalEntry{}
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.EntryDate,
+			&entry.Description,
+			&entry.SourceType,
+			&entry.SourceID,
+			&entry.ReversalOf,
+			&entry.ReversedBy,
+			&entry.CreatedBy,
+			&entry.CreatedAt,
+		); err != nil {
+			rows.Close()
+			log.Println("Error while scanning journal entry", err)
+			return models.JournalEntriesResponse{}, err
+		}
+		entries = append(entries, entry)
+	}
+	rows.Close()
+
+	for i := range entries {
+		if entries[i].Postings, err = entryPostings(ctx, l.DB, entries[i].ID); err != nil {
+			return models.JournalEntriesResponse{}, err
+		}
+	}
+
+	return models.JournalEntriesResponse{
+		JournalEntries: entries,
+		Count:          count,
+	}, nil
+}
+
+// ReverseEntry reverses a manual entry, the automatic ones follow their sales and transactions.
+func (l *ledgerRepo) ReverseEntry(ctx context.Context, request models.ReverseJournalEntry) (string, error) {
+	tx, err := l.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var sourceType string
+	if err := tx.QueryRow(ctx, `select source_type from journal_entries where id = $1 for update`,
+		request.ID).Scan(&sourceType); err != nil {
+		log.Println("Error while selecting journal entry", err)
+		return "", err
+	}
+
+	if sourceType != "manual" {
+		return "", pgx.ErrNoRows
+	}
+
+	id, err := reverseEntry(ctx, tx, request.ID, request.Description, request.CreatedBy)
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing journal entry reversal", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// postSale posts the price of a finished sale, reversing what was posted for it before.
+// It runs in the transaction that finishes the sale.
+func postSale(ctx context.Context, db querier, saleID string) error {
+	var (
+		price                 float64
+		paymentType, branchID string
+	)
+	if err := db.QueryRow(ctx, `select coalesce(price, 0), coalesce(payment_type::text, ''), coalesce(branch_id::text, '')
+		from sales where id = $1 and status = 'success' and deleted_at is null for update`, saleID).Scan(
+		&price,
+		&paymentType,
+		&branchID,
+	); err != nil {
+		log.Println("Error while selecting sale", err)
+		return err
+	}
+
+	if err := reverseSource(ctx, db, "sale", saleID, "sale changed"); err != nil {
+		return err
+	}
+
+	if price > 0 {
+		if _, err := postEntry(ctx, db, models.CreateJournalEntry{
+			Description: "sale",
+			SourceType:  "sale",
+			SourceID:    saleID,
+			Postings: []models.CreatePosting{
+				{AccountCode: paymentAccount(paymentType), BranchID: branchID, Debit: price},
+				{AccountCode: accountSales, BranchID: branchID, Credit: price},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *ledgerRepo) TrialBalance(ctx context.Context, request models.TrialBalanceRequest) (models.TrialBalance, error) {
+	var (
+		balance = models.TrialBalance{
+			BranchID: request.BranchID,
+			Lines:    []models.TrialBalanceLine{},
+		}
+		filter = ` where true`
+		args   = []interface{}{}
+	)
+
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and p.branch_id = $%d`, len(args))
+	}
+
+	if !request.To.IsZero() {
+		args = append(args, request.To)
+		filter += fmt.Sprintf(` and e.entry_date < $%d`, len(args))
+	}
+
+	rows, err := l.DB.Query(ctx, `select a.id, a.code, a.name, a.account_type, coalesce(t.debit, 0), coalesce(t.credit, 0)
+		from accounts a left join (select p.account_id, sum(p.debit) as debit, sum(p.credit) as credit
+			from postings p join journal_entries e on e.id = p.entry_id`+filter+` group by p.account_id) t on t.account_id = a.id
+		order by a.code`, args...)
+	if err != nil {
+		log.Println("Error while selecting trial balance", err)
+		return models.TrialBalance{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		line := models.TrialBalanceLine{}
+		if err := rows.Scan(
+			&line.AccountID,
+			&line.AccountCode,
+			&line.AccountName,
+			&line.AccountType,
+			&line.TotalDebit,
+			&line.TotalCredit,
+		); err != nil {
+			log.Println("Error while scanning trial balance", err)
+			return models.TrialBalance{}, err
+		}
+
+		if net := roundMoney(line.TotalDebit - line.TotalCredit); net > 0 {
+			line.Debit = net
+		} else {
+			line.Credit = -net
+		}
+		balance.Debit = roundMoney(balance.Debit + line.Debit)
+		balance.Credit = roundMoney(balance.Credit + line.Credit)
+		balance.Lines = append(balance.Lines, line)
+	}
+
+	return balance, rows.Err()
+}
+
+func (l *ledgerRepo) AccountStatement(ctx context.Context, request models.AccountStatementRequest) (models.AccountStatement, error) {
+	account, err := l.GetAccountByID(ctx, models.PrimaryKey{ID: request.AccountID})
+	if err != nil {
+		return models.AccountStatement{}, err
+	}
+
+	var (
+		statement = models.AccountStatement{
+			Account:  account,
+			StaffID:  request.StaffID,
+			BranchID: request.BranchID,
+			Lines:    []models.AccountStatementLine{},
+		}
+		filter = ` where p.account_id = $1`
+		args   = []interface{}{request.AccountID}
+		to     = request.To
+	)
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	if request.StaffID != "" {
+		args = append(args, request.StaffID)
+		filter += fmt.Sprintf(` and p.staff_id = $%d`, len(args))
+	}
+
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and p.branch_id = $%d`, len(args))
+	}
+
+	// assets and expenses grow on the debit side, the other accounts on the credit side
+	sign := -1.0
+	if account.AccountType == "asset" || account.AccountType == "expense" {
+		sign = 1
+	}
+
+	var debit, credit float64
+	if err := l.DB.QueryRow(ctx, `select coalesce(sum(p.debit), 0), coalesce(sum(p.credit), 0)
+		from postings p join journal_entries e on e.id = p.entry_id`+filter+
+		fmt.Sprintf(` and e.entry_date < $%d`, len(args)+1), append(args, request.From)...).Scan(&debit, &credit); err != nil {
+		log.Println("Error while selecting opening balance", err)
+		return models.AccountStatement{}, err
+	}
+	statement.OpeningBalance = roundMoney(sign * (debit - credit))
+
+	args = append(args, request.From, to)
+	rows, err := l.DB.Query(ctx, `select e.id, e.entry_date, e.description, e.source_type, coalesce(e.source_id::text, ''),
+		coalesce(p.staff_id::text, ''), coalesce(p.branch_id::text, ''), p.debit, p.credit
+		from postings p join journal_entries e on e.id = p.entry_id`+filter+
+		fmt.Sprintf(` and e.entry_date >= $%d and e.entry_date < $%d`, len(args)-1, len(args))+
+		` order by e.entry_date, e.created_at, p.id`, args...)
+	if err != nil {
+		log.Println("Error while selecting account postings", err)
+		return models.AccountStatement{}, err
+	}
+	defer rows.Close()
+
+	balance := statement.OpeningBalance
+	for rows.Next() {
+		line := models.AccountStatementLine{}
+		if err := rows.Scan(
+			&line.EntryID,
+			&line.EntryDate,
+			&line.Description,
+			&line.SourceType,
+			&line.SourceID,
+			&line.StaffID,
+			&line.BranchID,
+			&line.Debit,
+			&line.Credit,
+		); err != nil {
+			log.Println("Error while scanning account posting", err)
+			return models.AccountStatement{}, err
+		}
+
+		balance = roundMoney(balance + sign*(line.Debit-line.Credit))
+		line.Balance = balance
+		statement.Debit = roundMoney(statement.Debit + line.Debit)
+		statement.Credit = roundMoney(statement.Credit + line.Credit)
+		statement.Lines = append(statement.Lines, line)
+	}
+	statement.ClosingBalance = balance
+
+	return statement, rows.Err()
+}
+
+// balancedPostings rounds the postings to cents and returns storage.ErrUnbalancedEntry unless
+// there are two or more, each on one side only, and the debits equal the credits.
+func balancedPostings(entryPostings []models.CreatePosting) ([]models.CreatePosting, error) {
+	if len(entryPostings) < 2 {
+		return nil, storage.ErrUnbalancedEntry
+	}
+
+	var (
+		postings      = make([]models.CreatePosting, len(entryPostings))
+		debit, credit float64
+	)
+	for i, posting := range entryPostings {
+		posting.Debit, posting.Credit = roundMoney(posting.Debit), roundMoney(posting.Credit)
+		if posting.Debit < 0 || posting.Credit < 0 || (posting.Debit == 0) == (posting.Credit == 0) {
+			return nil, storage.ErrUnbalancedEntry
+		}
+
+		debit += posting.Debit
+		credit += posting.Credit
+		postings[i] = posting
+	}
+
+	if roundMoney(debit) != roundMoney(credit) {
+		return nil, storage.ErrUnbalancedEntry
+	}
+
+	return postings, nil
+}
+
+// postEntry checks that the entry is balanced and inserts it with its postings. The
+// postings_balanced trigger checks it again when the transaction commits.
+func postEntry(ctx context.Context, db querier, entry models.CreateJournalEntry) (string, error) {
+	postings, err := balancedPostings(entry.Postings)
+	if err != nil {
+		return "", err
+	}
+
+	var entryDate *time.Time
+	if !entry.EntryDate.IsZero() {
+		entryDate = &entry.EntryDate
+	}
+
+	id := uuid.New().String()
+	if _, err := db.Exec(ctx, `insert into journal_entries (id, entry_date, description, source_type, source_id, reversal_of, created_by)
+		values ($1, coalesce($2::timestamp, now()), left($3, 200), $4, nullif($5, '')::uuid, nullif($6, '')::uuid, nullif($7, '')::uuid)`,
+		id,
+		entryDate,
+		entry.Description,
+		entry.SourceType,
+		entry.SourceID,
+		entry.ReversalOf,
+		entry.CreatedBy,
+	); err != nil {
+		log.Println("Error while inserting journal entry", err)
+		return "", err
+	}
+
+	for _, posting := range postings {
+		tag, err := db.Exec(ctx, `insert into postings (id, entry_id, account_id, staff_id, branch_id, debit, credit)
+			select $1::uuid, $2::uuid, id, nullif($4, '')::uuid, nullif($5, '')::uuid, $6::numeric, $7::numeric
+			from accounts where code = $3`,
+			uuid.New().String(),
+			id,
+			posting.AccountCode,
+			posting.StaffID,
+			posting.BranchID,
+			posting.Debit,
+			posting.Credit,
+		)
+		if err != nil {
+			log.Println("Error while inserting posting", err)
+			return "", err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return "", fmt.Errorf("%w %s", storage.ErrUnknownAccount, posting.AccountCode)
+		}
+	}
+
+	return id, nil
+}
+
+// reverseEntry posts the lines of the entry on the other side and marks it reversed.
+// It returns pgx.ErrNoRows when the entry is a reversal or is reversed already.
+func reverseEntry(ctx context.Context, db querier, entryID, description, createdBy string) (string, error) {
+	var sourceType, sourceID string
+	if err := db.QueryRow(ctx, `select source_type, coalesce(source_id::text, '') from journal_entries
+		where id = $1 and reversal_of is null and reversed_by is null for update`, entryID).Scan(&sourceType, &sourceID); err != nil {
+		log.Println("Error while selecting journal entry to reverse", err)
+		return "", err
+	}
+
+	postings, err := entryPostings(ctx, db, entryID)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := postEntry(ctx, db, models.CreateJournalEntry{
+		Description: description,
+		SourceType:  sourceType,
+		SourceID:    sourceID,
+		ReversalOf:  entryID,
+		CreatedBy:   createdBy,
+		Postings:    reversalPostings(postings),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := db.Exec(ctx, `update journal_entries set reversed_by = $2 where id = $1`, entryID, id); err != nil {
+		log.Println("Error while marking journal entry reversed", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// reversalPostings puts the postings on the other side.
+func reversalPostings(postings []models.Posting) []models.CreatePosting {
+	reversal := make([]models.CreatePosting, 0, len(postings))
+	for _, posting := range postings {
+		reversal = append(reversal, models.CreatePosting{
+			AccountCode: posting.AccountCode,
+			StaffID:     posting.StaffID,
+			BranchID:    posting.BranchID,
+			Debit:       posting.Credit,
+			Credit:      posting.Debit,
+		})
+	}
+	return reversal
+}
+
+// reverseSource reverses the entry posted for the source, if there is one.
+func reverseSource(ctx context.Context, db querier, sourceType, sourceID, description string) error {
+	var entryID string
+	err := db.QueryRow(ctx, `select id from journal_entries where source_type = $1 and source_id = $2
+		and reversal_of is null and reversed_by is null`, sourceType, sourceID).Scan(&entryID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		log.Println("Error while selecting journal entry of source", err)
+		return err
+	}
+
+	_, err = reverseEntry(ctx, db, entryID, description, "")
+	return err
+}
+
+// postTransaction posts a staff transaction between the staff payables and the account the
+// source of the transaction is paid from or to.
+func postTransaction(ctx context.Context, db querier, transactionID string, trans models.CreateTransaction) error {
+	delta := signedAmount(trans.TransactionType, trans.Amount)
+	if delta == 0 {
+		return nil
+	}
+
+	var branchID string
+	if err := db.QueryRow(ctx, `select coalesce(branch_id::text, '') from staffs where id = $1`,
+		trans.StaffID).Scan(&branchID); err != nil {
+		log.Println("Error while selecting staff branch", err)
+		return err
+	}
+
+	description := trans.Description
+	if description == "" {
+		description = trans.SourceType
+	}
+
+	_, err := postEntry(ctx, db, models.CreateJournalEntry{
+		Description: description,
+		SourceType:  "transaction",
+		SourceID:    transactionID,
+		Postings:    transactionPostings(trans, branchID),
+	})
+	return err
+}
+
+// transactionPostings moves the signed amount of the transaction between the account of its
+// source and the staff payables.
+func transactionPostings(trans models.CreateTransaction, branchID string) []models.CreatePosting {
+	delta := signedAmount(trans.TransactionType, trans.Amount)
+	return []models.CreatePosting{
+		{AccountCode: transactionAccount(trans.SourceType), BranchID: branchID, Debit: math.Max(delta, 0), Credit: math.Max(-delta, 0)},
+		{AccountCode: accountStaffPayables, StaffID: trans.StaffID, BranchID: branchID, Debit: math.Max(-delta, 0), Credit: math.Max(delta, 0)},
+	}
+}
+
+// transactionAccount is the account on the other side of the staff payables for the source.
+func transactionAccount(sourceType string) string {
+	switch sourceType {
+	case "salary":
+		return accountSalaries
+	case "fine":
+		return accountFineIncome
+	case "payroll", "withdrawal":
+		return accountCash
+	case "adjustment":
+		return accountOpening
+	}
+	return accountCommissions
+}
+
+// paymentAccount is where the money of a sale paid with the payment type goes.
+func paymentAccount(paymentType string) string {
+	if paymentType == "card" {
+		return accountCard
+	}
+	return accountCash
+}
+
+func entryPostings(ctx context.Context, db querier, entryID string) ([]models.Posting, error) {
+	postings := []models.Posting{}
+
+	rows, err := db.Query(ctx, `select p.id, a.id, a.code, a.name, coalesce(p.staff_id::text, ''), coalesce(p.branch_id::text, ''),
+		p.debit, p.credit from postings p join accounts a on a.id = p.account_id where p.entry_id = $1 order by p.debit desc, a.code`, entryID)
+	if err != nil {
+		log.Println("Error while selecting postings", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		posting := models.Posting{}
+		if err := rows.Scan(
+			&posting.ID,
+			&posting.AccountID,
+			&posting.AccountCode,
+			&posting.AccountName,
+			&posting.StaffID,
+			&posting.BranchID,
+			&posting.Debit,
+			&posting.Credit,
+		); err != nil {
+			log.Println("Error while scanning posting", err)
+			return nil, err
+		}
+		postings = append(postings, posting)
+	}
+
+	return postings, rows.Err()
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+const journalEntryColumns = `e.id, e.entry_date, e.description, e.source_type, coalesce(e.source_id::text, ''),
+	coalesce(e.reversal_of::text, ''), coalesce(e.reversed_by::text, ''), coalesce(e.created_by::text, ''), e.created_at`
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"sell/api/models"
+	"sell/storage"
+)
+
+func TestBalancedPostings(t *testing.T) {
+	tests := []struct {
+		name     string
+		postings []models.CreatePosting
+		wantErr  bool
+	}{
+		{"balanced", []models.CreatePosting{
+			{AccountCode: accountCash, Debit: 100},
+			{AccountCode: accountSales, Credit: 100},
+		}, false},
+		{"split credit", []models.CreatePosting{
+			{AccountCode: accountCash, Debit: 100.1},
+			{AccountCode: accountSales, Credit: 50.05},
+			{AccountCode: accountFineIncome, Credit: 50.05},
+		}, false},
+		{"rounded to cents", []models.CreatePosting{
+			{AccountCode: accountCash, Debit: 10.004},
+			{AccountCode: accountSales, Credit: 9.996},
+		}, false},
+		{"unbalanced", []models.CreatePosting{
+			{AccountCode: accountCash, Debit: 100},
+			{AccountCode: accountSales, Credit: 99.99},
+		}, true},
+		{"single posting", []models.CreatePosting{
+			{AccountCode: accountCash, Debit: 100},
+		}, true},
+		{"both sides", []models.CreatePosting{
+			{AccountCode: accountCash, Debit: 100, Credit: 100},
+			{AccountCode: accountSales, Debit: 100, Credit: 100},
+		}, true},
+		{"empty posting", []models.CreatePosting{
+			{AccountCode: accountCash},
+			{AccountCode: accountSales},
+		}, true},
+		{"negative", []models.CreatePosting{
+			{AccountCode: accountCash, Debit: -100},
+			{AccountCode: accountSales, Credit: -100},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		_, err := balancedPostings(tt.postings)
+		if tt.wantErr && !errors.Is(err, storage.ErrUnbalancedEntry) || !tt.wantErr && err != nil {
+			t.Errorf("%s: balancedPostings error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTransactionPostingsBalance(t *testing.T) {
+	for _, sourceType := range []string{"bonus", "sales", "fine", "salary", "payroll", "withdrawal", "adjustment"} {
+		for _, transactionType := range []string{"topup", "withdraw"} {
+			postings := transactionPostings(models.CreateTransaction{
+				StaffID:         "staff",
+				TransactionType: transactionType,
+				SourceType:      sourceType,
+				Amount:          123.45,
+			}, "branch")
+
+			if _, err := balancedPostings(postings); err != nil {
+				t.Errorf("%s %s: %v", sourceType, transactionType, err)
+				continue
+			}
+
+			payables := postings[1]
+			if payables.AccountCode != accountStaffPayables || payables.StaffID != "staff" {
+				t.Errorf("%s %s: second posting is %+v, want the staff payables", sourceType, transactionType, payables)
+			}
+			if transactionType == "topup" && payables.Credit != 123.45 || transactionType == "withdraw" && payables.Debit != 123.45 {
+				t.Errorf("%s %s: staff payables moved the wrong way, %+v", sourceType, transactionType, payables)
+			}
+
+			reversal := reversalPostings([]models.Posting{
+				{AccountCode: postings[0].AccountCode, Debit: postings[0].Debit, Credit: postings[0].Credit},
+				{AccountCode: payables.AccountCode, StaffID: payables.StaffID, Debit: payables.Debit, Credit: payables.Credit},
+			})
+			if _, err := balancedPostings(reversal); err != nil {
+				t.Errorf("%s %s reversal: %v", sourceType, transactionType, err)
+			}
+			if reversal[1].Debit != payables.Credit || reversal[1].Credit != payables.Debit {
+				t.Errorf("%s %s reversal did not swap sides, %+v", sourceType, transactionType, reversal[1])
+			}
+		}
+	}
+}
+
+func TestTransactionAccount(t *testing.T) {
+	tests := map[string]string{
+		"salary":     accountSalaries,
+		"fine":       accountFineIncome,
+		"payroll":    accountCash,
+		"withdrawal": accountCash,
+		"adjustment": accountOpening,
+		"bonus":      accountCommissions,
+		"sales":      accountCommissions,
+	}
+
+	for sourceType, want := range tests {
+		if got := transactionAccount(sourceType); got != want {
+			t.Errorf("transactionAccount(%q) = %q, want %q", sourceType, got, want)
+		}
+	}
+}
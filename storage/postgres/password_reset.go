@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"sell/api/models"
+	"sell/pkg/security"
+	"sell/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type passwordResetRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPasswordResetRepo(DB *pgxpool.Pool) storage.IPasswordResetRepo {
+	return &passwordResetRepo{
+		DB: DB,
+	}
+}
+
+// Create issues a reset token, drops the earlier unused ones, makes the staff change
+// the password before logging in again and signs the staff out everywhere.
+func (p *passwordResetRepo) Create(ctx context.Context, reset models.CreatePasswordReset) (string, error) {
+	id := uuid.New().String()
+
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `update password_resets set used_at = now() 
+		where staff_id = $1 and used_at is null`, reset.StaffID); err != nil {
+		log.Println("Error while dropping old password resets", err)
+		return "", err
+	}
+
+	if _, err := tx.Exec(ctx, `insert into password_resets (id, staff_id, created_by, token_hash, expires_at) 
+		values ($1, $2, $3, $4, $5)`,
+		id,
+		reset.StaffID,
+		reset.CreatedBy,
+		reset.TokenHash,
+		reset.ExpiresAt,
+	); err != nil {
+		log.Println("Error while inserting password reset", err)
+		return "", err
+	}
+
+	if _, err := tx.Exec(ctx, `update staffs set must_change_password = true, updated_at = now() 
+		where id = $1`, reset.StaffID); err != nil {
+		log.Println("Error while forcing password change", err)
+		return "", err
+	}
+
+	if _, err := tx.Exec(ctx, `update refresh_tokens set revoked_at = now() 
+		where staff_id = $1 and revoked_at is null`, reset.StaffID); err != nil {
+		log.Println("Error while revoking refresh tokens of staff", err)
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing password reset", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (p *passwordResetRepo) GetByHash(ctx context.Context, hash string) (models.PasswordReset, error) {
+	reset := models.PasswordReset{}
+
+	if err := p.DB.QueryRow(ctx, `select id, staff_id, created_by, expires_at, used_at, created_at 
+		from password_resets where token_hash = $1`, hash).Scan(
+		&reset.ID,
+		&reset.StaffID,
+		&reset.CreatedBy,
+		&reset.ExpiresAt,
+		&reset.UsedAt,
+		&reset.CreatedAt,
+	); err != nil {
+		return models.PasswordReset{}, err
+	}
+
+	return reset, nil
+}
+
+// Use marks the token as used and sets the new password of its staff in one transaction.
+// It returns pgx.ErrNoRows when the token was already used or has expired.
+func (p *passwordResetRepo) Use(ctx context.Context, id, newPassword string) error {
+	password, err := security.HashPassword(newPassword)
+	if err != nil {
+		log.Println("Error while hashing password", err)
+		return err
+	}
+
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var staffID string
+	if err := tx.QueryRow(ctx, `update password_resets set used_at = now() 
+		where id = $1 and used_at is null and expires_at > now() returning staff_id`, id).Scan(&staffID); err != nil {
+		log.Println("Error while using password reset", err)
+		return err
+	}
+
+	if err := setPassword(ctx, tx, staffID, password); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing password reset", err)
+		return err
+	}
+
+	return nil
+}
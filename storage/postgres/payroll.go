@@ -0,0 +1,357 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type payrollRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPayrollRepo(DB *pgxpool.Pool) storage.IPayrollRepo {
+	return &payrollRepo{
+		DB: DB,
+	}
+}
+
+// Create calculates a draft run for the month. Every staff of the branch, or of every branch
+// without one, who is not in a run of the month yet gets an item. The branch and the base salary
+// are the ones of the assignment at the end of the month. Commissions and bonuses are
+// the sales and bonus top ups of the month less their withdraws, fines are the fine withdraws
+// less their top ups. It returns pgx.ErrNoRows when nobody is left to pay.
+func (p *payrollRepo) Create(ctx context.Context, run models.CreatePayrollRun) (string, error) {
+	id := uuid.New().String()
+
+	month, err := time.Parse("2006-01", run.Month)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `insert into payroll_runs (id, month, branch_id, created_by) 
+		values ($1, $2, nullif($3, '')::uuid, $4)`,
+		id,
+		month,
+		run.BranchID,
+		run.CreatedBy,
+	); err != nil {
+		log.Println("Error while inserting payroll run", err)
+		return "", err
+	}
+
+	rows, err := tx.Query(ctx, `
+		select s.id, coalesce(t.base_salary, 0),
+		       coalesce(sum(case when tr.transaction_type = 'topup' then tr.amount else -tr.amount end) 
+		           filter (where tr.source_type = 'sales'), 0),
+		       coalesce(sum(case when tr.transaction_type = 'topup' then tr.amount else -tr.amount end) 
+		           filter (where tr.source_type = 'bonus'), 0),
+		       coalesce(sum(case when tr.transaction_type = 'withdraw' then tr.amount else -tr.amount end) 
+		           filter (where tr.source_type = 'fine'), 0)
+			from staffs s
+			left join lateral (select a.id, a.branch_id, a.tariff_id from staff_assignments a 
+				where a.staff_id = s.id and a.effective_from < $2 order by a.effective_from desc limit 1) a on true
+			left join staff_tariffs t on t.id = case when a.id is not null then a.tariff_id else s.tariff_id end
+			left join transactions tr on tr.staff_id = s.id and tr.deleted_at is null 
+				and tr.created_at >= $1 and tr.created_at < $2
+				where s.deleted_at is null and s.staff_type <> 'owner'
+				  and (a.id is not null or not exists (select 1 from staff_assignments h where h.staff_id = s.id))
+				  and ($3 = '' or case when a.id is not null then a.branch_id else s.branch_id end = nullif($3, '')::uuid)
+				  and not exists (select 1 from payroll_run_items i where i.staff_id = s.id and i.month = $1)
+			group by s.id, t.base_salary`, month, month.AddDate(0, 1, 0), run.BranchID)
+	if err != nil {
+		log.Println("Error while calculating payroll", err)
+		return "", err
+	}
+
+	items := []models.PayrollItem{}
+	for rows.Next() {
+		item := models.PayrollItem{}
+		if err := rows.Scan(&item.StaffID, &item.BaseSalary, &item.Commissions, &item.Bonuses, &item.Fines); err != nil {
+			rows.Close()
+			log.Println("Error while scanning payroll", err)
+			return "", err
+		}
+		item.Net = item.BaseSalary + item.Commissions + item.Bonuses - item.Fines
+		items = append(items, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Println("Error while calculating payroll", err)
+		return "", err
+	}
+
+	if len(items) == 0 {
+		return "", pgx.ErrNoRows
+	}
+
+	for _, item := range items {
+		if _, err := tx.Exec(ctx, `insert into payroll_run_items 
+			(id, payroll_run_id, staff_id, month, base_salary, commissions, bonuses, fines, net) 
+				values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			uuid.New().String(),
+			id,
+			item.StaffID,
+			month,
+			item.BaseSalary,
+			item.Commissions,
+			item.Bonuses,
+			item.Fines,
+			item.Net,
+		); err != nil {
+			log.Println("Error while inserting payroll item", err)
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing payroll run", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (p *payrollRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.PayrollRun, error) {
+	run := models.PayrollRun{}
+
+	if err := p.DB.QueryRow(ctx, `select `+payrollRunColumns+` from payroll_runs r where r.id = $1`, key.ID).Scan(
+		&run.ID,
+		&run.Month,
+		&run.BranchID,
+		&run.Status,
+		&run.CreatedBy,
+		&run.ApprovedBy,
+		&run.ApprovedAt,
+		&run.Total,
+		&run.CreatedAt,
+		&run.UpdatedAt,
+	); err != nil {
+		log.Println("Error while selecting payroll run", err)
+		return models.PayrollRun{}, err
+	}
+
+	rows, err := p.DB.Query(ctx, `select i.id, i.staff_id, coalesce(s.name, ''), i.base_salary, i.commissions, i.bonuses, 
+       i.fines, i.net, i.paid, coalesce(i.salary_transaction_id::text, ''), coalesce(i.payout_transaction_id::text, '')
+			from payroll_run_items i join staffs s on s.id = i.staff_id
+				where i.payroll_run_id = $1 order by s.name`, key.ID)
+	if err != nil {
+		log.Println("Error while selecting payroll items", err)
+		return models.PayrollRun{}, err
+	}
+	defer rows.Close()
+
+	run.Items = []models.PayrollItem{}
+	for rows.Next() {
+		item := models.PayrollItem{}
+		if err := rows.Scan(
+			&item.ID,
+			&item.StaffID,
+			&item.StaffName,
+			&item.BaseSalary,
+			&item.Commissions,
+			&item.Bonuses,
+			&item.Fines,
+			&item.Net,
+			&item.Paid,
+			&item.SalaryTransactionID,
+			&item.PayoutTransactionID,
+		); err != nil {
+			log.Println("Error while scanning payroll item", err)
+			return models.PayrollRun{}, err
+		}
+		run.Items = append(run.Items, item)
+	}
+
+	return run, rows.Err()
+}
+
+func (p *payrollRepo) GetList(ctx context.Context, request models.PayrollRunGetListRequest) (models.PayrollRunsResponse, error) {
+	var (
+		runs   = []models.PayrollRun{}
+		count  int
+		filter = ` where true`
+		args   = []interface{}{}
+	)
+
+	if request.Status != "" {
+		args = append(args, request.Status)
+		filter += fmt.Sprintf(` and r.status = $%d`, len(args))
+	}
+
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and r.branch_id = $%d`, len(args))
+	}
+
+	if err := p.DB.QueryRow(ctx, `select count(*) from payroll_runs r`+filter, args...).Scan(&count); err != nil {
+		log.Println("Error while counting payroll runs", err)
+		return models.PayrollRunsResponse{}, err
+	}
+
+	args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	rows, err := p.DB.Query(ctx, `select `+payrollRunColumns+` from payroll_runs r`+filter+
+		fmt.Sprintf(` order by r.month desc, r.created_at desc limit $%d offset $%d`, len(args)-1, len(args)), args...)
+	if err != nil {
+		log.Println("Error while selecting payroll runs", err)
+		return models.PayrollRunsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		run := models.PayrollRun{}
+		if err := rows.Scan(
+			&run.ID,
+			&run.Month,
+			&run.BranchID,
+			&run.Status,
+			&run.CreatedBy,
+			&run.ApprovedBy,
+			&run.ApprovedAt,
+			&run.Total,
+			&run.CreatedAt,
+			&run.UpdatedAt,
+		); err != nil {
+			log.Println("Error while scanning payroll run", err)
+			return models.PayrollRunsResponse{}, err
+		}
+		runs = append(runs, run)
+	}
+
+	return models.PayrollRunsResponse{
+		PayrollRuns: runs,
+		Count:       count,
+	}, rows.Err()
+}
+
+// Delete removes a draft run so the month can be calculated again.
+func (p *payrollRepo) Delete(ctx context.Context, id string) error {
+	result, err := p.DB.Exec(ctx, `delete from payroll_runs where id = $1 and status = 'draft'`, id)
+	if err != nil {
+		log.Println("Error while deleting payroll run", err)
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("only draft payroll runs can be deleted")
+	}
+
+	return nil
+}
+
+// Approve pays the run out. For every item the base salary is topped up as a salary
+// transaction and the net pay, or the balance when it is lower, is withdrawn as a payroll
+// transaction, all or nothing.
+func (p *payrollRepo) Approve(ctx context.Context, id, approvedBy string) error {
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		status string
+		month  time.Time
+	)
+	if err := tx.QueryRow(ctx, `select status, month from payroll_runs where id = $1 for update`, id).Scan(&status, &month); err != nil {
+		log.Println("Error while locking payroll run", err)
+		return err
+	}
+
+	if status != "draft" {
+		return errors.New("payroll run is already " + status)
+	}
+
+	rows, err := tx.Query(ctx, `select id, staff_id, base_salary, net from payroll_run_items where payroll_run_id = $1`, id)
+	if err != nil {
+		log.Println("Error while selecting payroll items", err)
+		return err
+	}
+
+	items := []models.PayrollItem{}
+	for rows.Next() {
+		item := models.PayrollItem{}
+		if err := rows.Scan(&item.ID, &item.StaffID, &item.BaseSalary, &item.Net); err != nil {
+			rows.Close()
+			log.Println("Error while scanning payroll item", err)
+			return err
+		}
+		items = append(items, item)
+	}
+	rows.Close()
+
+	period := month.Format("2006-01")
+	for _, item := range items {
+		if item.BaseSalary > 0 {
+			if item.SalaryTransactionID, err = insertTransaction(ctx, tx, models.CreateTransaction{
+				StaffID:         item.StaffID,
+				TransactionType: "topup",
+				SourceType:      "salary",
+				Amount:          item.BaseSalary,
+				Description:     "salary " + period,
+			}); err != nil {
+				return err
+			}
+		}
+
+		// fines larger than the earnings are not paid out, they stay on the balance. A staff who
+		// already cashed out part of it through withdrawal requests is paid what is left, Paid
+		// below Net flags the item.
+		if item.Net > 0 {
+			var balance float64
+			if err := tx.QueryRow(ctx, `select balance from staffs where id = $1 for update`, item.StaffID).Scan(&balance); err != nil {
+				log.Println("Error while selecting staff balance", err)
+				return err
+			}
+
+			item.Paid = min(item.Net, balance)
+		}
+
+		if item.Paid > 0 {
+			if item.PayoutTransactionID, err = insertTransaction(ctx, tx, models.CreateTransaction{
+				StaffID:         item.StaffID,
+				TransactionType: "withdraw",
+				SourceType:      "payroll",
+				Amount:          item.Paid,
+				Description:     "payroll " + period,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(ctx, `update payroll_run_items set salary_transaction_id = nullif($2, '')::uuid, 
+			payout_transaction_id = nullif($3, '')::uuid, paid = $4 where id = $1`,
+			item.ID, item.SalaryTransactionID, item.PayoutTransactionID, item.Paid); err != nil {
+			log.Println("Error while updating payroll item", err)
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `update payroll_runs set status = 'approved', approved_by = $2, approved_at = now(), 
+		updated_at = now() where id = $1`, id, approvedBy); err != nil {
+		log.Println("Error while approving payroll run", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+const payrollRunColumns = `r.id, to_char(r.month, 'YYYY-MM'), coalesce(r.branch_id::text, ''), r.status, r.created_by, 
+	coalesce(r.approved_by::text, ''), r.approved_at, 
+	(select coalesce(sum(net), 0) from payroll_run_items i where i.payroll_run_id = r.id), r.created_at, r.updated_at`
@@ -88,6 +88,18 @@ func (s *Store) Product() storage.IProducts {
 	return NewProductRepo(s.Pool)
 }
 
+func (s *Store) ProductUnit() storage.IProductUnitRepo {
+	return NewProductUnitRepo(s.Pool)
+}
+
+func (s *Store) ProductVariant() storage.IProductVariantRepo {
+	return NewProductVariantRepo(s.Pool)
+}
+
+func (s *Store) Bundle() storage.IBundleRepo {
+	return NewBundleRepo(s.Pool)
+}
+
 func (s *Store) Branch() storage.IBranchStorage {
 	return NewBranchRepo(s.Pool)
 }
@@ -105,6 +117,38 @@ func (s *Store) Staff() storage.IStaffRepo {
 	return NewStaffRepo(s.Pool)
 }
 
+func (s *Store) RefreshToken() storage.IRefreshTokenRepo {
+	return NewRefreshTokenRepo(s.Pool)
+}
+
+func (s *Store) PasswordReset() storage.IPasswordResetRepo {
+	return NewPasswordResetRepo(s.Pool)
+}
+
+func (s *Store) Attendance() storage.IAttendanceRepo {
+	return NewAttendanceRepo(s.Pool)
+}
+
+func (s *Store) Payroll() storage.IPayrollRepo {
+	return NewPayrollRepo(s.Pool)
+}
+
+func (s *Store) Ledger() storage.ILedgerRepo {
+	return NewLedgerRepo(s.Pool)
+}
+
+func (s *Store) Withdrawal() storage.IWithdrawalRepo {
+	return NewWithdrawalRepo(s.Pool)
+}
+
+func (s *Store) SalesTarget() storage.ISalesTargetRepo {
+	return NewSalesTargetRepo(s.Pool)
+}
+
+func (s *Store) Commission() storage.ICommissionRepo {
+	return NewCommissionRepo(s.Pool)
+}
+
 func (s *Store) Repository() storage.IRepositoryRepo {
 	return NewRepositoryRepo(s.Pool)
 }
@@ -116,3 +160,27 @@ func (s *Store) Basket() storage.IBasketRepo {
 func (s *Store) RTransaction() storage.IRepositoryTransactionRepo {
 	return NewRepositoryTransactionRepo(s.Pool)
 }
+
+func (s *Store) ProductBarcode() storage.IProductBarcodeRepo {
+	return NewProductBarcodeRepo(s.Pool)
+}
+
+func (s *Store) ProductImage() storage.IProductImageRepo {
+	return NewProductImageRepo(s.Pool)
+}
+
+func (s *Store) PriceList() storage.IPriceListRepo {
+	return NewPriceListRepo(s.Pool)
+}
+
+func (s *Store) PriceChange() storage.IPriceChangeRepo {
+	return NewPriceChangeRepo(s.Pool)
+}
+
+func (s *Store) Stock() storage.IStockRepo {
+	return NewStockRepo(s.Pool)
+}
+
+func (s *Store) WriteOff() storage.IWriteOffRepo {
+	return NewWriteOffRepo(s.Pool)
+}
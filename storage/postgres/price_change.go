@@ -0,0 +1,375 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sell/api/models"
+	"sell/pkg/pricing"
+	"sell/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type priceChangeRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPriceChangeRepo(DB *pgxpool.Pool) storage.IPriceChangeRepo {
+	return &priceChangeRepo{
+		DB: DB,
+	}
+}
+
+func (s *priceChangeRepo) Create(ctx context.Context, change models.CreatePriceChange) (string, error) {
+	id := uuid.New()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `INSERT INTO price_changes 
+    (id, name, change_type, value, rounding, rounding_step, category_id, effective_at) 
+        VALUES ($1, $2, $3, $4, $5, $6, nullif($7, ''), $8)`,
+		id,
+		change.Name,
+		change.ChangeType,
+		change.Value,
+		change.Rounding,
+		change.RoundingStep,
+		change.CategoryID,
+		change.EffectiveAt,
+	); err != nil {
+		log.Println("Error while inserting price change", err)
+		return "", err
+	}
+
+	for _, productID := range change.ProductIDs {
+		if _, err = tx.Exec(ctx, `INSERT INTO price_change_products (price_change_id, product_id) VALUES ($1, $2) 
+			ON CONFLICT DO NOTHING`, id, productID); err != nil {
+			log.Println("Error while inserting price change product", err)
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing price change", err)
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *priceChangeRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.PriceChange, error) {
+	change := models.PriceChange{}
+
+	if err := s.DB.QueryRow(ctx, `SELECT id, name, change_type, value, rounding, rounding_step, 
+		coalesce(category_id, ''), effective_at, status, applied_at, created_at, updated_at, 
+		array(select product_id::text from price_change_products where price_change_id = price_changes.id) 
+		FROM price_changes WHERE id = $1 AND deleted_at IS NULL`, key.ID).Scan(
+		&change.ID,
+		&change.Name,
+		&change.ChangeType,
+		&change.Value,
+		&change.Rounding,
+		&change.RoundingStep,
+		&change.CategoryID,
+		&change.EffectiveAt,
+		&change.Status,
+		&change.AppliedAt,
+		&change.CreatedAt,
+		&change.UpdatedAt,
+		&change.ProductIDs,
+	); err != nil {
+		log.Println("Error while selecting price change by id", err)
+		return models.PriceChange{}, err
+	}
+
+	return change, nil
+}
+
+// GetList lists price changes, Search filters by status.
+func (s *priceChangeRepo) GetList(ctx context.Context, request models.GetListRequest) (models.PriceChangesResponse, error) {
+	var (
+		changes = []models.PriceChange{}
+		count   = 0
+		offset  = (request.Page - 1) * request.Limit
+		filter  = ` WHERE deleted_at IS NULL AND ($1 = '' OR status::text = $1) `
+	)
+
+	if err := s.DB.QueryRow(ctx, `SELECT count(1) FROM price_changes `+filter, request.Search).Scan(&count); err != nil {
+		log.Println("Error while selecting price change count", err)
+		return models.PriceChangesResponse{}, err
+	}
+
+	rows, err := s.DB.Query(ctx, `SELECT id, name, change_type, value, rounding, rounding_step, 
+		coalesce(category_id, ''), effective_at, status, applied_at, created_at, updated_at, 
+		array(select product_id::text from price_change_products where price_change_id = price_changes.id) 
+		FROM price_changes `+filter+` ORDER BY effective_at DESC LIMIT $2 OFFSET $3`,
+		request.Search, request.Limit, offset)
+	if err != nil {
+		log.Println("Error while selecting price changes", err)
+		return models.PriceChangesResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		change := models.PriceChange{}
+		if err = rows.Scan(
+			&change.ID,
+			&change.Name,
+			&change.ChangeType,
+			&change.Value,
+			&change.Rounding,
+			&change.RoundingStep,
+			&change.CategoryID,
+			&change.EffectiveAt,
+			&change.Status,
+			&change.AppliedAt,
+			&change.CreatedAt,
+			&change.UpdatedAt,
+			&change.ProductIDs,
+		); err != nil {
+			log.Println("Error while scanning price change", err)
+			return models.PriceChangesResponse{}, err
+		}
+		changes = append(changes, change)
+	}
+
+	return models.PriceChangesResponse{
+		PriceChanges: changes,
+		Count:        count,
+	}, nil
+}
+
+// Cancel stops a scheduled price change, applied ones can not be cancelled.
+func (s *priceChangeRepo) Cancel(ctx context.Context, key models.PrimaryKey) error {
+	result, err := s.DB.Exec(ctx, `UPDATE price_changes SET status = 'cancelled', updated_at = now() 
+		WHERE id = $1 AND status = 'scheduled' AND deleted_at IS NULL`, key.ID)
+	if err != nil {
+		log.Println("Error while cancelling price change", err)
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("only scheduled price changes can be cancelled")
+	}
+
+	return nil
+}
+
+// Preview returns the current and resulting prices of the products the change targets.
+func (s *priceChangeRepo) Preview(ctx context.Context, change models.CreatePriceChange) (models.PriceChangePreview, error) {
+	items, err := priceChangeTargets(ctx, s.DB, change)
+	if err != nil {
+		return models.PriceChangePreview{}, err
+	}
+
+	return models.PriceChangePreview{
+		Items: items,
+		Count: len(items),
+	}, nil
+}
+
+// Due returns the ids of scheduled price changes whose effective time has come, oldest first.
+func (s *priceChangeRepo) Due(ctx context.Context, now time.Time) ([]string, error) {
+	ids := []string{}
+
+	rows, err := s.DB.Query(ctx, `SELECT id FROM price_changes 
+		WHERE status = 'scheduled' AND effective_at <= $1 AND deleted_at IS NULL ORDER BY effective_at`, now)
+	if err != nil {
+		log.Println("Error while selecting due price changes", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			log.Println("Error while scanning due price change", err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Apply sets the new prices of a scheduled change, writes them to the product
+// price history and marks the change applied. It returns the repriced products.
+func (s *priceChangeRepo) Apply(ctx context.Context, key models.PrimaryKey) (int, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	change := models.CreatePriceChange{}
+	if err = tx.QueryRow(ctx, `SELECT change_type, value, rounding, rounding_step, coalesce(category_id, ''), 
+		array(select product_id::text from price_change_products where price_change_id = price_changes.id) 
+		FROM price_changes WHERE id = $1 AND status = 'scheduled' AND deleted_at IS NULL FOR UPDATE`, key.ID).Scan(
+		&change.ChangeType,
+		&change.Value,
+		&change.Rounding,
+		&change.RoundingStep,
+		&change.CategoryID,
+		&change.ProductIDs,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, errors.New("price change is not scheduled")
+		}
+		log.Println("Error while selecting price change", err)
+		return 0, err
+	}
+
+	items, err := priceChangeTargets(ctx, tx, change)
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, item := range items {
+		if item.NewPrice == item.OldPrice {
+			continue
+		}
+
+		if _, err = tx.Exec(ctx, `UPDATE products SET price = $1, updated_at = now() WHERE id = $2`,
+			item.NewPrice, item.ProductID); err != nil {
+			log.Println("Error while updating product price", err)
+			return 0, err
+		}
+
+		if err = insertProductPriceHistory(ctx, tx, item.ProductID, item.OldPrice, item.NewPrice, key.ID); err != nil {
+			return 0, err
+		}
+		applied++
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE price_changes SET status = 'applied', applied_at = now(), updated_at = now() 
+		WHERE id = $1`, key.ID); err != nil {
+		log.Println("Error while marking price change applied", err)
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing price change", err)
+		return 0, err
+	}
+
+	return applied, nil
+}
+
+// History lists the price changes of a product, Search is the product id.
+func (s *priceChangeRepo) History(ctx context.Context, request models.GetListRequest) (models.ProductPriceHistoryResponse, error) {
+	var (
+		history = []models.ProductPriceHistory{}
+		count   = 0
+		offset  = (request.Page - 1) * request.Limit
+	)
+
+	if err := s.DB.QueryRow(ctx, `SELECT count(1) FROM product_price_history WHERE product_id = $1`,
+		request.Search).Scan(&count); err != nil {
+		log.Println("Error while selecting product price history count", err)
+		return models.ProductPriceHistoryResponse{}, err
+	}
+
+	rows, err := s.DB.Query(ctx, `SELECT id, product_id, old_price, new_price, coalesce(price_change_id::text, ''), created_at 
+		FROM product_price_history WHERE product_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`,
+		request.Search, request.Limit, offset)
+	if err != nil {
+		log.Println("Error while selecting product price history", err)
+		return models.ProductPriceHistoryResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		change := models.ProductPriceHistory{}
+		if err = rows.Scan(
+			&change.ID,
+			&change.ProductID,
+			&change.OldPrice,
+			&change.NewPrice,
+			&change.PriceChangeID,
+			&change.CreatedAt,
+		); err != nil {
+			log.Println("Error while scanning product price history", err)
+			return models.ProductPriceHistoryResponse{}, err
+		}
+		history = append(history, change)
+	}
+
+	return models.ProductPriceHistoryResponse{
+		History: history,
+		Count:   count,
+	}, nil
+}
+
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// priceChangeTargets selects the products of the change with their current
+// and new price. Inside a transaction the products are locked.
+func priceChangeTargets(ctx context.Context, db querier, change models.CreatePriceChange) ([]models.PriceChangePreviewItem, error) {
+	var (
+		items = []models.PriceChangePreviewItem{}
+		query string
+		arg   interface{}
+	)
+
+	if change.CategoryID != "" {
+		query = `WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = $1 AND deleted_at IS NULL
+				UNION ALL
+				SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id WHERE c.deleted_at IS NULL
+			) SELECT id, name, price FROM products 
+			WHERE category_id IN (SELECT id FROM subtree) AND deleted_at IS NULL ORDER BY name`
+		arg = change.CategoryID
+	} else {
+		query = `SELECT id, name, price FROM products WHERE id = any($1::uuid[]) AND deleted_at IS NULL ORDER BY name`
+		arg = change.ProductIDs
+	}
+
+	if _, ok := db.(pgx.Tx); ok {
+		query += ` FOR UPDATE`
+	}
+
+	rows, err := db.Query(ctx, query, arg)
+	if err != nil {
+		log.Println("Error while selecting price change products", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.PriceChangePreviewItem{}
+		if err = rows.Scan(&item.ProductID, &item.Name, &item.OldPrice); err != nil {
+			log.Println("Error while scanning price change product", err)
+			return nil, err
+		}
+		item.NewPrice = pricing.Change(item.OldPrice, change.ChangeType, change.Value, change.Rounding, change.RoundingStep)
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func insertProductPriceHistory(ctx context.Context, tx pgx.Tx, productID string, oldPrice, newPrice int, priceChangeID string) error {
+	if _, err := tx.Exec(ctx, `INSERT INTO product_price_history (id, product_id, old_price, new_price, price_change_id) 
+		VALUES ($1, $2, $3, $4, nullif($5, '')::uuid)`, uuid.New(), productID, oldPrice, newPrice, priceChangeID); err != nil {
+		log.Println("Error while inserting product price history", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type priceListRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewPriceListRepo(DB *pgxpool.Pool) storage.IPriceListRepo {
+	return &priceListRepo{
+		DB: DB,
+	}
+}
+
+func (s *priceListRepo) Create(ctx context.Context, priceList models.CreatePriceList) (string, error) {
+	id := uuid.New()
+
+	if _, err := s.DB.Exec(ctx, `INSERT INTO price_lists (id, name) VALUES ($1, $2)`, id, priceList.Name); err != nil {
+		log.Println("Error while inserting price list", err)
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *priceListRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.PriceList, error) {
+	priceList := models.PriceList{}
+
+	if err := s.DB.QueryRow(ctx, `SELECT id, name, created_at, updated_at 
+		FROM price_lists WHERE id = $1 AND deleted_at IS NULL`, key.ID).Scan(
+		&priceList.ID,
+		&priceList.Name,
+		&priceList.CreatedAt,
+		&priceList.UpdatedAt,
+	); err != nil {
+		log.Println("Error while selecting price list by id", err)
+		return models.PriceList{}, err
+	}
+
+	rows, err := s.DB.Query(ctx, `SELECT id, price_list_id, product_id, price, updated_at 
+		FROM price_list_items WHERE price_list_id = $1 ORDER BY created_at`, priceList.ID)
+	if err != nil {
+		log.Println("Error while selecting price list items", err)
+		return models.PriceList{}, err
+	}
+	defer rows.Close()
+
+	priceList.Items = []models.PriceListItem{}
+	for rows.Next() {
+		item := models.PriceListItem{}
+		if err = rows.Scan(&item.ID, &item.PriceListID, &item.ProductID, &item.Price, &item.UpdatedAt); err != nil {
+			log.Println("Error while scanning price list item", err)
+			return models.PriceList{}, err
+		}
+		priceList.Items = append(priceList.Items, item)
+	}
+
+	return priceList, nil
+}
+
+func (s *priceListRepo) GetList(ctx context.Context, request models.GetListRequest) (models.PriceListsResponse, error) {
+	var (
+		priceLists = []models.PriceList{}
+		count      = 0
+		offset     = (request.Page - 1) * request.Limit
+		filter     = ` WHERE deleted_at IS NULL `
+		args       = []interface{}{}
+	)
+
+	if request.Search != "" {
+		args = append(args, request.Search)
+		filter += fmt.Sprintf(` AND name ILIKE '%%' || $%d || '%%' `, len(args))
+	}
+
+	if err := s.DB.QueryRow(ctx, `SELECT count(1) FROM price_lists `+filter, args...).Scan(&count); err != nil {
+		log.Println("Error while selecting price list count", err)
+		return models.PriceListsResponse{}, err
+	}
+
+	args = append(args, request.Limit, offset)
+	rows, err := s.DB.Query(ctx, `SELECT id, name, created_at, updated_at FROM price_lists `+filter+
+		fmt.Sprintf(` ORDER BY created_at DESC LIMIT $%d OFFSET $%d`, len(args)-1, len(args)), args...)
+	if err != nil {
+		log.Println("Error while selecting price lists", err)
+		return models.PriceListsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		priceList := models.PriceList{}
+		if err = rows.Scan(&priceList.ID, &priceList.Name, &priceList.CreatedAt, &priceList.UpdatedAt); err != nil {
+			log.Println("Error while scanning price list", err)
+			return models.PriceListsResponse{}, err
+		}
+		priceLists = append(priceLists, priceList)
+	}
+
+	return models.PriceListsResponse{
+		PriceLists: priceLists,
+		Count:      count,
+	}, nil
+}
+
+func (s *priceListRepo) Update(ctx context.Context, priceList models.UpdatePriceList) (string, error) {
+	if _, err := s.DB.Exec(ctx, `UPDATE price_lists SET name = $1, updated_at = now() WHERE id = $2`,
+		priceList.Name, priceList.ID); err != nil {
+		log.Println("Error while updating price list", err)
+		return "", err
+	}
+
+	return priceList.ID, nil
+}
+
+// Delete removes the price list and unassigns it from its branches.
+func (s *priceListRepo) Delete(ctx context.Context, key models.PrimaryKey) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `UPDATE branches SET price_list_id = NULL, updated_at = now() WHERE price_list_id = $1`, key.ID); err != nil {
+		log.Println("Error while unassigning price list", err)
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE price_lists SET deleted_at = now() WHERE id = $1`, key.ID); err != nil {
+		log.Println("Error while deleting price list", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SetItem creates or changes the price of the product in the list and writes it to the history.
+func (s *priceListRepo) SetItem(ctx context.Context, item models.SetPriceListItem) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var oldPrice int
+	err = tx.QueryRow(ctx, `SELECT price FROM price_list_items WHERE price_list_id = $1 AND product_id = $2 FOR UPDATE`,
+		item.PriceListID, item.ProductID).Scan(&oldPrice)
+	switch err {
+	case nil:
+		if oldPrice == item.Price {
+			return nil
+		}
+
+		if _, err = tx.Exec(ctx, `UPDATE price_list_items SET price = $1, updated_at = now() 
+			WHERE price_list_id = $2 AND product_id = $3`, item.Price, item.PriceListID, item.ProductID); err != nil {
+			log.Println("Error while updating price list item", err)
+			return err
+		}
+
+		if err = insertPriceListHistory(ctx, tx, item.PriceListID, item.ProductID, "updated", &oldPrice, &item.Price); err != nil {
+			return err
+		}
+	case pgx.ErrNoRows:
+		if _, err = tx.Exec(ctx, `INSERT INTO price_list_items (id, price_list_id, product_id, price) VALUES ($1, $2, $3, $4)`,
+			uuid.New(), item.PriceListID, item.ProductID, item.Price); err != nil {
+			log.Println("Error while inserting price list item", err)
+			return err
+		}
+
+		if err = insertPriceListHistory(ctx, tx, item.PriceListID, item.ProductID, "created", nil, &item.Price); err != nil {
+			return err
+		}
+	default:
+		log.Println("Error while selecting price list item", err)
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE price_lists SET updated_at = now() WHERE id = $1`, item.PriceListID); err != nil {
+		log.Println("Error while updating price list", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RemoveItem drops the product from the list, so it falls back to the base price.
+func (s *priceListRepo) RemoveItem(ctx context.Context, priceListID, productID string) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var oldPrice int
+	if err = tx.QueryRow(ctx, `DELETE FROM price_list_items WHERE price_list_id = $1 AND product_id = $2 RETURNING price`,
+		priceListID, productID).Scan(&oldPrice); err != nil {
+		log.Println("Error while deleting price list item", err)
+		return err
+	}
+
+	if err = insertPriceListHistory(ctx, tx, priceListID, productID, "removed", &oldPrice, nil); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE price_lists SET updated_at = now() WHERE id = $1`, priceListID); err != nil {
+		log.Println("Error while updating price list", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *priceListRepo) History(ctx context.Context, request models.PriceListHistoryRequest) (models.PriceListHistoryResponse, error) {
+	var (
+		history = []models.PriceListHistory{}
+		count   = 0
+		offset  = (request.Page - 1) * request.Limit
+		filter  = ` WHERE price_list_id = $1 AND ($2 = '' OR product_id::text = $2) `
+	)
+
+	if err := s.DB.QueryRow(ctx, `SELECT count(1) FROM price_list_history `+filter,
+		request.PriceListID, request.ProductID).Scan(&count); err != nil {
+		log.Println("Error while selecting price list history count", err)
+		return models.PriceListHistoryResponse{}, err
+	}
+
+	rows, err := s.DB.Query(ctx, `SELECT id, price_list_id, product_id, action, old_price, new_price, created_at 
+		FROM price_list_history `+filter+` ORDER BY created_at DESC LIMIT $3 OFFSET $4`,
+		request.PriceListID, request.ProductID, request.Limit, offset)
+	if err != nil {
+		log.Println("Error while selecting price list history", err)
+		return models.PriceListHistoryResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		change := models.PriceListHistory{}
+		if err = rows.Scan(
+			&change.ID,
+			&change.PriceListID,
+			&change.ProductID,
+			&change.Action,
+			&change.OldPrice,
+			&change.NewPrice,
+			&change.CreatedAt,
+		); err != nil {
+			log.Println("Error while scanning price list history", err)
+			return models.PriceListHistoryResponse{}, err
+		}
+		history = append(history, change)
+	}
+
+	return models.PriceListHistoryResponse{
+		History: history,
+		Count:   count,
+	}, nil
+}
+
+// Assign sets the price list of the branch, an empty id falls back to base prices.
+func (s *priceListRepo) Assign(ctx context.Context, request models.AssignPriceList) error {
+	if _, err := s.DB.Exec(ctx, `UPDATE branches SET price_list_id = nullif($1, '')::uuid, updated_at = now() WHERE id = $2`,
+		request.PriceListID, request.BranchID); err != nil {
+		log.Println("Error while assigning price list", err)
+		return err
+	}
+
+	return nil
+}
+
+// EffectivePrices returns the price of the products in the branch: the price
+// list item when the branch price list has one, otherwise the base price.
+func (s *priceListRepo) EffectivePrices(ctx context.Context, branchID string, productIDs []string) (map[string]int, error) {
+	prices := make(map[string]int, len(productIDs))
+
+	rows, err := s.DB.Query(ctx, `SELECT p.id, coalesce(i.price, p.price, 0) FROM products p 
+		LEFT JOIN branches b ON b.id = $1 
+		LEFT JOIN price_lists pl ON pl.id = b.price_list_id AND pl.deleted_at IS NULL 
+		LEFT JOIN price_list_items i ON i.price_list_id = pl.id AND i.product_id = p.id 
+		WHERE p.id = any($2::uuid[])`, branchID, productIDs)
+	if err != nil {
+		log.Println("Error while selecting effective prices", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id    string
+			price int
+		)
+		if err = rows.Scan(&id, &price); err != nil {
+			log.Println("Error while scanning effective price", err)
+			return nil, err
+		}
+		prices[id] = price
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("Error while iterating effective prices", err)
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func insertPriceListHistory(ctx context.Context, tx pgx.Tx, priceListID, productID, action string, oldPrice, newPrice *int) error {
+	if _, err := tx.Exec(ctx, `INSERT INTO price_list_history (id, price_list_id, product_id, action, old_price, new_price) 
+		VALUES ($1, $2, $3, $4, $5, $6)`, uuid.New(), priceListID, productID, action, oldPrice, newPrice); err != nil {
+		log.Println("Error while inserting price list history", err)
+		return err
+	}
+
+	return nil
+}
@@ -7,9 +7,26 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"sell/api/models"
 	"sell/storage"
-	"strconv"
 )
 
+// categorySubtreeFilter keeps the products of the category and all of its descendants,
+// formatted with the number of the category id argument.
+const categorySubtreeFilter = ` and category_id in (
+		with recursive subtree as (
+			select id from categories where id = $%d and deleted_at is null
+			union all
+			select c.id from categories c join subtree s on c.parent_id = s.id where c.deleted_at is null
+		) select id from subtree) `
+
+// primaryBarcode selects the primary code of a product, formatted with the
+// product id column.
+const primaryBarcode = `coalesce((select b.code from product_barcodes b 
+			where b.product_id = %s and b.variant_id is null order by b.is_primary desc, b.created_at limit 1), '')`
+
+// barcodeFilter keeps the products that have the code, on the product or on a variant,
+// formatted with the number of the code argument.
+const barcodeFilter = ` and id in (select product_id from product_barcodes where code = $%d) `
+
 type productRepo struct {
 	db *pgxpool.Pool
 }
@@ -20,18 +37,38 @@ func NewProductRepo(db *pgxpool.Pool) storage.IProducts {
 
 func (p productRepo) Create(ctx context.Context, product models.CreateProduct) (string, error) {
 	id := uuid.New()
-	query := `insert into products (id, name, price, barcode, category_id) values($1, $2, $3, $4, $5)`
-	if _, err := p.db.Exec(ctx, query,
-		id, product.Name, product.Price, product.Barcode, product.CategoryID); err != nil {
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `insert into products (id, name, price, category_id, unit, plu) values($1, $2, $3, $4, $5, nullif($6, ''))`
+	if _, err = tx.Exec(ctx, query,
+		id, product.Name, product.Price, product.CategoryID, product.Unit, product.PLU); err != nil {
 		fmt.Println("error is while inserting data", err.Error())
 		return "", err
 	}
+
+	if product.Barcode != "" {
+		if err = setPrimaryBarcode(ctx, tx, id.String(), "", product.Barcode); err != nil {
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing", err.Error())
+		return "", err
+	}
+
 	return id.String(), nil
 }
 
 func (p productRepo) GetByID(ctx context.Context, id string) (models.Product, error) {
 	product := models.Product{}
-	query := `select id, name, price, barcode, category_id, created_at, updated_at 
+	query := `select id, name, price, ` + fmt.Sprintf(primaryBarcode, "products.id") + `, category_id, unit, coalesce(plu, ''), created_at, updated_at 
 							from products where id = $1 and deleted_at is null`
 	if err := p.db.QueryRow(ctx, query, id).Scan(
 		&product.ID,
@@ -39,52 +76,85 @@ func (p productRepo) GetByID(ctx context.Context, id string) (models.Product, er
 		&product.Price,
 		&product.Barcode,
 		&product.CategoryID,
+		&product.Unit,
+		&product.PLU,
 		&product.CreatedAt,
 		&product.UpdatedAt); err != nil {
 		fmt.Println("error is while scanning", err.Error())
 		return models.Product{}, err
 	}
+
+	variants, err := p.getVariants(ctx, []string{product.ID})
+	if err != nil {
+		return models.Product{}, err
+	}
+	product.Variants = variants[product.ID]
+
+	images, err := getProductImages(ctx, p.db, []string{product.ID})
+	if err != nil {
+		return models.Product{}, err
+	}
+	product.Images = images[product.ID]
+
+	barcodes, err := getProductBarcodes(ctx, p.db, []string{product.ID})
+	if err != nil {
+		return models.Product{}, err
+	}
+	product.Barcodes = barcodes[product.ID]
+
 	return product, nil
 }
 
+// GetByPLU finds the product of a scale label plu, leading zeros do not matter.
+func (p productRepo) GetByPLU(ctx context.Context, plu string) (models.Product, error) {
+	var id string
+	query := `select id from products where ltrim(plu, '0') = ltrim($1, '0') and deleted_at is null`
+	if err := p.db.QueryRow(ctx, query, plu).Scan(&id); err != nil {
+		fmt.Println("error is while selecting by plu", err.Error())
+		return models.Product{}, err
+	}
+
+	return p.GetByID(ctx, id)
+}
+
 func (p productRepo) GetList(ctx context.Context, request models.ProductGetListRequest) (models.ProductResponse, error) {
 	var (
-		page              = request.Page
-		offset            = (page - 1) * request.Limit
-		query, countQuery string
-		count             = 0
-		products          = []models.Product{}
-		name              = request.Name
-		barcode           = request.Barcode
+		page     = request.Page
+		offset   = (page - 1) * request.Limit
+		count    = 0
+		products = []models.Product{}
+		name     = request.Name
+		barcode  = request.Barcode
+		filter   string
+		args     = []interface{}{}
 	)
-	countQuery = `select count(1) from products where deleted_at is null `
 
-	if name != "" && barcode != 0 {
-		countQuery += fmt.Sprintf(` and name ilike '%s' && barcode = %s`, name, strconv.Itoa(barcode))
-	} else if name != "" {
-		countQuery += fmt.Sprintf(` and name ilike '%s' `, name)
-	} else if barcode != 0 {
-		countQuery += ` and barcode = ` + strconv.Itoa(barcode)
+	if name != "" {
+		args = append(args, name)
+		filter += fmt.Sprintf(` and name ilike '%%' || $%d || '%%' `, len(args))
 	}
 
-	if err := p.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		fmt.Println("error is while scanning count ....", err.Error())
-		return models.ProductResponse{}, err
+	if barcode != "" {
+		args = append(args, barcode)
+		filter += fmt.Sprintf(barcodeFilter, len(args))
 	}
 
-	query = `select  id, name, price, barcode, category_id, created_at, updated_at 
-							from products where deleted_at is null `
+	if request.CategoryID != "" {
+		args = append(args, request.CategoryID)
+		filter += fmt.Sprintf(categorySubtreeFilter, len(args))
+	}
 
-	if name != "" && barcode != 0 {
-		query += fmt.Sprintf(` and name ilike '%s' && barcode = %s`, name, strconv.Itoa(barcode))
-	} else if name != "" {
-		query += fmt.Sprintf(` and name ilike '%s' `, name)
-	} else if barcode != 0 {
-		query += ` and barcode = ` + strconv.Itoa(barcode)
+	countQuery := `select count(1) from products where deleted_at is null ` + filter
+	if err := p.db.QueryRow(ctx, countQuery, args...).Scan(&count); err != nil {
+		fmt.Println("error is while scanning count ....", err.Error())
+		return models.ProductResponse{}, err
 	}
 
-	query += ` order by created_at desc LIMIT $1 OFFSET $2 `
-	rows, err := p.db.Query(ctx, query, request.Limit, offset)
+	args = append(args, request.Limit, offset)
+	query := `select  id, name, price, ` + fmt.Sprintf(primaryBarcode, "products.id") + `, category_id, unit, coalesce(plu, ''), created_at, updated_at 
+							from products where deleted_at is null ` + filter +
+		fmt.Sprintf(` order by created_at desc LIMIT $%d OFFSET $%d `, len(args)-1, len(args))
+	rows, err := p.db.Query(ctx, query, args...)
 	if err != nil {
 		fmt.Println("error is while selecting all", err.Error())
 		return models.ProductResponse{}, err
@@ -98,6 +168,8 @@ func (p productRepo) GetList(ctx context.Context, request models.ProductGetListR
 			&product.Price,
 			&product.Barcode,
 			&product.CategoryID,
+			&product.Unit,
+			&product.PLU,
 			&product.CreatedAt,
 			&product.UpdatedAt); err != nil {
 			fmt.Println("error is while scanning category", err.Error())
@@ -105,6 +177,33 @@ func (p productRepo) GetList(ctx context.Context, request models.ProductGetListR
 		}
 		products = append(products, product)
 	}
+
+	productIDs := make([]string, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+	}
+
+	variants, err := p.getVariants(ctx, productIDs)
+	if err != nil {
+		return models.ProductResponse{}, err
+	}
+
+	images, err := getProductImages(ctx, p.db, productIDs)
+	if err != nil {
+		return models.ProductResponse{}, err
+	}
+
+	barcodes, err := getProductBarcodes(ctx, p.db, productIDs)
+	if err != nil {
+		return models.ProductResponse{}, err
+	}
+
+	for i := range products {
+		products[i].Variants = variants[products[i].ID]
+		products[i].Images = images[products[i].ID]
+		products[i].Barcodes = barcodes[products[i].ID]
+	}
+
 	return models.ProductResponse{
 		Products: products,
 		Count:    count,
@@ -113,16 +212,43 @@ func (p productRepo) GetList(ctx context.Context, request models.ProductGetListR
 }
 
 func (p productRepo) Update(ctx context.Context, product models.UpdateProduct) (string, error) {
-	query := `update products set name = $1, price = $2, category_id = $3, updated_at = now() 
-									where id = $4`
-	if _, err := p.db.Exec(ctx, query,
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var oldPrice int
+	if err = tx.QueryRow(ctx, `select price from products where id = $1 for update`, product.ID).Scan(&oldPrice); err != nil {
+		fmt.Println("error is while selecting price", err.Error())
+		return "", err
+	}
+
+	query := `update products set name = $1, price = $2, category_id = $3, unit = $4, plu = nullif($5, ''), updated_at = now() 
+									where id = $6`
+	if _, err = tx.Exec(ctx, query,
 		&product.Name,
 		&product.Price,
 		&product.CategoryID,
+		&product.Unit,
+		&product.PLU,
 		&product.ID); err != nil {
 		fmt.Println("error is while updating", err.Error())
 		return "", err
 	}
+
+	if oldPrice != product.Price {
+		if err = insertProductPriceHistory(ctx, tx, product.ID, oldPrice, product.Price, ""); err != nil {
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing", err.Error())
+		return "", err
+	}
+
 	return product.ID, nil
 }
 
@@ -134,3 +260,41 @@ func (p productRepo) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// getVariants groups the variants of the given products by product id.
+// Every requested product gets a non-nil slice so it is listed as [] in json.
+func (p productRepo) getVariants(ctx context.Context, productIDs []string) (map[string][]models.ProductVariant, error) {
+	variants := make(map[string][]models.ProductVariant, len(productIDs))
+	for _, id := range productIDs {
+		variants[id] = []models.ProductVariant{}
+	}
+
+	query := `select id, product_id, name, attributes, ` + fmt.Sprintf(variantBarcode, "product_variants.id") + `, coalesce(price, 0), created_at, updated_at 
+							from product_variants where product_id = any($1::uuid[]) and deleted_at is null 
+							order by created_at`
+	rows, err := p.db.Query(ctx, query, productIDs)
+	if err != nil {
+		fmt.Println("error is while selecting variants", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		variant := models.ProductVariant{}
+		if err = rows.Scan(
+			&variant.ID,
+			&variant.ProductID,
+			&variant.Name,
+			&variant.Attributes,
+			&variant.Barcode,
+			&variant.Price,
+			&variant.CreatedAt,
+			&variant.UpdatedAt); err != nil {
+			fmt.Println("error is while scanning variant", err.Error())
+			return nil, err
+		}
+		variants[variant.ProductID] = append(variants[variant.ProductID], variant)
+	}
+
+	return variants, nil
+}
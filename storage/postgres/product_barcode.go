@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"sell/api/models"
+	"sell/pkg/barcode"
+	"sell/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// variantBarcode selects the primary code of a variant, formatted with the
+// variant id column.
+const variantBarcode = `coalesce((select b.code from product_barcodes b 
+			where b.variant_id = %s order by b.is_primary desc, b.created_at limit 1), '')`
+
+type productBarcodeRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewProductBarcodeRepo(DB *pgxpool.Pool) storage.IProductBarcodeRepo {
+	return &productBarcodeRepo{
+		DB: DB,
+	}
+}
+
+// Create adds a code to the product. A primary code replaces the primary flag
+// of the other codes of the same product or variant.
+func (s *productBarcodeRepo) Create(ctx context.Context, code models.CreateProductBarcode) (string, error) {
+	id := uuid.New()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if code.IsPrimary {
+		if _, err = tx.Exec(ctx, `UPDATE product_barcodes SET is_primary = false 
+			WHERE product_id = $1 AND coalesce(variant_id::text, '') = $2`, code.ProductID, code.VariantID); err != nil {
+			log.Println("Error while updating primary barcode", err)
+			return "", err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `INSERT INTO product_barcodes (id, product_id, variant_id, code, kind, is_primary) 
+		VALUES ($1, $2, nullif($3, '')::uuid, $4, $5, $6)`,
+		id,
+		code.ProductID,
+		code.VariantID,
+		code.Code,
+		code.Kind,
+		code.IsPrimary,
+	); err != nil {
+		log.Println("Error while inserting barcode", err)
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing barcode", err)
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *productBarcodeRepo) GetByID(ctx context.Context, id string) (models.ProductBarcode, error) {
+	return s.getBy(ctx, `id = $1`, id)
+}
+
+// GetByCode finds the code exactly as scanned.
+func (s *productBarcodeRepo) GetByCode(ctx context.Context, code string) (models.ProductBarcode, error) {
+	return s.getBy(ctx, `code = $1`, code)
+}
+
+func (s *productBarcodeRepo) GetList(ctx context.Context, productID string) ([]models.ProductBarcode, error) {
+	barcodes, err := getProductBarcodes(ctx, s.DB, []string{productID})
+	if err != nil {
+		return nil, err
+	}
+
+	return barcodes[productID], nil
+}
+
+func (s *productBarcodeRepo) Delete(ctx context.Context, id string) error {
+	if _, err := s.DB.Exec(ctx, `DELETE FROM product_barcodes WHERE id = $1`, id); err != nil {
+		log.Println("Error while deleting barcode", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *productBarcodeRepo) getBy(ctx context.Context, condition string, value string) (models.ProductBarcode, error) {
+	code := models.ProductBarcode{}
+
+	if err := s.DB.QueryRow(ctx, `SELECT id, product_id, coalesce(variant_id::text, ''), code, kind, is_primary, created_at 
+		FROM product_barcodes WHERE `+condition, value).Scan(
+		&code.ID,
+		&code.ProductID,
+		&code.VariantID,
+		&code.Code,
+		&code.Kind,
+		&code.IsPrimary,
+		&code.CreatedAt,
+	); err != nil {
+		log.Println("Error while selecting barcode", err)
+		return models.ProductBarcode{}, err
+	}
+
+	return code, nil
+}
+
+// getProductBarcodes groups the codes of the given products, variant codes
+// included, by product id with the primary ones first.
+func getProductBarcodes(ctx context.Context, db *pgxpool.Pool, productIDs []string) (map[string][]models.ProductBarcode, error) {
+	barcodes := make(map[string][]models.ProductBarcode, len(productIDs))
+	for _, id := range productIDs {
+		barcodes[id] = []models.ProductBarcode{}
+	}
+
+	rows, err := db.Query(ctx, `SELECT id, product_id, coalesce(variant_id::text, ''), code, kind, is_primary, created_at 
+		FROM product_barcodes WHERE product_id = any($1::uuid[]) 
+		ORDER BY variant_id NULLS FIRST, is_primary DESC, created_at`, productIDs)
+	if err != nil {
+		log.Println("Error while selecting product barcodes", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		code := models.ProductBarcode{}
+		if err = rows.Scan(
+			&code.ID,
+			&code.ProductID,
+			&code.VariantID,
+			&code.Code,
+			&code.Kind,
+			&code.IsPrimary,
+			&code.CreatedAt,
+		); err != nil {
+			log.Println("Error while scanning product barcode", err)
+			return nil, err
+		}
+		barcodes[code.ProductID] = append(barcodes[code.ProductID], code)
+	}
+
+	return barcodes, nil
+}
+
+// setPrimaryBarcode makes code the primary code of the product, or of the
+// variant when variantID is set. The code should be validated already.
+func setPrimaryBarcode(ctx context.Context, tx pgx.Tx, productID, variantID, code string) error {
+	var existingID string
+	err := tx.QueryRow(ctx, `SELECT id FROM product_barcodes 
+		WHERE code = $1 AND product_id = $2 AND coalesce(variant_id::text, '') = $3`, code, productID, variantID).Scan(&existingID)
+	if err != nil && err != pgx.ErrNoRows {
+		log.Println("Error while selecting barcode", err)
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE product_barcodes SET is_primary = false 
+		WHERE product_id = $1 AND coalesce(variant_id::text, '') = $2`, productID, variantID); err != nil {
+		log.Println("Error while updating primary barcode", err)
+		return err
+	}
+
+	if existingID != "" {
+		if _, err = tx.Exec(ctx, `UPDATE product_barcodes SET is_primary = true WHERE id = $1`, existingID); err != nil {
+			log.Println("Error while updating primary barcode", err)
+			return err
+		}
+		return nil
+	}
+
+	if _, err = tx.Exec(ctx, `INSERT INTO product_barcodes (id, product_id, variant_id, code, kind, is_primary) 
+		VALUES ($1, $2, nullif($3, '')::uuid, $4, $5, true)`,
+		uuid.New(), productID, variantID, code, barcode.Detect(code)); err != nil {
+		log.Println("Error while inserting barcode", err)
+		return err
+	}
+
+	return nil
+}
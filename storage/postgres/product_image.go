@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type productImageRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewProductImageRepo(DB *pgxpool.Pool) storage.IProductImageRepo {
+	return &productImageRepo{
+		DB: DB,
+	}
+}
+
+// Create appends the image to the end of the product gallery.
+func (s *productImageRepo) Create(ctx context.Context, image models.CreateProductImage) (string, error) {
+	if _, err := s.DB.Exec(ctx, `INSERT INTO product_images 
+    (id, product_id, original_key, thumbnail_key, content_type, position) 
+        VALUES ($1, $2, $3, $4, $5, 
+                (select coalesce(max(position) + 1, 0) from product_images where product_id = $2))`,
+		image.ID,
+		image.ProductID,
+		image.OriginalKey,
+		image.ThumbnailKey,
+		image.ContentType,
+	); err != nil {
+		log.Println("Error while inserting product image", err)
+		return "", err
+	}
+
+	return image.ID, nil
+}
+
+func (s *productImageRepo) GetByID(ctx context.Context, id string) (models.ProductImage, error) {
+	image := models.ProductImage{}
+	if err := s.DB.QueryRow(ctx, `SELECT id, product_id, position, content_type, original_key, thumbnail_key, created_at 
+		FROM product_images WHERE id = $1`, id).Scan(
+		&image.ID,
+		&image.ProductID,
+		&image.Position,
+		&image.ContentType,
+		&image.OriginalKey,
+		&image.ThumbnailKey,
+		&image.CreatedAt,
+	); err != nil {
+		log.Println("Error while selecting product image by id", err)
+		return models.ProductImage{}, err
+	}
+
+	return withImageURLs(image), nil
+}
+
+func (s *productImageRepo) GetList(ctx context.Context, productID string) ([]models.ProductImage, error) {
+	images, err := getProductImages(ctx, s.DB, []string{productID})
+	if err != nil {
+		return nil, err
+	}
+
+	return images[productID], nil
+}
+
+// Reorder sets the gallery order to the given image ids, which should be all
+// images of the product.
+func (s *productImageRepo) Reorder(ctx context.Context, request models.ReorderProductImages) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	count := 0
+	if err = tx.QueryRow(ctx, `SELECT count(1) FROM product_images WHERE product_id = $1`, request.ProductID).Scan(&count); err != nil {
+		log.Println("Error while counting product images", err)
+		return err
+	}
+
+	if count != len(request.ImageIDs) {
+		return errors.New("image_ids should list every image of the product once")
+	}
+
+	for position, id := range request.ImageIDs {
+		result, err := tx.Exec(ctx, `UPDATE product_images SET position = $1 WHERE id = $2 AND product_id = $3`,
+			position, id, request.ProductID)
+		if err != nil {
+			log.Println("Error while updating product image position", err)
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("image %s does not belong to the product", id)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *productImageRepo) Delete(ctx context.Context, id string) error {
+	if _, err := s.DB.Exec(ctx, `DELETE FROM product_images WHERE id = $1`, id); err != nil {
+		log.Println("Error while deleting product image", err)
+		return err
+	}
+
+	return nil
+}
+
+// getProductImages groups the images of the given products by product id in gallery order.
+// Every requested product gets a non-nil slice so it is listed as [] in json.
+func getProductImages(ctx context.Context, db *pgxpool.Pool, productIDs []string) (map[string][]models.ProductImage, error) {
+	images := make(map[string][]models.ProductImage, len(productIDs))
+	for _, id := range productIDs {
+		images[id] = []models.ProductImage{}
+	}
+
+	rows, err := db.Query(ctx, `SELECT id, product_id, position, content_type, original_key, thumbnail_key, created_at 
+		FROM product_images WHERE product_id = any($1::uuid[]) ORDER BY position, created_at`, productIDs)
+	if err != nil {
+		log.Println("Error while selecting product images", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		image := models.ProductImage{}
+		if err = rows.Scan(
+			&image.ID,
+			&image.ProductID,
+			&image.Position,
+			&image.ContentType,
+			&image.OriginalKey,
+			&image.ThumbnailKey,
+			&image.CreatedAt,
+		); err != nil {
+			log.Println("Error while scanning product image", err)
+			return nil, err
+		}
+		images[image.ProductID] = append(images[image.ProductID], withImageURLs(image))
+	}
+
+	return images, nil
+}
+
+func withImageURLs(image models.ProductImage) models.ProductImage {
+	image.URL = "/product-image/" + image.ID
+	image.ThumbnailURL = "/product-image/" + image.ID + "/thumbnail"
+	return image
+}
@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sell/api/models"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Import upserts the rows by any of the product barcodes, creates missing categories of the category
+// paths and sets the branch stock, all in one transaction. A dry run rolls the
+// transaction back, so the report shows what the import would do.
+func (p productRepo) Import(ctx context.Context, rows []models.ProductImportRow, dryRun bool) (models.ProductImportReport, error) {
+	report := models.ProductImportReport{
+		DryRun: dryRun,
+		Rows:   len(rows),
+		Errors: []models.ProductImportError{},
+	}
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return models.ProductImportReport{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	categories := map[string]string{}
+
+	for _, row := range rows {
+		categoryID, created, err := resolveCategoryPath(ctx, tx, categories, row.CategoryPath)
+		if err != nil {
+			return models.ProductImportReport{}, err
+		}
+		report.CategoriesCreated += created
+
+		var (
+			productID string
+			oldPrice  int
+		)
+		err = tx.QueryRow(ctx, `select p.id, p.price from product_barcodes b join products p on p.id = b.product_id 
+				where b.code = $1 for update of p`, row.Barcode).Scan(&productID, &oldPrice)
+		switch err {
+		case nil:
+			if _, err = tx.Exec(ctx, `update products set name = $1, price = $2, category_id = nullif($3, ''), unit = $4, 
+                    updated_at = now(), deleted_at = null where id = $5`,
+				row.Name, row.Price, categoryID, row.Unit, productID); err != nil {
+				fmt.Println("error is while updating imported product", err.Error())
+				return models.ProductImportReport{}, err
+			}
+			if oldPrice != row.Price {
+				if err = insertProductPriceHistory(ctx, tx, productID, oldPrice, row.Price, ""); err != nil {
+					return models.ProductImportReport{}, err
+				}
+			}
+			report.Updated++
+		case pgx.ErrNoRows:
+			productID = uuid.New().String()
+			if _, err = tx.Exec(ctx, `insert into products (id, name, price, category_id, unit) 
+					values($1, $2, $3, nullif($4, ''), $5)`,
+				productID, row.Name, row.Price, categoryID, row.Unit); err != nil {
+				fmt.Println("error is while inserting imported product", err.Error())
+				return models.ProductImportReport{}, err
+			}
+
+			if err = setPrimaryBarcode(ctx, tx, productID, "", row.Barcode); err != nil {
+				return models.ProductImportReport{}, err
+			}
+			report.Created++
+		default:
+			fmt.Println("error is while selecting product by barcode", err.Error())
+			return models.ProductImportReport{}, err
+		}
+
+		for branchID, count := range row.Stock {
+			if err = setImportedStock(ctx, tx, branchID, productID, count); err != nil {
+				return models.ProductImportReport{}, err
+			}
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing import", err.Error())
+		return models.ProductImportReport{}, err
+	}
+
+	return report, nil
+}
+
+// Export returns every product with its category path and stock by branch.
+func (p productRepo) Export(ctx context.Context) ([]models.ProductImportRow, error) {
+	var (
+		products = []models.ProductImportRow{}
+		indexes  = map[string]int{}
+	)
+
+	query := `with recursive paths as (
+					select id, name::text as path from categories where parent_id is null and deleted_at is null
+					union all
+					select c.id, paths.path || '/' || c.name from categories c 
+						join paths on c.parent_id = paths.id where c.deleted_at is null
+				)
+				select p.id, p.name, p.price, ` + fmt.Sprintf(primaryBarcode, "p.id") + `, coalesce(paths.path, ''), p.unit from products p
+					left join paths on paths.id = p.category_id
+				where p.deleted_at is null order by p.name`
+	rows, err := p.db.Query(ctx, query)
+	if err != nil {
+		fmt.Println("error is while selecting products for export", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		product := models.ProductImportRow{Stock: map[string]float64{}}
+		if err = rows.Scan(&id, &product.Name, &product.Price, &product.Barcode, &product.CategoryPath, &product.Unit); err != nil {
+			fmt.Println("error is while scanning product for export", err.Error())
+			return nil, err
+		}
+		indexes[id] = len(products)
+		products = append(products, product)
+	}
+	rows.Close()
+
+	stockRows, err := p.db.Query(ctx, `select product_id, branch_id, sum(count) from repositories 
+					where variant_id is null and deleted_at is null group by product_id, branch_id`)
+	if err != nil {
+		fmt.Println("error is while selecting stock for export", err.Error())
+		return nil, err
+	}
+	defer stockRows.Close()
+
+	for stockRows.Next() {
+		var (
+			productID, branchID string
+			count               float64
+		)
+		if err = stockRows.Scan(&productID, &branchID, &count); err != nil {
+			fmt.Println("error is while scanning stock for export", err.Error())
+			return nil, err
+		}
+
+		if i, ok := indexes[productID]; ok {
+			products[i].Stock[branchID] = count
+		}
+	}
+
+	return products, nil
+}
+
+// resolveCategoryPath returns the id of the last category of a path like
+// "Food/Dairy/Cheese", creating the missing ones. Resolved paths are cached.
+func resolveCategoryPath(ctx context.Context, tx pgx.Tx, cache map[string]string, path string) (string, int, error) {
+	var (
+		parentID, key string
+		created       int
+	)
+
+	for _, name := range strings.Split(path, "/") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		key += "/" + name
+		if id, ok := cache[key]; ok {
+			parentID = id
+			continue
+		}
+
+		var id string
+		err := tx.QueryRow(ctx, `select id from categories 
+                				where name = $1 and coalesce(parent_id, '') = $2 and deleted_at is null limit 1`,
+			name, parentID).Scan(&id)
+		if err == pgx.ErrNoRows {
+			id = uuid.New().String()
+			if _, err = tx.Exec(ctx, `insert into categories (id, name, parent_id) values($1, $2, nullif($3, ''))`,
+				id, name, parentID); err != nil {
+				fmt.Println("error is while inserting imported category", err.Error())
+				return "", 0, err
+			}
+			created++
+		} else if err != nil {
+			fmt.Println("error is while selecting category by name", err.Error())
+			return "", 0, err
+		}
+
+		cache[key] = id
+		parentID = id
+	}
+
+	return parentID, created, nil
+}
+
+// setImportedStock sets the branch stock of the product and writes the difference
+// to the repository transactions ledger.
+func setImportedStock(ctx context.Context, tx pgx.Tx, branchID, productID string, count float64) error {
+	var (
+		repositoryID string
+		current      float64
+	)
+
+	err := tx.QueryRow(ctx, `select id, count from repositories 
+				where branch_id = $1 and product_id = $2 and variant_id is null and deleted_at is null limit 1`,
+		branchID, productID).Scan(&repositoryID, &current)
+	switch err {
+	case nil:
+		if _, err = tx.Exec(ctx, `update repositories set count = $1, updated_at = now() where id = $2`, count, repositoryID); err != nil {
+			fmt.Println("error is while updating imported stock", err.Error())
+			return err
+		}
+	case pgx.ErrNoRows:
+		if _, err = tx.Exec(ctx, `insert into repositories (id, product_id, branch_id, count) values($1, $2, $3, $4)`,
+			uuid.New(), productID, branchID, count); err != nil {
+			fmt.Println("error is while inserting imported stock", err.Error())
+			return err
+		}
+	default:
+		fmt.Println("error is while selecting stock", err.Error())
+		return err
+	}
+
+	difference, transactionType := count-current, "plus"
+	if difference == 0 {
+		return nil
+	}
+	if difference < 0 {
+		difference, transactionType = -difference, "minus"
+	}
+
+	if _, err = tx.Exec(ctx, `insert into repository_transactions 
+    			(id, branch_id, product_id, repository_transaction_type, price, quantity) values($1, $2, $3, $4, 0, $5)`,
+		uuid.New(), branchID, productID, transactionType, difference); err != nil {
+		fmt.Println("error is while inserting imported stock transaction", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sell/api/models"
+)
+
+// productMatch matches products by full text, by trigram similarity for typos
+// and by part of the name or the start of any barcode. $1 is the search text.
+const productMatch = ` and ($1 = '' 
+			or p.search_vector @@ plainto_tsquery('simple', $1) 
+			or p.name % $1 or $1 <% p.name 
+			or p.name ilike '%' || $1 || '%' 
+			or exists (select 1 from product_barcodes b where b.product_id = p.id and b.code like $1 || '%')) `
+
+// productRank puts full text hits first and orders the rest by similarity.
+const productRank = `(case when $1 = '' then 0 else 
+			ts_rank(p.search_vector, plainto_tsquery('simple', $1)) + word_similarity($1, p.name) + similarity(p.name, $1) end)`
+
+func (p productRepo) Search(ctx context.Context, request models.ProductSearchRequest) (models.ProductSearchResponse, error) {
+	var (
+		offset   = (request.Page - 1) * request.Limit
+		filter   = productMatch
+		args     = []interface{}{request.Query}
+		count    = 0
+		products = []models.ProductSearchResult{}
+	)
+
+	if request.CategoryID != "" {
+		args = append(args, request.CategoryID)
+		filter += fmt.Sprintf(` and p.category_id in (
+				with recursive subtree as (
+					select id from categories where id = $%d and deleted_at is null
+					union all
+					select c.id from categories c join subtree s on c.parent_id = s.id where c.deleted_at is null
+				) select id from subtree) `, len(args))
+	}
+
+	if request.MinPrice > 0 {
+		args = append(args, request.MinPrice)
+		filter += fmt.Sprintf(` and p.price >= $%d `, len(args))
+	}
+
+	if request.MaxPrice > 0 {
+		args = append(args, request.MaxPrice)
+		filter += fmt.Sprintf(` and p.price <= $%d `, len(args))
+	}
+
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and exists (select 1 from repositories r where r.product_id = p.id 
+				and r.branch_id = $%d and r.count > 0 and r.deleted_at is null) `, len(args))
+	}
+
+	countQuery := `select count(1) from products p where p.deleted_at is null ` + filter
+	if err := p.db.QueryRow(ctx, countQuery, args...).Scan(&count); err != nil {
+		fmt.Println("error is while scanning search count", err.Error())
+		return models.ProductSearchResponse{}, err
+	}
+
+	query := fmt.Sprintf(`select p.id, p.name, p.price, %s, coalesce(p.category_id::text, ''), p.unit, 
+				p.created_at, p.updated_at, %s as rank 
+				from products p where p.deleted_at is null %s 
+				order by rank desc, p.name LIMIT $%d OFFSET $%d`,
+		fmt.Sprintf(primaryBarcode, "p.id"), productRank, filter, len(args)+1, len(args)+2)
+	args = append(args, request.Limit, offset)
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		fmt.Println("error is while searching products", err.Error())
+		return models.ProductSearchResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product := models.ProductSearchResult{}
+		if err = rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.Price,
+			&product.Barcode,
+			&product.CategoryID,
+			&product.Unit,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.Rank); err != nil {
+			fmt.Println("error is while scanning searched product", err.Error())
+			return models.ProductSearchResponse{}, err
+		}
+		products = append(products, product)
+	}
+	rows.Close()
+
+	productIDs := make([]string, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+	}
+
+	variants, err := p.getVariants(ctx, productIDs)
+	if err != nil {
+		return models.ProductSearchResponse{}, err
+	}
+
+	images, err := getProductImages(ctx, p.db, productIDs)
+	if err != nil {
+		return models.ProductSearchResponse{}, err
+	}
+
+	for i := range products {
+		products[i].Variants = variants[products[i].ID]
+		products[i].Images = images[products[i].ID]
+	}
+
+	return models.ProductSearchResponse{
+		Products: products,
+		Count:    count,
+	}, nil
+}
+
+// Suggest returns a few product names for a search box. Prefix matches come
+// first, the trigram index keeps it fast for typos.
+func (p productRepo) Suggest(ctx context.Context, search string, limit int) ([]models.ProductSuggestion, error) {
+	suggestions := []models.ProductSuggestion{}
+
+	query := `select id, name, price, ` + fmt.Sprintf(primaryBarcode, "p.id") + ` from products p 
+				where deleted_at is null and (name ilike $1 || '%' or $1 <% name 
+					or exists (select 1 from product_barcodes b where b.product_id = p.id and b.code like $1 || '%')) 
+				order by name ilike $1 || '%' desc, word_similarity($1, name) desc, name 
+				limit $2`
+	rows, err := p.db.Query(ctx, query, search, limit)
+	if err != nil {
+		fmt.Println("error is while selecting suggestions", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		suggestion := models.ProductSuggestion{}
+		if err = rows.Scan(&suggestion.ID, &suggestion.Name, &suggestion.Price, &suggestion.Barcode); err != nil {
+			fmt.Println("error is while scanning suggestion", err.Error())
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
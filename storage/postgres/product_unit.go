@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type productUnitRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewProductUnitRepo(DB *pgxpool.Pool) storage.IProductUnitRepo {
+	return &productUnitRepo{
+		DB: DB,
+	}
+}
+
+func (s *productUnitRepo) Create(ctx context.Context, unit models.CreateProductUnit) (string, error) {
+	id := uuid.New()
+
+	if _, err := s.DB.Exec(ctx, `INSERT INTO product_units 
+    (id, product_id, name, factor) 
+        VALUES ($1, $2, $3, $4)`,
+		id,
+		unit.ProductID,
+		unit.Name,
+		unit.Factor,
+	); err != nil {
+		log.Println("Error while inserting data:", err)
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *productUnitRepo) GetByID(ctx context.Context, id models.PrimaryKey) (models.ProductUnit, error) {
+	unit := models.ProductUnit{}
+	query := `SELECT id, product_id, name, factor, created_at, updated_at 
+							FROM product_units WHERE id = $1 and deleted_at is null`
+
+	err := s.DB.QueryRow(ctx, query, id.ID).Scan(
+		&unit.ID,
+		&unit.ProductID,
+		&unit.Name,
+		&unit.Factor,
+		&unit.CreatedAt,
+		&unit.UpdatedAt,
+	)
+	if err != nil {
+		log.Println("Error while selecting product unit by ID:", err)
+		return models.ProductUnit{}, err
+	}
+	return unit, nil
+}
+
+func (s *productUnitRepo) GetList(ctx context.Context, request models.GetListRequest) (models.ProductUnitsResponse, error) {
+	var (
+		units = []models.ProductUnit{}
+		count int
+	)
+
+	filter := ""
+	args := []interface{}{}
+	if request.Search != "" {
+		args = append(args, request.Search)
+		filter += fmt.Sprintf(` and product_id = $%d`, len(args))
+	}
+
+	err := s.DB.QueryRow(ctx, `SELECT COUNT(*) FROM product_units where deleted_at is null`+filter, args...).Scan(&count)
+	if err != nil {
+		log.Println("Error while scanning count of product units:", err)
+		return models.ProductUnitsResponse{}, err
+	}
+
+	args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	query := `SELECT id, product_id, name, factor, created_at, updated_at FROM product_units where deleted_at is null` + filter +
+		fmt.Sprintf(` order by created_at desc LIMIT $%d OFFSET $%d `, len(args)-1, len(args))
+
+	rows, err := s.DB.Query(ctx, query, args...)
+	if err != nil {
+		log.Println("Error while querying product units:", err)
+		return models.ProductUnitsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		unit := models.ProductUnit{}
+		err := rows.Scan(
+			&unit.ID,
+			&unit.ProductID,
+			&unit.Name,
+			&unit.Factor,
+			&unit.CreatedAt,
+			&unit.UpdatedAt,
+		)
+		if err != nil {
+			log.Println("Error while scanning row of product units:", err)
+			return models.ProductUnitsResponse{}, err
+		}
+		units = append(units, unit)
+	}
+
+	return models.ProductUnitsResponse{
+		ProductUnits: units,
+		Count:        count,
+	}, nil
+}
+
+func (s *productUnitRepo) Update(ctx context.Context, unit models.UpdateProductUnit) (string, error) {
+	query := `UPDATE product_units SET product_id = $1, name = $2, factor = $3, updated_at = NOW() WHERE id = $4`
+
+	_, err := s.DB.Exec(ctx, query,
+		&unit.ProductID,
+		&unit.Name,
+		&unit.Factor,
+		&unit.ID,
+	)
+	if err != nil {
+		log.Println("Error while updating product unit :", err)
+		return "", err
+	}
+
+	return unit.ID, nil
+}
+
+func (s *productUnitRepo) Delete(ctx context.Context, id string) error {
+	query := `UPDATE product_units SET deleted_at = NOW() WHERE id = $1`
+
+	_, err := s.DB.Exec(ctx, query, id)
+	if err != nil {
+		log.Println("Error while deleting product unit :", err)
+		return err
+	}
+
+	return nil
+}
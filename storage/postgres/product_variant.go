@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type productVariantRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewProductVariantRepo(DB *pgxpool.Pool) storage.IProductVariantRepo {
+	return &productVariantRepo{
+		DB: DB,
+	}
+}
+
+func (s *productVariantRepo) Create(ctx context.Context, variant models.CreateProductVariant) (string, error) {
+	id := uuid.New()
+
+	if variant.Attributes == nil {
+		variant.Attributes = map[string]string{}
+	}
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction:", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `INSERT INTO product_variants 
+    (id, product_id, name, attributes, price) 
+        VALUES ($1, $2, $3, $4, nullif($5, 0))`,
+		id,
+		variant.ProductID,
+		variant.Name,
+		variant.Attributes,
+		variant.Price,
+	); err != nil {
+		log.Println("Error while inserting data:", err)
+		return "", err
+	}
+
+	if variant.Barcode != "" {
+		if err = setPrimaryBarcode(ctx, tx, variant.ProductID, id.String(), variant.Barcode); err != nil {
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing product variant:", err)
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (s *productVariantRepo) GetByID(ctx context.Context, id models.PrimaryKey) (models.ProductVariant, error) {
+	variant := models.ProductVariant{}
+	query := `SELECT id, product_id, name, attributes, ` + fmt.Sprintf(variantBarcode, "product_variants.id") + `, coalesce(price, 0), created_at, updated_at 
+							FROM product_variants WHERE id = $1 and deleted_at is null`
+
+	err := s.DB.QueryRow(ctx, query, id.ID).Scan(
+		&variant.ID,
+		&variant.ProductID,
+		&variant.Name,
+		&variant.Attributes,
+		&variant.Barcode,
+		&variant.Price,
+		&variant.CreatedAt,
+		&variant.UpdatedAt,
+	)
+	if err != nil {
+		log.Println("Error while selecting product variant by ID:", err)
+		return models.ProductVariant{}, err
+	}
+	return variant, nil
+}
+
+func (s *productVariantRepo) GetList(ctx context.Context, request models.GetListRequest) (models.ProductVariantsResponse, error) {
+	var (
+		variants = []models.ProductVariant{}
+		count    int
+	)
+
+	filter := ""
+	args := []interface{}{}
+	if request.Search != "" {
+		args = append(args, request.Search)
+		filter += fmt.Sprintf(` and product_id = $%d`, len(args))
+	}
+
+	err := s.DB.QueryRow(ctx, `SELECT COUNT(*) FROM product_variants where deleted_at is null`+filter, args...).Scan(&count)
+	if err != nil {
+		log.Println("Error while scanning count of product variants:", err)
+		return models.ProductVariantsResponse{}, err
+	}
+
+	args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	query := `SELECT id, product_id, name, attributes, ` + fmt.Sprintf(variantBarcode, "product_variants.id") + `, coalesce(price, 0), created_at, updated_at 
+							FROM product_variants where deleted_at is null` + filter +
+		fmt.Sprintf(` order by created_at desc LIMIT $%d OFFSET $%d `, len(args)-1, len(args))
+
+	rows, err := s.DB.Query(ctx, query, args...)
+	if err != nil {
+		log.Println("Error while querying product variants:", err)
+		return models.ProductVariantsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		variant := models.ProductVariant{}
+		err := rows.Scan(
+			&variant.ID,
+			&variant.ProductID,
+			&variant.Name,
+			&variant.Attributes,
+			&variant.Barcode,
+			&variant.Price,
+			&variant.CreatedAt,
+			&variant.UpdatedAt,
+		)
+		if err != nil {
+			log.Println("Error while scanning row of product variants:", err)
+			return models.ProductVariantsResponse{}, err
+		}
+		variants = append(variants, variant)
+	}
+
+	return models.ProductVariantsResponse{
+		ProductVariants: variants,
+		Count:           count,
+	}, nil
+}
+
+// Update changes the variant, a new barcode becomes its primary code and the
+// old codes stay so labels already printed still scan.
+func (s *productVariantRepo) Update(ctx context.Context, variant models.UpdateProductVariant) (string, error) {
+	query := `UPDATE product_variants SET product_id = $1, name = $2, attributes = $3, 
+                            price = nullif($4, 0), updated_at = NOW() WHERE id = $5`
+
+	if variant.Attributes == nil {
+		variant.Attributes = map[string]string{}
+	}
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction:", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, query,
+		&variant.ProductID,
+		&variant.Name,
+		&variant.Attributes,
+		&variant.Price,
+		&variant.ID,
+	)
+	if err != nil {
+		log.Println("Error while updating product variant :", err)
+		return "", err
+	}
+
+	if variant.Barcode != "" {
+		if err = setPrimaryBarcode(ctx, tx, variant.ProductID, variant.ID, variant.Barcode); err != nil {
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing product variant:", err)
+		return "", err
+	}
+
+	return variant.ID, nil
+}
+
+func (s *productVariantRepo) Delete(ctx context.Context, id string) error {
+	query := `UPDATE product_variants SET deleted_at = NOW() WHERE id = $1`
+
+	_, err := s.DB.Exec(ctx, query, id)
+	if err != nil {
+		log.Println("Error while deleting product variant :", err)
+		return err
+	}
+
+	return nil
+}
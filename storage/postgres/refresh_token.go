@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type refreshTokenRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewRefreshTokenRepo(DB *pgxpool.Pool) storage.IRefreshTokenRepo {
+	return &refreshTokenRepo{
+		DB: DB,
+	}
+}
+
+func (r *refreshTokenRepo) Create(ctx context.Context, token models.CreateRefreshToken) (string, error) {
+	id := uuid.New().String()
+
+	if _, err := r.DB.Exec(ctx, `insert into refresh_tokens (id, staff_id, token_hash, expires_at) 
+		values ($1, $2, $3, $4)`,
+		id,
+		token.StaffID,
+		token.TokenHash,
+		token.ExpiresAt,
+	); err != nil {
+		log.Println("Error while inserting refresh token", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *refreshTokenRepo) GetByHash(ctx context.Context, hash string) (models.RefreshToken, error) {
+	token := models.RefreshToken{}
+
+	if err := r.DB.QueryRow(ctx, `select id, staff_id, expires_at, revoked_at, created_at 
+		from refresh_tokens where token_hash = $1`, hash).Scan(
+		&token.ID,
+		&token.StaffID,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	); err != nil {
+		return models.RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+// Revoke marks the token as used, it returns pgx.ErrNoRows when the token was already revoked,
+// so a refresh token can be exchanged only once.
+func (r *refreshTokenRepo) Revoke(ctx context.Context, id string) error {
+	result, err := r.DB.Exec(ctx, `update refresh_tokens set revoked_at = now() 
+		where id = $1 and revoked_at is null`, id)
+	if err != nil {
+		log.Println("Error while revoking refresh token", err)
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// RevokeByStaff signs the staff out everywhere, it is used when the password changes.
+func (r *refreshTokenRepo) RevokeByStaff(ctx context.Context, staffID string) error {
+	if _, err := r.DB.Exec(ctx, `update refresh_tokens set revoked_at = now() 
+		where staff_id = $1 and revoked_at is null`, staffID); err != nil {
+		log.Println("Error while revoking refresh tokens of staff", err)
+		return err
+	}
+
+	return nil
+}
@@ -2,12 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sell/api/models"
 	"sell/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -21,32 +23,50 @@ func NewRepositoryRepo(DB *pgxpool.Pool) storage.IRepositoryRepo {
 	}
 }
 
+// Create adds the count to the stock row of the branch, product and variant and records it
+// as a plus repository transaction.
 func (s *repositoryRepo) Create(ctx context.Context, repository models.CreateRepository) (string, error) {
-	id := uuid.New()
-
-	if _, err := s.DB.Exec(ctx, `INSERT INTO repositories 
-    (id, product_id, branch_id, count) 
-        VALUES ($1, $2, $3, $4)`,
-		id,
-		repository.ProductID,
-		repository.BranchID,
-		repository.Count,
-	); err != nil {
-		log.Println("Error while inserting data:", err)
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction:", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = moveStock(ctx, tx, repository.BranchID, repository.ProductID, repository.VariantID, repository.Count); err != nil {
+		return "", err
+	}
+
+	if err = recordStockMovement(ctx, tx, repository.BranchID, repository.ProductID, repository.VariantID,
+		repository.StaffID, repository.Count); err != nil {
 		return "", err
 	}
 
-	return id.String(), nil
+	var id string
+	if err = tx.QueryRow(ctx, `SELECT id FROM repositories WHERE branch_id = $1 AND product_id = $2 
+                    AND coalesce(variant_id::text, '') = $3 AND deleted_at IS NULL`,
+		repository.BranchID, repository.ProductID, repository.VariantID).Scan(&id); err != nil {
+		log.Println("Error while selecting repository:", err)
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing repository:", err)
+		return "", err
+	}
+
+	return id, nil
 }
 
 func (s *repositoryRepo) GetByID(ctx context.Context, id models.PrimaryKey) (models.Repository, error) {
 	repository := models.Repository{}
-	query := `SELECT id, product_id, branch_id, count, created_at, updated_at 
+	query := `SELECT id, product_id, coalesce(variant_id::text, ''), branch_id, count, created_at, updated_at 
 							FROM repositories WHERE id = $1 and deleted_at is null
 `
 	err := s.DB.QueryRow(ctx, query, id.ID).Scan(
 		&repository.ID,
 		&repository.ProductID,
+		&repository.VariantID,
 		&repository.BranchID,
 		&repository.Count,
 		&repository.CreatedAt,
@@ -76,7 +96,8 @@ func (s *repositoryRepo) GetList(ctx context.Context, request models.GetListRequ
 		return models.RepositoriesResponse{}, err
 	}
 
-	query := `SELECT id, product_id, branch_id, count, created_at, updated_at FROM repositories where deleted_at is null`
+	query := `SELECT id, product_id, coalesce(variant_id::text, ''), branch_id, count, created_at, updated_at 
+							FROM repositories where deleted_at is null`
 	if request.Search != "" {
 		query += fmt.Sprintf(` and branch_id = '%s'`, request.Search)
 	}
@@ -94,6 +115,7 @@ func (s *repositoryRepo) GetList(ctx context.Context, request models.GetListRequ
 		err := rows.Scan(
 			&repository.ID,
 			&repository.ProductID,
+			&repository.VariantID,
 			&repository.BranchID,
 			&repository.Count,
 			&repository.CreatedAt,
@@ -112,37 +134,135 @@ func (s *repositoryRepo) GetList(ctx context.Context, request models.GetListRequ
 	}, nil
 }
 
+// Update corrects the stock row. The old count is recorded as taken from the old branch,
+// product and variant and the new one as put into the new ones, only the difference when
+// they stay the same.
 func (s *repositoryRepo) Update(ctx context.Context, repository models.UpdateRepository) (string, error) {
-	query := `UPDATE repositories SET branch_id = $1, product_id = $2, count = $3, updated_at = NOW() WHERE id = $4`
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction:", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	old := models.Repository{}
+	if err = tx.QueryRow(ctx, `SELECT branch_id, product_id, coalesce(variant_id::text, ''), count FROM repositories 
+                    WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, repository.ID).Scan(
+		&old.BranchID,
+		&old.ProductID,
+		&old.VariantID,
+		&old.Count,
+	); err != nil {
+		log.Println("Error while selecting Repository :", err)
+		return "", err
+	}
+
+	query := `UPDATE repositories SET branch_id = $1, product_id = $2, variant_id = nullif($3, '')::uuid, count = $4, 
+                        updated_at = NOW() WHERE id = $5`
 
-	_, err := s.DB.Exec(ctx, query,
+	if _, err = tx.Exec(ctx, query,
 		&repository.BranchID,
 		&repository.ProductID,
+		&repository.VariantID,
 		&repository.Count,
 		&repository.ID,
-	)
-	if err != nil {
+	); err != nil {
 		log.Println("Error while updating Repository :", err)
 		return "", err
 	}
 
+	if old.BranchID == repository.BranchID && old.ProductID == repository.ProductID && old.VariantID == repository.VariantID {
+		err = recordStockMovement(ctx, tx, old.BranchID, old.ProductID, old.VariantID, repository.StaffID, repository.Count-old.Count)
+	} else if err = recordStockMovement(ctx, tx, old.BranchID, old.ProductID, old.VariantID, repository.StaffID, -old.Count); err == nil {
+		err = recordStockMovement(ctx, tx, repository.BranchID, repository.ProductID, repository.VariantID, repository.StaffID, repository.Count)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing Repository :", err)
+		return "", err
+	}
+
 	return repository.ID, nil
 }
 
-func (s *repositoryRepo) UpdateProductQuantity(ctx context.Context, repository models.UpdateRepository) (string, error) {
-	query := `UPDATE repositories SET count = $3, updated_at = NOW() WHERE branch_id = $1 AND product_id = $2 `
+// recordStockMovement writes the plus or minus repository transaction of a stock change made
+// by hand, so the reconciliation finds the ledger and the stock rows agreeing.
+func recordStockMovement(ctx context.Context, db querier, branchID, productID, variantID, staffID string, delta float64) error {
+	if delta == 0 {
+		return nil
+	}
 
-	_, err := s.DB.Exec(ctx, query,
-		&repository.BranchID,
-		&repository.ProductID,
-		&repository.Count,
-	)
+	transactionType := "plus"
+	if delta < 0 {
+		transactionType, delta = "minus", -delta
+	}
+
+	_, err := insertRepositoryTransaction(ctx, db, models.CreateRepositoryTransaction{
+		BranchID:                  branchID,
+		StaffID:                   staffID,
+		ProductID:                 productID,
+		VariantID:                 variantID,
+		RepositoryTransactionType: transactionType,
+		Quantity:                  delta,
+	})
+	return err
+}
+
+// MoveProductQuantity adds delta to the branch stock of the product and returns the count
+// left. It returns storage.ErrNotEnoughStock instead of taking the count below zero.
+func (s *repositoryRepo) MoveProductQuantity(ctx context.Context, branchID, productID, variantID string, delta float64) (float64, error) {
+	return moveStock(ctx, s.DB, branchID, productID, variantID, delta)
+}
+
+// moveStock changes the one stock row of the branch, product and variant in a single
+// statement, so concurrent sales can not take the same units. Stock coming back creates
+// the row when there is none.
+func moveStock(ctx context.Context, db querier, branchID, productID, variantID string, delta float64) (float64, error) {
+	var count float64
+
+	if delta >= 0 {
+		if err := db.QueryRow(ctx, `INSERT INTO repositories (id, product_id, variant_id, branch_id, count) 
+			VALUES ($1, $2, nullif($3, '')::uuid, $4, $5)
+			ON CONFLICT (branch_id, product_id, coalesce(variant_id::text, '')) WHERE deleted_at IS NULL
+			DO UPDATE SET count = repositories.count + excluded.count, updated_at = NOW()
+			RETURNING count`,
+			uuid.New(), productID, variantID, branchID, delta).Scan(&count); err != nil {
+			log.Println("Error while adding stock:", err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	err := db.QueryRow(ctx, `UPDATE repositories SET count = count - $4, updated_at = NOW() 
+		WHERE branch_id = $1 AND product_id = $2 AND coalesce(variant_id::text, '') = $3 
+		  AND deleted_at IS NULL AND count >= $4
+		RETURNING count`, branchID, productID, variantID, -delta).Scan(&count)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, storage.ErrNotEnoughStock
+	}
 	if err != nil {
-		log.Println("Error while updating Repository :", err)
-		return "", err
+		log.Println("Error while taking stock:", err)
+		return 0, err
 	}
 
-	return repository.ID, nil
+	return count, nil
+}
+
+func (s *repositoryRepo) GetProductCount(ctx context.Context, branchID, productID, variantID string) (float64, error) {
+	var count float64
+	query := `SELECT COALESCE(SUM(count), 0) FROM repositories 
+                        WHERE branch_id = $1 AND product_id = $2 AND coalesce(variant_id::text, '') = $3 
+                          AND deleted_at is null`
+
+	if err := s.DB.QueryRow(ctx, query, branchID, productID, variantID).Scan(&count); err != nil {
+		log.Println("Error while selecting product count:", err)
+		return 0, err
+	}
+
+	return count, nil
 }
 
 func (s *repositoryRepo) Delete(ctx context.Context, id string) error {
@@ -21,17 +21,27 @@ func NewRepositoryTransactionRepo(DB *pgxpool.Pool) storage.IRepositoryTransacti
 }
 
 func (s *repositoryTransactionRepo) Create(ctx context.Context, rtransaction models.CreateRepositoryTransaction) (string, error) {
+	return insertRepositoryTransaction(ctx, s.DB, rtransaction)
+}
+
+// insertRepositoryTransaction records a stock movement, the sales and refunds write theirs
+// in the transaction that moves the stock.
+func insertRepositoryTransaction(ctx context.Context, db querier, rtransaction models.CreateRepositoryTransaction) (string, error) {
 	id := uuid.New().String()
 
-	if _, err := s.DB.Exec(ctx, `INSERT INTO repository_transactions
-		(id, staff_id, product_id, repository_transaction_type, price, quantity)
-			VALUES($1, $2, $3, $4, $5, $6)`,
+	if _, err := db.Exec(ctx, `INSERT INTO repository_transactions
+		(id, branch_id, staff_id, product_id, variant_id, repository_transaction_type, price, quantity, reason, sale_id)
+			VALUES($1, nullif($2, '')::uuid, $3, $4, nullif($5, '')::uuid, $6, $7, $8, nullif($9, '')::write_off_reason_enum, nullif($10, '')::uuid)`,
 		id,
+		rtransaction.BranchID,
 		rtransaction.StaffID,
 		rtransaction.ProductID,
+		rtransaction.VariantID,
 		rtransaction.RepositoryTransactionType,
 		rtransaction.Price,
 		rtransaction.Quantity,
+		rtransaction.Reason,
+		rtransaction.SaleID,
 	); err != nil {
 		log.Println("Error while inserting data:", err)
 		return "", err
@@ -42,17 +52,22 @@ func (s *repositoryTransactionRepo) Create(ctx context.Context, rtransaction mod
 
 func (s *repositoryTransactionRepo) GetByID(ctx context.Context, id models.PrimaryKey) (models.RepositoryTransaction, error) {
 	rtransaction := models.RepositoryTransaction{}
-	query := `SELECT id, staff_id, product_id, repository_transaction_type, price, quantity, created_at, updated_at 
+	query := `SELECT id, coalesce(branch_id::text, ''), staff_id, product_id, coalesce(variant_id::text, ''), coalesce(sale_id::text, ''), repository_transaction_type, price, quantity, 
+       						coalesce(reason::text, ''), created_at, updated_at 
 							FROM repository_transactions WHERE id = $1 and deleted_at is null
 `
 
 	err := s.DB.QueryRow(ctx, query, id.ID).Scan(
 		&rtransaction.ID,
+		&rtransaction.BranchID,
 		&rtransaction.StaffID,
 		&rtransaction.ProductID,
+		&rtransaction.VariantID,
+		&rtransaction.SaleID,
 		&rtransaction.RepositoryTransactionType,
 		&rtransaction.Price,
 		&rtransaction.Quantity,
+		&rtransaction.Reason,
 		&rtransaction.CreatedAt,
 		&rtransaction.UpdatedAt,
 	)
@@ -81,7 +96,8 @@ func (s *repositoryTransactionRepo) GetList(ctx context.Context, req models.GetL
 		return models.RepositoryTransactionsResponse{}, err
 	}
 
-	query := `SELECT id, staff_id, product_id, repository_transaction_type, price, quantity, created_at, updated_at 
+	query := `SELECT id, coalesce(branch_id::text, ''), staff_id, product_id, coalesce(variant_id::text, ''), coalesce(sale_id::text, ''), repository_transaction_type, price, quantity, 
+       						coalesce(reason::text, ''), created_at, updated_at 
 							FROM repository_transactions where deleted_at is null
 `
 	if req.Search != "" {
@@ -100,11 +116,15 @@ func (s *repositoryTransactionRepo) GetList(ctx context.Context, req models.GetL
 		rtransaction := models.RepositoryTransaction{}
 		err := rows.Scan(
 			&rtransaction.ID,
+			&rtransaction.BranchID,
 			&rtransaction.StaffID,
 			&rtransaction.ProductID,
+			&rtransaction.VariantID,
+			&rtransaction.SaleID,
 			&rtransaction.RepositoryTransactionType,
 			&rtransaction.Price,
 			&rtransaction.Quantity,
+			&rtransaction.Reason,
 			&rtransaction.CreatedAt,
 			&rtransaction.UpdatedAt,
 		)
@@ -122,16 +142,20 @@ func (s *repositoryTransactionRepo) GetList(ctx context.Context, req models.GetL
 }
 
 func (s *repositoryTransactionRepo) Update(ctx context.Context, transaction models.UpdateRepositoryTransaction) (string, error) {
-	query := `UPDATE repository_transactions SET staff_id = $1, product_id = $2, repository_transaction_type = $3, 
-                                   price = $4, quantity = $5, updated_at = NOW() WHERE id = $6
+	query := `UPDATE repository_transactions SET branch_id = nullif($1, '')::uuid, staff_id = $2, product_id = $3, 
+                                   variant_id = nullif($4, '')::uuid, repository_transaction_type = $5, price = $6, quantity = $7, 
+                                   reason = nullif($8, '')::write_off_reason_enum, updated_at = NOW() WHERE id = $9
 `
 
 	_, err := s.DB.Exec(ctx, query,
+		&transaction.BranchID,
 		&transaction.StaffID,
 		&transaction.ProductID,
+		&transaction.VariantID,
 		&transaction.RepositoryTransactionType,
 		&transaction.Price,
 		&transaction.Quantity,
+		&transaction.Reason,
 		&transaction.ID,
 	)
 	if err != nil {
@@ -2,8 +2,10 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"sell/api/models"
 	"sell/storage"
@@ -20,15 +22,13 @@ func NewSaleRepo(db *pgxpool.Pool) storage.ISaleStorage {
 func (s saleRepo) Create(ctx context.Context, sale models.CreateSale) (string, error) {
 	id := uuid.New()
 	query := `insert into sales (id, branch_id, shop_assistant_id, cashier_id, payment_type, price, status, client_name)
-								values($1, $2, $3, $4, $5, $6, $7, $8)`
+								values($1, $2, $3, $4, $5, 0, 'in_process', $6)`
 
 	if _, err := s.db.Exec(ctx, query, id,
 		sale.BranchID,
 		sale.ShopAssistantID,
 		sale.CashierID,
 		sale.PaymentType,
-		sale.Price,
-		sale.Status,
 		sale.ClientName); err != nil {
 		fmt.Println("error is while inserting data", err.Error())
 		return "", err
@@ -112,22 +112,26 @@ func (s saleRepo) GetList(ctx context.Context, request models.GetListRequest) (m
 	}, nil
 }
 
+// Update changes a sale in process, it returns pgx.ErrNoRows for the other sales.
 func (s saleRepo) Update(ctx context.Context, sale models.UpdateSale) (string, error) {
 	query := `update sales set branch_id = $1, shop_assistant_id = $2, cashier_id = $3, payment_type = $4, 
-				price = $5, status = $6, client_name = $7, updated_at = now() where id = $8`
+				client_name = $5, updated_at = now() where id = $6 and status = 'in_process' and deleted_at is null`
 
-	if _, err := s.db.Exec(ctx, query,
+	result, err := s.db.Exec(ctx, query,
 		&sale.BranchID,
 		&sale.ShopAssistantID,
 		&sale.CashierID,
 		&sale.PaymentType,
-		&sale.Price,
-		&sale.Status,
 		&sale.ClientName,
-		&sale.ID); err != nil {
+		&sale.ID)
+	if err != nil {
 		fmt.Println("error is while updating sale", err.Error())
 		return "", err
 	}
+
+	if result.RowsAffected() == 0 {
+		return "", pgx.ErrNoRows
+	}
 	return sale.ID, nil
 }
 
@@ -140,15 +144,263 @@ func (s saleRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s saleRepo) UpdatePrice(ctx context.Context, totalSum int, id string) (string, error) {
-	query := `update sales set price = $1, status = 'success' where id = $2`
-	if rowsAffected, err := s.db.Exec(ctx, query, &totalSum, &id); err != nil {
-		if r := rowsAffected.RowsAffected(); r == 0 {
-			fmt.Println("error in rows affected", err.Error())
-			return "", err
+// End finishes a sale that is in process, taking the stock of its baskets, writing the
+// minus repository transactions, posting the sale to the ledger and paying the commissions. It returns pgx.ErrNoRows when the sale is not in process
+// and storage.ErrNotEnoughStock when a movement would take the stock below zero.
+func (s saleRepo) End(ctx context.Context, sale models.EndSale) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var branchID string
+	query := `select coalesce(branch_id::text, '') from sales 
+				where id = $1 and status = 'in_process' and deleted_at is null for update`
+	if err = tx.QueryRow(ctx, query, sale.ID).Scan(&branchID); err != nil {
+		fmt.Println("error is while locking sale", err.Error())
+		return err
+	}
+
+	for _, movement := range sale.Movements {
+		if _, err = moveStock(ctx, tx, branchID, movement.ProductID, movement.VariantID, -movement.Quantity); err != nil {
+			if errors.Is(err, storage.ErrNotEnoughStock) {
+				return fmt.Errorf("%w: %s", storage.ErrNotEnoughStock, movement.ProductID)
+			}
+			return err
 		}
-		fmt.Println("error is while updating sale price", err.Error())
-		return "", err
+
+		movement.BranchID = branchID
+		movement.StaffID = sale.StaffID
+		movement.SaleID = sale.ID
+		if _, err = insertRepositoryTransaction(ctx, tx, movement); err != nil {
+			return err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `update sales set price = $1, status = 'success', updated_at = now() where id = $2`,
+		sale.Price, sale.ID); err != nil {
+		fmt.Println("error is while ending sale", err.Error())
+		return err
+	}
+
+	if err = postSale(ctx, tx, sale.ID); err != nil {
+		return err
+	}
+
+	if err = postSaleCommissions(ctx, tx, sale.ID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing transaction", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Refund cancels a finished sale, puts back the stock its minus repository transactions took
+// with plus ones, takes the commissions back and posts the money going back to the client. It returns pgx.ErrNoRows when
+// the sale is not finished.
+func (s saleRepo) Refund(ctx context.Context, sale models.RefundSale) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		price                 float64
+		paymentType, branchID string
+	)
+	query := `update sales set status = 'cancel', updated_at = now() 
+				where id = $1 and status = 'success' and deleted_at is null
+				returning coalesce(price, 0), coalesce(payment_type::text, ''), coalesce(branch_id::text, '')`
+	if err = tx.QueryRow(ctx, query, sale.ID).Scan(&price, &paymentType, &branchID); err != nil {
+		fmt.Println("error is while refunding sale", err.Error())
+		return err
+	}
+
+	movements, err := saleStockMovements(ctx, tx, sale.ID)
+	if err != nil {
+		return err
+	}
+	if len(movements) == 0 {
+		movements = sale.Movements
+	}
+
+	for _, movement := range movements {
+		if _, err = moveStock(ctx, tx, branchID, movement.ProductID, movement.VariantID, movement.Quantity); err != nil {
+			return err
+		}
+
+		movement.RepositoryTransactionType = "plus"
+		movement.BranchID = branchID
+		movement.StaffID = sale.StaffID
+		movement.SaleID = sale.ID
+		if _, err = insertRepositoryTransaction(ctx, tx, movement); err != nil {
+			return err
+		}
+	}
+
+	if err = reverseSaleCommissions(ctx, tx, sale.ID); err != nil {
+		return err
+	}
+
+	if price > 0 {
+		if _, err = postEntry(ctx, tx, models.CreateJournalEntry{
+			Description: "refund",
+			SourceType:  "refund",
+			SourceID:    sale.ID,
+			Postings: []models.CreatePosting{
+				{AccountCode: accountSalesReturns, BranchID: branchID, Debit: price},
+				{AccountCode: paymentAccount(paymentType), BranchID: branchID, Credit: price},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing transaction", err.Error())
+		return err
+	}
+	return nil
+}
+
+// saleStockMovements returns the minus repository transactions the sale wrote when it ended.
+func saleStockMovements(ctx context.Context, tx pgx.Tx, saleID string) ([]models.CreateRepositoryTransaction, error) {
+	rows, err := tx.Query(ctx, `select product_id, coalesce(variant_id::text, ''), price, quantity from repository_transactions 
+				where sale_id = $1 and repository_transaction_type = 'minus' and deleted_at is null`, saleID)
+	if err != nil {
+		fmt.Println("error is while selecting sale repository transactions", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := []models.CreateRepositoryTransaction{}
+	for rows.Next() {
+		movement := models.CreateRepositoryTransaction{}
+		if err = rows.Scan(&movement.ProductID, &movement.VariantID, &movement.Price, &movement.Quantity); err != nil {
+			fmt.Println("error is while scanning sale repository transaction", err.Error())
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+	return movements, rows.Err()
+}
+
+// postSaleCommissions tops up the cashier and the shop assistant of the sale with the
+// commission of every line as sales transactions, the payroll pays them out. Staff without a
+// tariff earn nothing.
+func postSaleCommissions(ctx context.Context, tx pgx.Tx, saleID string) error {
+	var cashierID, assistantID string
+	if err := tx.QueryRow(ctx, `select coalesce(cashier_id::text, ''), coalesce(shop_assistant_id, '') from sales where id = $1`,
+		saleID).Scan(&cashierID, &assistantID); err != nil {
+		fmt.Println("error is while selecting sale staff", err.Error())
+		return err
+	}
+
+	rows, err := tx.Query(ctx, `select id from baskets where sale_id = $1 and deleted_at is null`, saleID)
+	if err != nil {
+		fmt.Println("error is while selecting baskets", err.Error())
+		return err
+	}
+
+	basketIDs := []string{}
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			fmt.Println("error is while scanning basket", err.Error())
+			return err
+		}
+		basketIDs = append(basketIDs, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	staffIDs := []string{cashierID}
+	if assistantID != cashierID {
+		staffIDs = append(staffIDs, assistantID)
+	}
+
+	for _, staffID := range staffIDs {
+		if staffID == "" {
+			continue
+		}
+
+		amount := 0.0
+		for _, basketID := range basketIDs {
+			commission, err := calculateCommission(ctx, tx, models.CommissionRequest{StaffID: staffID, BasketID: basketID})
+			if errors.Is(err, storage.ErrNoTariff) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			amount += commission.Amount
+		}
+
+		if amount <= 0 {
+			continue
+		}
+
+		if _, err = insertTransaction(ctx, tx, models.CreateTransaction{
+			SaleID:          saleID,
+			StaffID:         staffID,
+			TransactionType: "topup",
+			SourceType:      "sales",
+			Amount:          roundMoney(amount),
+			Description:     "commission",
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reverseSaleCommissions withdraws the commissions a refunded sale paid.
+func reverseSaleCommissions(ctx context.Context, tx pgx.Tx, saleID string) error {
+	rows, err := tx.Query(ctx, `select staff_id, sum(case when transaction_type = 'topup' then amount else -amount end) 
+		from transactions where sale_id = $1 and source_type = 'sales' and deleted_at is null group by staff_id`, saleID)
+	if err != nil {
+		fmt.Println("error is while selecting sale commissions", err.Error())
+		return err
 	}
-	return id, nil
+
+	commissions := []models.CreateTransaction{}
+	for rows.Next() {
+		commission := models.CreateTransaction{
+			SaleID:          saleID,
+			TransactionType: "withdraw",
+			SourceType:      "sales",
+			Description:     "commission refund",
+		}
+		if err = rows.Scan(&commission.StaffID, &commission.Amount); err != nil {
+			rows.Close()
+			fmt.Println("error is while scanning sale commission", err.Error())
+			return err
+		}
+		if commission.Amount > 0 {
+			commissions = append(commissions, commission)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, commission := range commissions {
+		if _, err = insertTransaction(ctx, tx, commission); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,395 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type salesTargetRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewSalesTargetRepo(DB *pgxpool.Pool) storage.ISalesTargetRepo {
+	return &salesTargetRepo{
+		DB: DB,
+	}
+}
+
+func (s *salesTargetRepo) Create(ctx context.Context, target models.CreateSalesTarget) (string, error) {
+	id := uuid.New().String()
+
+	month, err := time.Parse("2006-01", target.Month)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `insert into sales_targets (id, staff_id, branch_id, month, created_by) 
+		values ($1, nullif($2, '')::uuid, nullif($3, '')::uuid, $4, $5)`,
+		id,
+		target.StaffID,
+		target.BranchID,
+		month,
+		target.CreatedBy,
+	); err != nil {
+		log.Println("Error while inserting sales target", err)
+		return "", err
+	}
+
+	for _, tier := range target.Tiers {
+		if _, err := tx.Exec(ctx, `insert into sales_target_tiers (id, sales_target_id, threshold, bonus) 
+			values ($1, $2, $3, $4)`, uuid.New(), id, tier.Threshold, tier.Bonus); err != nil {
+			log.Println("Error while inserting sales target tier", err)
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing sales target", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *salesTargetRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.SalesTarget, error) {
+	target := models.SalesTarget{}
+
+	if err := s.DB.QueryRow(ctx, `select `+salesTargetColumns+` from sales_targets t 
+		where t.id = $1 and t.deleted_at is null`, key.ID).Scan(
+		&target.ID,
+		&target.StaffID,
+		&target.BranchID,
+		&target.Month,
+		&target.CreatedBy,
+		&target.CreatedAt,
+		&target.UpdatedAt,
+	); err != nil {
+		log.Println("Error while selecting sales target", err)
+		return models.SalesTarget{}, err
+	}
+
+	tiers, err := s.getTiers(ctx, target.ID)
+	if err != nil {
+		return models.SalesTarget{}, err
+	}
+	target.Tiers = tiers
+
+	return target, nil
+}
+
+func (s *salesTargetRepo) GetList(ctx context.Context, request models.SalesTargetGetListRequest) (models.SalesTargetsResponse, error) {
+	var (
+		targets = []models.SalesTarget{}
+		count   int
+		filter  = ` where t.deleted_at is null`
+		args    = []interface{}{}
+	)
+
+	if request.Month != "" {
+		month, err := time.Parse("2006-01", request.Month)
+		if err != nil {
+			return models.SalesTargetsResponse{}, err
+		}
+		args = append(args, month)
+		filter += fmt.Sprintf(` and t.month = $%d`, len(args))
+	}
+
+	if request.StaffID != "" {
+		args = append(args, request.StaffID)
+		filter += fmt.Sprintf(` and t.staff_id = $%d`, len(args))
+	}
+
+	// the branch targets and the targets of the staff of the branch
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and (t.branch_id = $%d or t.staff_id in (select id from staffs where branch_id = $%d))`,
+			len(args), len(args))
+	}
+
+	if err := s.DB.QueryRow(ctx, `select count(*) from sales_targets t`+filter, args...).Scan(&count); err != nil {
+		log.Println("Error while counting sales targets", err)
+		return models.SalesTargetsResponse{}, err
+	}
+
+	args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	rows, err := s.DB.Query(ctx, `select `+salesTargetColumns+` from sales_targets t`+filter+
+		fmt.Sprintf(` order by t.month desc, t.created_at desc limit $%d offset $%d`, len(args)-1, len(args)), args...)
+	if err != nil {
+		log.Println("Error while selecting sales targets", err)
+		return models.SalesTargetsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		target := models.SalesTarget{}
+		if err := rows.Scan(
+			&target.ID,
+			&target.StaffID,
+			&target.BranchID,
+			&target.Month,
+			&target.CreatedBy,
+			&target.CreatedAt,
+			&target.UpdatedAt,
+		); err != nil {
+			log.Println("Error while scanning sales target", err)
+			return models.SalesTargetsResponse{}, err
+		}
+		targets = append(targets, target)
+	}
+	rows.Close()
+
+	for i := range targets {
+		if targets[i].Tiers, err = s.getTiers(ctx, targets[i].ID); err != nil {
+			return models.SalesTargetsResponse{}, err
+		}
+	}
+
+	return models.SalesTargetsResponse{
+		SalesTargets: targets,
+		Count:        count,
+	}, nil
+}
+
+// Delete removes a target that has not paid any bonus yet.
+func (s *salesTargetRepo) Delete(ctx context.Context, id string) error {
+	result, err := s.DB.Exec(ctx, `update sales_targets set deleted_at = now() where id = $1 and deleted_at is null 
+		and not exists (select 1 from sales_target_payouts p where p.sales_target_id = $1)`, id)
+	if err != nil {
+		log.Println("Error while deleting sales target", err)
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("sales target is not found or has already paid bonuses")
+	}
+
+	return nil
+}
+
+// Progress sums the completed sales of the month for the targets of the staff, together with
+// the target of their branch, or for the targets of the branch and of its staff.
+func (s *salesTargetRepo) Progress(ctx context.Context, request models.SalesTargetProgressRequest) ([]models.SalesTargetProgress, error) {
+	month, err := time.Parse("2006-01", request.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		progress = []models.SalesTargetProgress{}
+		filter   = ` where t.deleted_at is null and t.month = $1`
+		args     = []interface{}{month}
+	)
+
+	if request.StaffID != "" {
+		args = append(args, request.StaffID)
+		filter += fmt.Sprintf(` and (t.staff_id = $%d or t.branch_id = (select branch_id from staffs where id = $%d))`,
+			len(args), len(args))
+	}
+
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and (t.branch_id = $%d or t.staff_id in (select id from staffs where branch_id = $%d))`,
+			len(args), len(args))
+	}
+
+	rows, err := s.DB.Query(ctx, `select t.id, coalesce(t.staff_id::text, ''), coalesce(t.branch_id::text, ''), 
+		to_char(t.month, 'YYYY-MM'), `+salesTargetSales+` from sales_targets t`+filter+` order by t.branch_id nulls last, t.staff_id`, args...)
+	if err != nil {
+		log.Println("Error while selecting sales target progress", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		target := models.SalesTargetProgress{}
+		if err := rows.Scan(
+			&target.TargetID,
+			&target.StaffID,
+			&target.BranchID,
+			&target.Month,
+			&target.Sales,
+		); err != nil {
+			log.Println("Error while scanning sales target progress", err)
+			return nil, err
+		}
+		progress = append(progress, target)
+	}
+	rows.Close()
+
+	for i := range progress {
+		target := &progress[i]
+
+		tierRows, err := s.DB.Query(ctx, `select r.threshold, r.bonus, 
+			exists (select 1 from sales_target_payouts p where p.tier_id = r.id 
+			    and (p.staff_id = nullif($2, '')::uuid or $2 = ''))
+				from sales_target_tiers r where r.sales_target_id = $1 order by r.threshold`, target.TargetID, request.StaffID)
+		if err != nil {
+			log.Println("Error while selecting sales target tiers", err)
+			return nil, err
+		}
+
+		target.Tiers = []models.SalesTargetTierProgress{}
+		for tierRows.Next() {
+			tier := models.SalesTargetTierProgress{}
+			if err := tierRows.Scan(&tier.Threshold, &tier.Bonus, &tier.Paid); err != nil {
+				tierRows.Close()
+				log.Println("Error while scanning sales target tier", err)
+				return nil, err
+			}
+			tier.Reached = target.Sales >= tier.Threshold
+			if !tier.Reached && target.NextThreshold == 0 {
+				target.NextThreshold = tier.Threshold
+				target.Remaining = tier.Threshold - target.Sales
+			}
+			target.Tiers = append(target.Tiers, tier)
+		}
+		tierRows.Close()
+	}
+
+	return progress, nil
+}
+
+// Evaluate pays the bonus of every reached tier of the targets of the closed months from the
+// month of from on, so a refund later in the month can not take a paid tier back below its
+// threshold. A tier pays a staff once, a branch tier pays every staff who was the cashier or
+// the shop assistant of a completed sale of the branch that month, wherever they work now.
+// It returns the number of bonuses paid.
+func (s *salesTargetRepo) Evaluate(ctx context.Context, from time.Time) (int, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	type payout struct {
+		targetID, tierID, staffID, month string
+		bonus, threshold                 float64
+	}
+
+	rows, err := tx.Query(ctx, `
+		with progress as (
+			select t.id, t.staff_id, t.branch_id, t.month, `+salesTargetSales+` as sales
+				from sales_targets t where t.deleted_at is null and t.month >= date_trunc('month', $1::timestamp) 
+				  and t.month < date_trunc('month', now())
+		)
+		select p.id, r.id, coalesce(p.staff_id, b.id)::text, to_char(p.month, 'YYYY-MM'), r.bonus, r.threshold
+			from progress p
+				join sales_target_tiers r on r.sales_target_id = p.id and r.threshold <= p.sales
+				left join lateral (
+					select st.id from staffs st 
+						where p.branch_id is not null and st.deleted_at is null 
+						  and exists (select 1 from sales sa where sa.status = 'success' and sa.deleted_at is null 
+						      and sa.created_at >= p.month and sa.created_at < p.month + interval '1 month' 
+						      and sa.branch_id = p.branch_id
+						      and (sa.cashier_id = st.id or sa.shop_assistant_id = st.id::text))
+				) b on true
+			where coalesce(p.staff_id, b.id) is not null 
+			  and not exists (select 1 from sales_target_payouts o where o.tier_id = r.id and o.staff_id = coalesce(p.staff_id, b.id))`,
+		from)
+	if err != nil {
+		log.Println("Error while selecting reached sales targets", err)
+		return 0, err
+	}
+
+	payouts := []payout{}
+	for rows.Next() {
+		p := payout{}
+		if err := rows.Scan(&p.targetID, &p.tierID, &p.staffID, &p.month, &p.bonus, &p.threshold); err != nil {
+			rows.Close()
+			log.Println("Error while scanning reached sales target", err)
+			return 0, err
+		}
+		payouts = append(payouts, p)
+	}
+	rows.Close()
+
+	paid := 0
+	for _, p := range payouts {
+		var payoutID string
+		if err := tx.QueryRow(ctx, `insert into sales_target_payouts (id, sales_target_id, tier_id, staff_id, amount) 
+			values ($1, $2, $3, $4, $5) on conflict (tier_id, staff_id) do nothing returning id`,
+			uuid.New(), p.targetID, p.tierID, p.staffID, p.bonus).Scan(&payoutID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			log.Println("Error while inserting sales target payout", err)
+			return 0, err
+		}
+
+		transactionID, err := insertTransaction(ctx, tx, models.CreateTransaction{
+			StaffID:         p.staffID,
+			TransactionType: "topup",
+			SourceType:      "bonus",
+			Amount:          p.bonus,
+			Description:     fmt.Sprintf("sales target %s, %g reached", p.month, p.threshold),
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := tx.Exec(ctx, `update sales_target_payouts set transaction_id = $2 where id = $1`,
+			payoutID, transactionID); err != nil {
+			log.Println("Error while updating sales target payout", err)
+			return 0, err
+		}
+		paid++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing sales target payouts", err)
+		return 0, err
+	}
+
+	return paid, nil
+}
+
+func (s *salesTargetRepo) getTiers(ctx context.Context, targetID string) ([]models.SalesTargetTier, error) {
+	tiers := []models.SalesTargetTier{}
+
+	rows, err := s.DB.Query(ctx, `select id, threshold, bonus from sales_target_tiers 
+		where sales_target_id = $1 order by threshold`, targetID)
+	if err != nil {
+		log.Println("Error while selecting sales target tiers", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		tier := models.SalesTargetTier{}
+		if err := rows.Scan(&tier.ID, &tier.Threshold, &tier.Bonus); err != nil {
+			log.Println("Error while scanning sales target tier", err)
+			return nil, err
+		}
+		tiers = append(tiers, tier)
+	}
+
+	return tiers, rows.Err()
+}
+
+const salesTargetColumns = `t.id, coalesce(t.staff_id::text, ''), coalesce(t.branch_id::text, ''), to_char(t.month, 'YYYY-MM'), 
+	t.created_by, t.created_at, t.updated_at`
+
+// salesTargetSales is the completed sales of the month of the target t, where the staff was
+// the cashier or the shop assistant for a staff target.
+const salesTargetSales = `(select coalesce(sum(sa.price), 0) from sales sa 
+	where sa.status = 'success' and sa.deleted_at is null 
+	  and sa.created_at >= t.month and sa.created_at < t.month + interval '1 month' 
+	  and case when t.staff_id is not null then sa.cashier_id = t.staff_id or sa.shop_assistant_id = t.staff_id::text 
+	      else sa.branch_id = t.branch_id end)`
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"log"
 	"sell/api/models"
+	"sell/pkg/security"
 	"sell/storage"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -32,29 +34,59 @@ func (s *staffRepo) Create(ctx context.Context, staff models.CreateStaff) (strin
 	}
 	age := uint(time.Since(birthDate).Hours() / 24 / 365)
 
-	if _, err := s.DB.Exec(ctx, `INSERT INTO staffs 
-		(id, branch_id, tariff_id, staff_type, name, balance, age, birth_date, login, password)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+	password, err := security.HashPassword(staff.Password)
+	if err != nil {
+		log.Println("Error while hashing password", err)
+		return "", err
+	}
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `INSERT INTO staffs 
+		(id, branch_id, tariff_id, staff_type, name, age, birth_date, login, password)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
 		id,
 		staff.BranchID,
 		staff.TariffID,
 		staff.StaffType,
 		staff.Name,
-		staff.Balance,
 		age,
 		birthDate,
 		staff.Login,
-		staff.Password,
+		password,
 	); err != nil {
 		log.Println("Error while inserting data ", err)
 		return "", err
 	}
+
+	if err := insertPasswordHistory(ctx, tx, id, password); err != nil {
+		return "", err
+	}
+
+	if err := assignStaff(ctx, tx, models.CreateStaffAssignment{
+		StaffID:  id,
+		BranchID: staff.BranchID,
+		TariffID: staff.TariffID,
+	}, time.Now()); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing staff", err)
+		return "", err
+	}
+
 	return id, nil
 }
 
 func (s *staffRepo) StaffByID(ctx context.Context, id models.PrimaryKey) (models.Staff, error) {
 	staff := models.Staff{}
-	query := `SELECT id, branch_id, tariff_id, staff_type, name, balance, age, birth_date::text, login, created_at, updated_at 
+	query := `SELECT id, coalesce(branch_id::text, ''), coalesce(tariff_id::text, ''), staff_type, name, balance, age, birth_date::text, login, created_at, updated_at 
 						FROM staffs WHERE id = $1 and deleted_at is null
 `
 
@@ -79,33 +111,37 @@ func (s *staffRepo) StaffByID(ctx context.Context, id models.PrimaryKey) (models
 	return staff, nil
 }
 
-func (s *staffRepo) GetStaffTList(ctx context.Context, request models.GetListRequest) (models.StaffsResponse, error) {
+func (s *staffRepo) GetStaffTList(ctx context.Context, request models.StaffGetListRequest) (models.StaffsResponse, error) {
 	var (
 		staffs []models.Staff
 		count  int
+		filter string
+		args   = []interface{}{}
 	)
 
-	countQuery := `SELECT COUNT(*) FROM staffs where deleted_at is null`
 	if request.Search != "" {
-		countQuery += fmt.Sprintf(` and name ILIKE '%s'`, request.Search)
+		args = append(args, request.Search)
+		filter += fmt.Sprintf(` and name ILIKE $%d`, len(args))
 	}
 
-	err := s.DB.QueryRow(ctx, countQuery).Scan(&count)
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and branch_id = $%d`, len(args))
+	}
+
+	err := s.DB.QueryRow(ctx, `SELECT COUNT(*) FROM staffs where deleted_at is null`+filter, args...).Scan(&count)
 	if err != nil {
 		log.Println("Error while scanning count of staffs:", err)
 		return models.StaffsResponse{}, err
 	}
 
-	query := `SELECT id, branch_id, tariff_id, staff_type, name, balance, age, 
+	args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	query := `SELECT id, coalesce(branch_id::text, ''), coalesce(tariff_id::text, ''), staff_type, name, balance, age, 
        				birth_date::text, login, created_at, updated_at
-						FROM staffs where deleted_at is null
-`
-	if request.Search != "" {
-		query += fmt.Sprintf(` and name ILIKE '%s'`, request.Search)
-	}
-	query += ` order by created_at desc LIMIT $1 OFFSET $2 `
+						FROM staffs where deleted_at is null` + filter +
+		fmt.Sprintf(` order by created_at desc LIMIT $%d OFFSET $%d `, len(args)-1, len(args))
 
-	rows, err := s.DB.Query(ctx, query, request.Limit, (request.Page-1)*request.Limit)
+	rows, err := s.DB.Query(ctx, query, args...)
 	if err != nil {
 		log.Println("Error while querying staff :", err)
 		return models.StaffsResponse{}, err
@@ -140,16 +176,30 @@ func (s *staffRepo) GetStaffTList(ctx context.Context, request models.GetListReq
 	}, nil
 }
 
+// UpdateStaff records a new assignment from now on when the branch or the tariff changes.
 func (s *staffRepo) UpdateStaff(ctx context.Context, staff models.UpdateStaff) (string, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var branchID, tariffID string
+	if err := tx.QueryRow(ctx, `SELECT coalesce(branch_id::text, ''), coalesce(tariff_id::text, '') 
+		FROM staffs WHERE id = $1 FOR UPDATE`, staff.ID).Scan(&branchID, &tariffID); err != nil {
+		log.Println("Error while selecting Staff :", err)
+		return "", err
+	}
+
 	query := `UPDATE staffs SET branch_id = $1, tariff_id = $2, staff_type = $3, 
-                  name = $4, balance = $5, login = $6, updated_at = NOW() WHERE id = $7`
+                  name = $4, login = $5, updated_at = NOW() WHERE id = $6`
 
-	_, err := s.DB.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		&staff.BranchID,
 		&staff.TariffID,
 		&staff.StaffType,
 		&staff.Name,
-		&staff.Balance,
 		&staff.Login,
 		staff.ID,
 	)
@@ -158,6 +208,22 @@ func (s *staffRepo) UpdateStaff(ctx context.Context, staff models.UpdateStaff) (
 		return "", err
 	}
 
+	if branchID != staff.BranchID || tariffID != staff.TariffID {
+		if err := assignStaff(ctx, tx, models.CreateStaffAssignment{
+			StaffID:   staff.ID,
+			BranchID:  staff.BranchID,
+			TariffID:  staff.TariffID,
+			ChangedBy: staff.ChangedBy,
+		}, time.Now()); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing Staff :", err)
+		return "", err
+	}
+
 	return staff.ID, nil
 }
 
@@ -173,31 +239,164 @@ func (s *staffRepo) DeleteStaff(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *staffRepo) GetPassword(ctx context.Context, id string) (string, error) {
-	password := ""
+// GetCredentials returns the password hash and lockout state of the staff.
+func (s *staffRepo) GetCredentials(ctx context.Context, id string) (models.StaffCredentials, error) {
+	return s.credentials(ctx, `id = $1`, id)
+}
+
+// GetByLogin returns the id, role, password hash and lockout state of the staff with the login.
+func (s *staffRepo) GetByLogin(ctx context.Context, login string) (models.StaffCredentials, error) {
+	return s.credentials(ctx, `login = $1`, login)
+}
+
+func (s *staffRepo) credentials(ctx context.Context, where string, arg string) (models.StaffCredentials, error) {
+	credentials := models.StaffCredentials{}
 
 	query := `
-		select password from staffs 
-		                where  id = $1`
+		select id, coalesce(branch_id::text, ''), staff_type, coalesce(password, ''), 
+		       failed_logins, locked_until, must_change_password from staffs 
+		                where ` + where + ` and deleted_at is null`
 
-	if err := s.DB.QueryRow(ctx, query, id).Scan(&password); err != nil {
-		fmt.Println("Error while scanning password from users", err.Error())
-		return "", err
+	if err := s.DB.QueryRow(ctx, query, arg).Scan(
+		&credentials.ID,
+		&credentials.BranchID,
+		&credentials.StaffType,
+		&credentials.Password,
+		&credentials.FailedLogins,
+		&credentials.LockedUntil,
+		&credentials.MustChangePassword,
+	); err != nil {
+		return models.StaffCredentials{}, err
 	}
 
-	return password, nil
+	return credentials, nil
 }
 
+// UpdatePassword stores the new password, keeps it in the history
+// and clears the lockout and a pending forced change.
 func (s *staffRepo) UpdatePassword(ctx context.Context, request models.UpdateStaffPassword) error {
+	password, err := security.HashPassword(request.NewPassword)
+	if err != nil {
+		fmt.Println("error while hashing password for staff", err.Error())
+		return err
+	}
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		fmt.Println("error while starting transaction", err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := setPassword(ctx, tx, request.ID, password); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// setPassword stores the hashed password of the staff in the transaction and keeps it in
+// the history.
+func setPassword(ctx context.Context, tx pgx.Tx, staffID, password string) error {
 	query := `
 		update staffs 
-				set password = $1
+				set password = $1, failed_logins = 0, locked_until = null, 
+				    must_change_password = false, updated_at = now()
 					where id = $2`
 
-	if _, err := s.DB.Exec(ctx, query, request.NewPassword, request.ID); err != nil {
+	if _, err := tx.Exec(ctx, query, password, staffID); err != nil {
 		fmt.Println("error while updating password for staff", err.Error())
 		return err
 	}
 
+	return insertPasswordHistory(ctx, tx, staffID, password)
+}
+
+// PasswordHistory returns the hashes of the last passwords of the staff, the current one first.
+func (s *staffRepo) PasswordHistory(ctx context.Context, id string, limit int) ([]string, error) {
+	rows, err := s.DB.Query(ctx, `select password from staff_password_history 
+		where staff_id = $1 order by created_at desc limit $2`, id, limit)
+	if err != nil {
+		log.Println("Error while selecting password history", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := []string{}
+	for rows.Next() {
+		hash := ""
+		if err := rows.Scan(&hash); err != nil {
+			log.Println("Error while scanning password history", err)
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, rows.Err()
+}
+
+// RegisterFailedLogin counts a wrong password. The attempt that reaches maxAttempts locks the staff
+// until lockedUntil and starts the count again.
+func (s *staffRepo) RegisterFailedLogin(ctx context.Context, id string, maxAttempts int, lockedUntil time.Time) error {
+	if _, err := s.DB.Exec(ctx, `update staffs set 
+		locked_until = case when failed_logins + 1 >= $2 then $3 else locked_until end,
+		failed_logins = case when failed_logins + 1 >= $2 then 0 else failed_logins + 1 end
+			where id = $1`, id, maxAttempts, lockedUntil); err != nil {
+		log.Println("Error while registering failed login", err)
+		return err
+	}
+
+	return nil
+}
+
+// ResetFailedLogins forgets the wrong passwords after a successful login.
+func (s *staffRepo) ResetFailedLogins(ctx context.Context, id string) error {
+	if _, err := s.DB.Exec(ctx, `update staffs set failed_logins = 0, locked_until = null 
+		where id = $1 and (failed_logins <> 0 or locked_until is not null)`, id); err != nil {
+		log.Println("Error while resetting failed logins", err)
+		return err
+	}
+
+	return nil
+}
+
+// EnsureOwner creates the first owner so somebody can log in on a new database,
+// it does nothing when an owner already exists.
+func (s *staffRepo) EnsureOwner(ctx context.Context, login, password string) error {
+	hash, err := security.HashPassword(password)
+	if err != nil {
+		log.Println("Error while hashing password", err)
+		return err
+	}
+
+	if _, err := s.DB.Exec(ctx, `with owner as (
+		insert into staffs (id, staff_type, name, login, password)
+			select $1, 'owner', $2, $2, $3 
+				where not exists (select 1 from staffs where staff_type = 'owner' and deleted_at is null)
+			returning id, password
+		)
+		insert into staff_password_history (id, staff_id, password) select $4, id, password from owner`,
+		uuid.New().String(),
+		login,
+		hash,
+		uuid.New().String(),
+	); err != nil {
+		log.Println("Error while creating owner", err)
+		return err
+	}
+
+	return nil
+}
+
+func insertPasswordHistory(ctx context.Context, tx pgx.Tx, staffID, password string) error {
+	if _, err := tx.Exec(ctx, `insert into staff_password_history (id, staff_id, password) values ($1, $2, $3)`,
+		uuid.New().String(),
+		staffID,
+		password,
+	); err != nil {
+		log.Println("Error while inserting password history", err)
+		return err
+	}
+
 	return nil
 }
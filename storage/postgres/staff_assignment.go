@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sell/api/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Assign records the branch and tariff of the staff from the given time on, it may be in the past
+// to correct the history. The staffs row keeps the current assignment.
+func (s *staffRepo) Assign(ctx context.Context, assignment models.CreateStaffAssignment, from time.Time) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := assignStaff(ctx, tx, assignment, from); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `update staffs s set branch_id = a.branch_id, tariff_id = a.tariff_id, updated_at = now() 
+		from staff_assignments a where s.id = $1 and a.staff_id = s.id and a.effective_to is null`, assignment.StaffID); err != nil {
+		log.Println("Error while updating current assignment of staff", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing staff assignment", err)
+		return err
+	}
+
+	return nil
+}
+
+// Assignments returns the assignment history of the staff, the latest first.
+func (s *staffRepo) Assignments(ctx context.Context, staffID string) ([]models.StaffAssignment, error) {
+	assignments := []models.StaffAssignment{}
+
+	rows, err := s.DB.Query(ctx, `select id, staff_id, coalesce(branch_id::text, ''), coalesce(tariff_id::text, ''), 
+       effective_from, effective_to, coalesce(changed_by::text, ''), created_at 
+			from staff_assignments where staff_id = $1 order by effective_from desc`, staffID)
+	if err != nil {
+		log.Println("Error while selecting staff assignments", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		assignment := models.StaffAssignment{}
+		if err := rows.Scan(
+			&assignment.ID,
+			&assignment.StaffID,
+			&assignment.BranchID,
+			&assignment.TariffID,
+			&assignment.EffectiveFrom,
+			&assignment.EffectiveTo,
+			&assignment.ChangedBy,
+			&assignment.CreatedAt,
+		); err != nil {
+			log.Println("Error while scanning staff assignment", err)
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	return assignments, rows.Err()
+}
+
+// assignStaff puts an assignment into the history from the given time up to the next recorded
+// change. The period it starts in is cut there, one starting at the very same time is replaced.
+func assignStaff(ctx context.Context, tx pgx.Tx, assignment models.CreateStaffAssignment, from time.Time) error {
+	// one change of the staff at a time
+	if _, err := tx.Exec(ctx, `select 1 from staffs where id = $1 for update`, assignment.StaffID); err != nil {
+		log.Println("Error while locking staff", err)
+		return err
+	}
+
+	var (
+		currentID   string
+		currentFrom time.Time
+		currentTo   *time.Time
+	)
+	err := tx.QueryRow(ctx, `select id, effective_from, effective_to from staff_assignments 
+		where staff_id = $1 and effective_from <= $2 and (effective_to is null or effective_to > $2)`,
+		assignment.StaffID, from).Scan(&currentID, &currentFrom, &currentTo)
+
+	switch {
+	case err == nil && currentFrom.Equal(from):
+		if _, err := tx.Exec(ctx, `update staff_assignments set branch_id = nullif($2, '')::uuid, 
+			tariff_id = nullif($3, '')::uuid, changed_by = nullif($4, '')::uuid where id = $1`,
+			currentID, assignment.BranchID, assignment.TariffID, assignment.ChangedBy); err != nil {
+			log.Println("Error while replacing staff assignment", err)
+			return err
+		}
+		return nil
+	case err == nil:
+		if _, err := tx.Exec(ctx, `update staff_assignments set effective_to = $2 where id = $1`, currentID, from); err != nil {
+			log.Println("Error while closing staff assignment", err)
+			return err
+		}
+	case errors.Is(err, pgx.ErrNoRows):
+		// before the first recorded assignment, the new one lasts up to it
+		if err := tx.QueryRow(ctx, `select min(effective_from) from staff_assignments 
+			where staff_id = $1 and effective_from > $2`, assignment.StaffID, from).Scan(&currentTo); err != nil {
+			log.Println("Error while selecting next staff assignment", err)
+			return err
+		}
+	default:
+		log.Println("Error while selecting staff assignment", err)
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `insert into staff_assignments 
+		(id, staff_id, branch_id, tariff_id, effective_from, effective_to, changed_by) 
+			values ($1, $2, nullif($3, '')::uuid, nullif($4, '')::uuid, $5, $6, nullif($7, '')::uuid)`,
+		uuid.New().String(),
+		assignment.StaffID,
+		assignment.BranchID,
+		assignment.TariffID,
+		from,
+		currentTo,
+		assignment.ChangedBy,
+	); err != nil {
+		log.Println("Error while inserting staff assignment", err)
+		return err
+	}
+
+	return nil
+}
+
+// staffTariffAt joins the tariff t of the staff s valid at $2, the staffs row is used for the
+// times before the recorded history.
+const staffTariffAt = `
+	left join lateral (select a.id, a.tariff_id from staff_assignments a 
+		where a.staff_id = s.id and a.effective_from <= $2 and (a.effective_to is null or a.effective_to > $2)) a on true
+	join staff_tariffs t on t.id = case when a.id is not null then a.tariff_id else s.tariff_id end`
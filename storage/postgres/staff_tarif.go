@@ -24,13 +24,14 @@ func (s *staffTariffRepo) Create(ctx context.Context, tariff models.CreateStaffT
 	id := uuid.New().String()
 
 	if _, err := s.DB.Exec(ctx, `INSERT INTO staff_tariffs 
-	(id, name, tariff_type, amount_for_cash, amount_for_card) 
-		VALUES ($1, $2, $3, $4, $5)`,
+	(id, name, tariff_type, amount_for_cash, amount_for_card, base_salary) 
+		VALUES ($1, $2, $3, $4, $5, $6)`,
 		id,
 		tariff.Name,
 		tariff.TariffType,
 		tariff.AmountForCash,
 		tariff.AmountForCard,
+		tariff.BaseSalary,
 	); err != nil {
 		log.Println("Error while inserting data:", err)
 		return "", err
@@ -41,7 +42,7 @@ func (s *staffTariffRepo) Create(ctx context.Context, tariff models.CreateStaffT
 
 func (s *staffTariffRepo) GetStaffTariffByID(ctx context.Context, id models.PrimaryKey) (models.StaffTariff, error) {
 	staffTariff := models.StaffTariff{}
-	query := `SELECT id, name, tariff_type, amount_for_cash, amount_for_card, created_at, updated_at 
+	query := `SELECT id, name, tariff_type, amount_for_cash, amount_for_card, base_salary, created_at, updated_at 
 								FROM staff_tariffs WHERE id = $1 and deleted_at is null`
 	err := s.DB.QueryRow(ctx, query, id.ID).Scan(
 		&staffTariff.ID,
@@ -49,6 +50,7 @@ func (s *staffTariffRepo) GetStaffTariffByID(ctx context.Context, id models.Prim
 		&staffTariff.TariffType,
 		&staffTariff.AmountForCash,
 		&staffTariff.AmountForCard,
+		&staffTariff.BaseSalary,
 		&staffTariff.CreatedAt,
 		&staffTariff.UpdatedAt,
 	)
@@ -56,6 +58,10 @@ func (s *staffTariffRepo) GetStaffTariffByID(ctx context.Context, id models.Prim
 		log.Println("Error while selecting staff tariff by ID:", err)
 		return models.StaffTariff{}, err
 	}
+
+	if staffTariff.Rules, err = commissionRules(ctx, s.DB, staffTariff.ID); err != nil {
+		return models.StaffTariff{}, err
+	}
 	return staffTariff, nil
 }
 
@@ -76,7 +82,7 @@ func (s *staffTariffRepo) GetStaffTariffList(ctx context.Context, request models
 		return models.StaffTariffResponse{}, err
 	}
 
-	query := `SELECT id, name, tariff_type, amount_for_cash, amount_for_card, created_at, updated_at FROM staff_tariffs where deleted_at is null`
+	query := `SELECT id, name, tariff_type, amount_for_cash, amount_for_card, base_salary, created_at, updated_at FROM staff_tariffs where deleted_at is null`
 	if request.Search != "" {
 		query += fmt.Sprintf(` and name ILIKE '%s'`, request.Search)
 	}
@@ -97,6 +103,7 @@ func (s *staffTariffRepo) GetStaffTariffList(ctx context.Context, request models
 			&staffTariff.TariffType,
 			&staffTariff.AmountForCash,
 			&staffTariff.AmountForCard,
+			&staffTariff.BaseSalary,
 			&staffTariff.CreatedAt,
 			&staffTariff.UpdatedAt,
 		)
@@ -115,13 +122,14 @@ func (s *staffTariffRepo) GetStaffTariffList(ctx context.Context, request models
 
 func (s *staffTariffRepo) UpdateStaffTariff(ctx context.Context, tariff models.UpdateStaffTariff) (string, error) {
 	query := `UPDATE staff_tariffs SET name = $1, tariff_type = $2, amount_for_cash = $3, 
-                         amount_for_card = $4, updated_at = NOW() WHERE id = $5 and deleted_at is null`
+                         amount_for_card = $4, base_salary = $5, updated_at = NOW() WHERE id = $6 and deleted_at is null`
 
 	_, err := s.DB.Exec(ctx, query,
 		tariff.Name,
 		tariff.TariffType,
 		tariff.AmountForCash,
 		tariff.AmountForCard,
+		tariff.BaseSalary,
 		tariff.ID,
 	)
 	if err != nil {
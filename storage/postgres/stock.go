@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type stockRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewStockRepo(DB *pgxpool.Pool) storage.IStockRepo {
+	return &stockRepo{
+		DB: DB,
+	}
+}
+
+// Mismatches recomputes the quantity of every product of the branch from the
+// repository transactions ledger and returns the rows that differ from repositories.
+// An empty branch id checks all branches.
+func (s *stockRepo) Mismatches(ctx context.Context, branchID string) (models.StockReconcileResponse, error) {
+	return stockMismatches(ctx, s.DB, branchID)
+}
+
+func stockMismatches(ctx context.Context, db querier, branchID string) (models.StockReconcileResponse, error) {
+	mismatches := []models.StockMismatch{}
+
+	query := `
+		with ledger as (
+			select branch_id, product_id, coalesce(variant_id::text, '') as variant_id,
+			       sum(case when repository_transaction_type = 'plus' then quantity else -quantity end) as expected
+			from repository_transactions
+			where deleted_at is null and branch_id is not null
+			group by 1, 2, 3
+		), stock as (
+			select branch_id, product_id, coalesce(variant_id::text, '') as variant_id, sum(count) as actual
+			from repositories
+			where deleted_at is null
+			group by 1, 2, 3
+		)
+		select coalesce(s.branch_id, l.branch_id)::text, coalesce(s.product_id, l.product_id)::text,
+		       coalesce(s.variant_id, l.variant_id), coalesce(l.expected, 0), coalesce(s.actual, 0)
+		from stock s
+		         full join ledger l on s.branch_id = l.branch_id and s.product_id = l.product_id and s.variant_id = l.variant_id
+		where coalesce(l.expected, 0) <> coalesce(s.actual, 0)
+		  and ($1 = '' or coalesce(s.branch_id, l.branch_id)::text = $1)
+		order by 1, 2, 3`
+
+	rows, err := db.Query(ctx, query, branchID)
+	if err != nil {
+		log.Println("Error while querying stock mismatches:", err)
+		return models.StockReconcileResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		mismatch := models.StockMismatch{}
+		if err := rows.Scan(
+			&mismatch.BranchID,
+			&mismatch.ProductID,
+			&mismatch.VariantID,
+			&mismatch.Expected,
+			&mismatch.Actual,
+		); err != nil {
+			log.Println("Error while scanning row of stock mismatches:", err)
+			return models.StockReconcileResponse{}, err
+		}
+		mismatch.Difference = mismatch.Actual - mismatch.Expected
+		mismatches = append(mismatches, mismatch)
+	}
+
+	return models.StockReconcileResponse{
+		Mismatches: mismatches,
+		Count:      len(mismatches),
+	}, nil
+}
+
+// Reconcile posts one corrective repository transaction per mismatch so that the
+// ledger matches the counted stock in repositories. The stock rows are locked before the
+// mismatches are read, a sale or intake moving them waits for the corrections.
+func (s *stockRepo) Reconcile(ctx context.Context, request models.StockReconcileRequest) (models.StockReconcileResponse, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction:", err)
+		return models.StockReconcileResponse{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `select id from repositories 
+		where deleted_at is null and ($1 = '' or branch_id::text = $1) for update`, request.BranchID); err != nil {
+		log.Println("Error while locking stock:", err)
+		return models.StockReconcileResponse{}, err
+	}
+
+	response, err := stockMismatches(ctx, tx, request.BranchID)
+	if err != nil {
+		return models.StockReconcileResponse{}, err
+	}
+
+	for _, mismatch := range response.Mismatches {
+		transactionType, quantity := "plus", mismatch.Difference
+		if quantity < 0 {
+			transactionType, quantity = "minus", -quantity
+		}
+
+		if _, err = tx.Exec(ctx, `INSERT INTO repository_transactions
+		(id, branch_id, staff_id, product_id, variant_id, repository_transaction_type, price, quantity)
+			VALUES($1, $2, nullif($3, '')::uuid, $4, nullif($5, '')::uuid, $6, 0, $7)`,
+			uuid.New(),
+			mismatch.BranchID,
+			request.StaffID,
+			mismatch.ProductID,
+			mismatch.VariantID,
+			transactionType,
+			quantity,
+		); err != nil {
+			log.Println("Error while inserting corrective transaction:", err)
+			return models.StockReconcileResponse{}, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing corrective transactions:", err)
+		return models.StockReconcileResponse{}, err
+	}
+
+	response.Corrected = true
+
+	return response, nil
+}
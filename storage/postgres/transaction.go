@@ -7,7 +7,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"sell/api/models"
 	"sell/storage"
-	"strconv"
+	"time"
 )
 
 type transactionRepo struct {
@@ -19,11 +19,33 @@ func NewTransactionRepo(db *pgxpool.Pool) storage.ITransactionStorage {
 }
 
 func (t transactionRepo) Create(ctx context.Context, trans models.CreateTransaction) (string, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := insertTransaction(ctx, tx, trans)
+	if err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing transaction", err.Error())
+		return "", err
+	}
+	return id, nil
+}
+
+// insertTransaction adds a transaction, moves the staff balance by it and posts it to the
+// general ledger, db should be a pgx.Tx so that all of it happens or none.
+func insertTransaction(ctx context.Context, db querier, trans models.CreateTransaction) (string, error) {
 	id := uuid.New()
 	query := `insert into transactions 
     					(id, sale_id, staff_id, transaction_type, source_type, amount, description) 
-						values ($1, $2, $3, $4, $5, $6, $7)`
-	if _, err := t.db.Exec(ctx, query, id,
+						values ($1, nullif($2, '')::uuid, $3, $4, $5, $6, $7)`
+	if _, err := db.Exec(ctx, query, id,
 		trans.SaleID,
 		trans.StaffID,
 		trans.TransactionType,
@@ -33,13 +55,45 @@ func (t transactionRepo) Create(ctx context.Context, trans models.CreateTransact
 		fmt.Println("error is while inserting data", err.Error())
 		return "", err
 	}
+
+	if err := moveBalance(ctx, db, trans.StaffID, signedAmount(trans.TransactionType, trans.Amount)); err != nil {
+		return "", err
+	}
+
+	if err := postTransaction(ctx, db, id.String(), trans); err != nil {
+		return "", err
+	}
 	return id.String(), nil
 }
 
+// moveBalance adds delta to the staff balance. It returns storage.ErrNotEnoughBalance instead
+// of leaving the balance below zero, which staffs_balance_non_negative would reject. Only
+// withdrawals are checked, a top up always lands.
+func moveBalance(ctx context.Context, db querier, staffID string, delta float64) error {
+	tag, err := db.Exec(ctx, `update staffs set balance = balance + $2, updated_at = now() 
+						where id = $1 and ($2::numeric >= 0 or balance + $2 >= 0)`, staffID, delta)
+	if err != nil {
+		fmt.Println("error is while updating staff balance", err.Error())
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotEnoughBalance
+	}
+	return nil
+}
+
+// signedAmount is how a transaction moves the balance, withdraws take it down.
+func signedAmount(transactionType string, amount float64) float64 {
+	if transactionType == "withdraw" {
+		return -amount
+	}
+	return amount
+}
+
 func (t transactionRepo) GetByID(ctx context.Context, id string) (models.Transaction, error) {
 	trans := models.Transaction{}
-	query := `select id, sale_id, staff_id, transaction_type, source_type, amount,
-       						description, created_at, updated_at
+	query := `select id, coalesce(sale_id::text, ''), staff_id, transaction_type, source_type, amount,
+       						coalesce(description, ''), created_at, updated_at
 							from transactions where deleted_at is null and id = $1`
 	if err := t.db.QueryRow(ctx, query, id).Scan(
 		&trans.ID,
@@ -59,48 +113,96 @@ func (t transactionRepo) GetByID(ctx context.Context, id string) (models.Transac
 
 func (t transactionRepo) GetList(ctx context.Context, request models.TransactionGetListRequest) (models.TransactionResponse, error) {
 	var (
-		page              = request.Page
-		offset            = (page - 1) * request.Limit
-		transactions      = []models.Transaction{}
-		fromAmount        = request.FromAmount
-		toAmount          = request.ToAmount
-		count             = 0
-		query, countQuery string
+		offset       = (request.Page - 1) * request.Limit
+		transactions = []models.Transaction{}
+		totals       = models.TransactionTotals{BySource: []models.TransactionSubtotal{}}
+		count        = 0
+		filter       = ` where deleted_at is null`
+		args         = []interface{}{}
 	)
 
-	countQuery = `select count(1) from transactions where deleted_at is null `
-	if fromAmount != 0 && toAmount != 0 {
-		countQuery += fmt.Sprintf(` and amount between %f and %f`, fromAmount, toAmount)
-	} else if fromAmount != 0 {
-		countQuery += ` and amount >= ` + strconv.FormatFloat(fromAmount, 'f', 2, 64)
-	} else {
-		countQuery += ` and amount <= ` + strconv.FormatFloat(toAmount, 'f', 2, 64)
-
+	if request.FromAmount != 0 {
+		args = append(args, request.FromAmount)
+		filter += fmt.Sprintf(` and amount >= $%d`, len(args))
 	}
-	if err := t.db.QueryRow(ctx, countQuery).Scan(&count); err != nil {
-		fmt.Println("error is while scanning row", err.Error())
-		return models.TransactionResponse{}, err
+	if request.ToAmount != 0 {
+		args = append(args, request.ToAmount)
+		filter += fmt.Sprintf(` and amount <= $%d`, len(args))
+	}
+	if request.StaffID != "" {
+		args = append(args, request.StaffID)
+		filter += fmt.Sprintf(` and staff_id = $%d`, len(args))
+	}
+	if request.SaleID != "" {
+		args = append(args, request.SaleID)
+		filter += fmt.Sprintf(` and sale_id = $%d`, len(args))
+	}
+	if request.TransactionType != "" {
+		args = append(args, request.TransactionType)
+		filter += fmt.Sprintf(` and transaction_type = $%d`, len(args))
+	}
+	if request.SourceType != "" {
+		args = append(args, request.SourceType)
+		filter += fmt.Sprintf(` and source_type = $%d`, len(args))
+	}
+	if !request.FromDate.IsZero() {
+		args = append(args, request.FromDate)
+		filter += fmt.Sprintf(` and created_at >= $%d`, len(args))
+	}
+	if !request.ToDate.IsZero() {
+		args = append(args, request.ToDate)
+		filter += fmt.Sprintf(` and created_at < $%d`, len(args))
 	}
 
-	query = `select id, sale_id, staff_id, transaction_type, source_type, amount,
-       						description, created_at, updated_at from transactions where deleted_at is null `
+	rows, err := t.db.Query(ctx, `select transaction_type, source_type, count(1), coalesce(sum(amount), 0) 
+						from transactions`+filter+` group by 1, 2 order by 1, 2`, args...)
+	if err != nil {
+		fmt.Println("error is while selecting transaction totals", err.Error())
+		return models.TransactionResponse{}, err
+	}
+	for rows.Next() {
+		subtotal := models.TransactionSubtotal{}
+		if err = rows.Scan(&subtotal.TransactionType, &subtotal.SourceType, &subtotal.Count, &subtotal.Amount); err != nil {
+			rows.Close()
+			fmt.Println("error is while scanning transaction totals", err.Error())
+			return models.TransactionResponse{}, err
+		}
 
-	if fromAmount != 0 && toAmount != 0 {
-		query += fmt.Sprintf(` and amount between %f and %f  order by amount asc, `, fromAmount, toAmount)
-	} else if fromAmount != 0 {
-		query += ` and amount >= ` + strconv.FormatFloat(fromAmount, 'f', 2, 64) + `  order by amount asc, `
-	} else {
-		query += ` and amount <= ` + strconv.FormatFloat(toAmount, 'f', 2, 64) + ` order by amount asc, `
+		if subtotal.TransactionType == "withdraw" {
+			totals.Withdraws += subtotal.Amount
+		} else {
+			totals.Topups += subtotal.Amount
+		}
+		count += subtotal.Count
+		totals.BySource = append(totals.BySource, subtotal)
+	}
+	rows.Close()
+	totals.Net = totals.Topups - totals.Withdraws
 
+	// the column and the direction come from a fixed set, never from the request as is
+	order := ` order by amount asc, created_at desc`
+	if request.SortBy != "" || request.SortOrder != "" {
+		column, direction := "created_at", "desc"
+		if request.SortBy == "amount" {
+			column = "amount"
+		}
+		if request.SortOrder == "asc" {
+			direction = "asc"
+		}
+		order = fmt.Sprintf(` order by %s %s, id`, column, direction)
 	}
 
-	query += ` created_at desc LIMIT $1 OFFSET $2 `
+	args = append(args, request.Limit, offset)
+	query := `select id, coalesce(sale_id::text, ''), staff_id, transaction_type, source_type, amount,
+       						coalesce(description, ''), created_at, updated_at from transactions` + filter + order +
+		fmt.Sprintf(` limit $%d offset $%d`, len(args)-1, len(args))
 
-	rows, err := t.db.Query(ctx, query, request.Limit, offset)
+	rows, err = t.db.Query(ctx, query, args...)
 	if err != nil {
 		fmt.Println("error is while selecting all from transactions", err.Error())
 		return models.TransactionResponse{}, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		trans := models.Transaction{}
@@ -122,14 +224,45 @@ func (t transactionRepo) GetList(ctx context.Context, request models.Transaction
 	return models.TransactionResponse{
 		Transactions: transactions,
 		Count:        count,
+		Totals:       totals,
 	}, nil
 }
 
+// generatedTransaction tells the transactions t other records point at, the opening balances
+// of 020 and the commissions of the sales. Changing them would leave those records behind.
+const generatedTransaction = `((t.source_type = 'adjustment' and t.description = 'opening balance')
+	or (t.source_type = 'sales' and t.sale_id is not null and t.description in ('commission', 'commission refund'))
+	or exists (select 1 from payroll_run_items i where t.id in (i.salary_transaction_id, i.payout_transaction_id))
+	or exists (select 1 from withdrawal_requests w where w.transaction_id = t.id)
+	or exists (select 1 from sales_target_payouts p where p.transaction_id = t.id))`
+
 func (t transactionRepo) Update(ctx context.Context, transaction models.UpdateTransaction) (string, error) {
-	query := `update transactions set sale_id = $1, staff_id = $2, transaction_type = $3, source_type = $4, amount = $5,
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		oldStaffID, oldType string
+		oldAmount           float64
+		generated           bool
+	)
+	if err = tx.QueryRow(ctx, `select staff_id, transaction_type, amount, `+generatedTransaction+` from transactions t 
+                    			where id = $1 and deleted_at is null for update`, transaction.ID).Scan(&oldStaffID, &oldType, &oldAmount, &generated); err != nil {
+		fmt.Println("error is while selecting transaction", err.Error())
+		return "", err
+	}
+
+	if generated {
+		return "", storage.ErrGeneratedTransaction
+	}
+
+	query := `update transactions set sale_id = nullif($1, '')::uuid, staff_id = $2, transaction_type = $3, source_type = $4, amount = $5,
 								description = $6, updated_at = now() 
                     			where id = $7`
-	if _, err := t.db.Exec(ctx, query,
+	if _, err = tx.Exec(ctx, query,
 		&transaction.SaleID,
 		&transaction.StaffID,
 		&transaction.TransactionType,
@@ -140,14 +273,178 @@ func (t transactionRepo) Update(ctx context.Context, transaction models.UpdateTr
 		fmt.Println("error is while updating transaction", err.Error())
 		return "", err
 	}
+
+	// one move for the same staff, so a bigger top up is not taken back first
+	oldDelta, newDelta := signedAmount(oldType, oldAmount), signedAmount(transaction.TransactionType, transaction.Amount)
+	if oldStaffID == transaction.StaffID {
+		err = moveBalance(ctx, tx, oldStaffID, newDelta-oldDelta)
+	} else if err = moveBalance(ctx, tx, oldStaffID, -oldDelta); err == nil {
+		err = moveBalance(ctx, tx, transaction.StaffID, newDelta)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err = reverseSource(ctx, tx, "transaction", transaction.ID, "transaction changed"); err != nil {
+		return "", err
+	}
+
+	if err = postTransaction(ctx, tx, transaction.ID, models.CreateTransaction{
+		SaleID:          transaction.SaleID,
+		StaffID:         transaction.StaffID,
+		TransactionType: transaction.TransactionType,
+		SourceType:      transaction.SourceType,
+		Amount:          transaction.Amount,
+		Description:     transaction.Description,
+	}); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing transaction", err.Error())
+		return "", err
+	}
 	return transaction.ID, nil
 }
 
 func (t transactionRepo) Delete(ctx context.Context, id string) error {
-	query := `update transactions set deleted_at = now() where id = $1`
-	if _, err := t.db.Exec(ctx, query, id); err != nil {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		fmt.Println("error is while starting transaction", err.Error())
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		staffID, transactionType string
+		amount                   float64
+		generated                bool
+	)
+	if err = tx.QueryRow(ctx, `select staff_id, transaction_type, amount, `+generatedTransaction+` from transactions t 
+                    			where id = $1 and deleted_at is null for update`, id).Scan(&staffID, &transactionType, &amount, &generated); err != nil {
+		fmt.Println("error is while selecting transaction", err.Error())
+		return err
+	}
+
+	if generated {
+		return storage.ErrGeneratedTransaction
+	}
+
+	if _, err = tx.Exec(ctx, `update transactions set deleted_at = now() where id = $1`, id); err != nil {
 		fmt.Println("error is while deleting", err.Error())
 		return err
 	}
+
+	if err = moveBalance(ctx, tx, staffID, -signedAmount(transactionType, amount)); err != nil {
+		return err
+	}
+
+	if err = reverseSource(ctx, tx, "transaction", id, "transaction deleted"); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		fmt.Println("error is while committing transaction", err.Error())
+		return err
+	}
 	return nil
 }
+
+// Statement returns the balance of the staff before From, the transactions from From up to To
+// and the balance after them. A zero From starts from the first transaction, a zero To ends now.
+func (t transactionRepo) Statement(ctx context.Context, request models.StaffStatementRequest) (models.StaffStatement, error) {
+	statement := models.StaffStatement{
+		StaffID:      request.StaffID,
+		Transactions: []models.Transaction{},
+	}
+
+	to := request.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	if err := t.db.QueryRow(ctx, `select coalesce(sum(case when transaction_type = 'topup' then amount else -amount end), 0) 
+						from transactions where deleted_at is null and staff_id = $1 and created_at < $2`,
+		request.StaffID, request.From).Scan(&statement.OpeningBalance); err != nil {
+		fmt.Println("error is while selecting opening balance", err.Error())
+		return models.StaffStatement{}, err
+	}
+
+	rows, err := t.db.Query(ctx, `select id, coalesce(sale_id::text, ''), staff_id, transaction_type, source_type, amount,
+       						coalesce(description, ''), created_at, updated_at from transactions 
+       						where deleted_at is null and staff_id = $1 and created_at >= $2 and created_at < $3 
+       						order by created_at, id`, request.StaffID, request.From, to)
+	if err != nil {
+		fmt.Println("error is while selecting statement transactions", err.Error())
+		return models.StaffStatement{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		trans := models.Transaction{}
+		if err = rows.Scan(
+			&trans.ID,
+			&trans.SaleID,
+			&trans.StaffID,
+			&trans.TransactionType,
+			&trans.SourceType,
+			&trans.Amount,
+			&trans.Description,
+			&trans.CreatedAt,
+			&trans.UpdatedAt); err != nil {
+			fmt.Println("error is while scanning rows", err.Error())
+			return models.StaffStatement{}, err
+		}
+
+		if trans.TransactionType == "withdraw" {
+			statement.Withdraws += trans.Amount
+		} else {
+			statement.Topups += trans.Amount
+		}
+		statement.Transactions = append(statement.Transactions, trans)
+	}
+
+	statement.ClosingBalance = statement.OpeningBalance + statement.Topups - statement.Withdraws
+
+	return statement, rows.Err()
+}
+
+// BalanceMismatches returns every staff whose stored balance differs from the sum of the ledger.
+func (t transactionRepo) BalanceMismatches(ctx context.Context) ([]models.BalanceMismatch, error) {
+	mismatches := []models.BalanceMismatch{}
+
+	rows, err := t.db.Query(ctx, `select s.id, s.balance, `+ledgerBalance+` as ledger from staffs s 
+						where s.balance <> `+ledgerBalance+` order by s.id`)
+	if err != nil {
+		fmt.Println("error is while selecting balance mismatches", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		mismatch := models.BalanceMismatch{}
+		if err = rows.Scan(&mismatch.StaffID, &mismatch.Stored, &mismatch.Ledger); err != nil {
+			fmt.Println("error is while scanning balance mismatch", err.Error())
+			return nil, err
+		}
+		mismatch.Difference = mismatch.Stored - mismatch.Ledger
+		mismatches = append(mismatches, mismatch)
+	}
+
+	return mismatches, rows.Err()
+}
+
+// FixBalances sets every stored balance that differs from the ledger back to the ledger sum.
+func (t transactionRepo) FixBalances(ctx context.Context) (int64, error) {
+	tag, err := t.db.Exec(ctx, `update staffs s set balance = `+ledgerBalance+`, updated_at = now() 
+						where s.balance <> `+ledgerBalance)
+	if err != nil {
+		fmt.Println("error is while fixing balances", err.Error())
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ledgerBalance is the sum of the ledger of the staff s.
+const ledgerBalance = `coalesce((select sum(case when t.transaction_type = 'topup' then t.amount else -t.amount end) 
+						from transactions t where t.staff_id = s.id and t.deleted_at is null), 0)`
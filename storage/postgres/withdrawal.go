@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type withdrawalRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewWithdrawalRepo(DB *pgxpool.Pool) storage.IWithdrawalRepo {
+	return &withdrawalRepo{
+		DB: DB,
+	}
+}
+
+func (w *withdrawalRepo) Create(ctx context.Context, request models.CreateWithdrawalRequest) (string, error) {
+	id := uuid.New().String()
+
+	tx, err := w.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `insert into withdrawal_requests (id, staff_id, amount, note) 
+		values ($1, $2, $3, $4)`,
+		id,
+		request.StaffID,
+		request.Amount,
+		request.Note,
+	); err != nil {
+		log.Println("Error while inserting withdrawal request", err)
+		return "", err
+	}
+
+	var balance float64
+	if err := tx.QueryRow(ctx, `select balance from staffs where id = $1`, request.StaffID).Scan(&balance); err != nil {
+		log.Println("Error while selecting staff balance", err)
+		return "", err
+	}
+
+	if err := addWithdrawalEvent(ctx, tx, id, request.StaffID, "requested", request.Note, balance); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Println("Error while committing withdrawal request", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (w *withdrawalRepo) GetByID(ctx context.Context, key models.PrimaryKey) (models.WithdrawalRequest, error) {
+	request := models.WithdrawalRequest{}
+
+	if err := w.DB.QueryRow(ctx, `select `+withdrawalColumns+` from withdrawal_requests w where w.id = $1`, key.ID).Scan(
+		&request.ID,
+		&request.StaffID,
+		&request.Amount,
+		&request.Status,
+		&request.Note,
+		&request.DecidedBy,
+		&request.DecidedAt,
+		&request.TransactionID,
+		&request.CreatedAt,
+		&request.UpdatedAt,
+	); err != nil {
+		log.Println("Error while selecting withdrawal request", err)
+		return models.WithdrawalRequest{}, err
+	}
+
+	rows, err := w.DB.Query(ctx, `select id, actor_id, action, coalesce(note, ''), balance, created_at 
+		from withdrawal_request_events where withdrawal_request_id = $1 order by created_at`, key.ID)
+	if err != nil {
+		log.Println("Error while selecting withdrawal request events", err)
+		return models.WithdrawalRequest{}, err
+	}
+	defer rows.Close()
+
+	request.Events = []models.WithdrawalEvent{}
+	for rows.Next() {
+		event := models.WithdrawalEvent{}
+		if err := rows.Scan(
+			&event.ID,
+			&event.ActorID,
+			&event.Action,
+			&event.Note,
+			&event.Balance,
+			&event.CreatedAt,
+		); err != nil {
+			log.Println("Error while scanning withdrawal request event", err)
+			return models.WithdrawalRequest{}, err
+		}
+		request.Events = append(request.Events, event)
+	}
+
+	return request, rows.Err()
+}
+
+func (w *withdrawalRepo) GetList(ctx context.Context, request models.WithdrawalRequestGetListRequest) (models.WithdrawalRequestsResponse, error) {
+	var (
+		requests = []models.WithdrawalRequest{}
+		count    int
+		filter   = ` where true`
+		args     = []interface{}{}
+	)
+
+	if request.StaffID != "" {
+		args = append(args, request.StaffID)
+		filter += fmt.Sprintf(` and w.staff_id = $%d`, len(args))
+	}
+
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and w.staff_id in (select id from staffs where branch_id = $%d)`, len(args))
+	}
+
+	if request.Status != "" {
+		args = append(args, request.Status)
+		filter += fmt.Sprintf(` and w.status = $%d`, len(args))
+	}
+
+	if err := w.DB.QueryRow(ctx, `select count(*) from withdrawal_requests w`+filter, args...).Scan(&count); err != nil {
+		log.Println("Error while counting withdrawal requests", err)
+		return models.WithdrawalRequestsResponse{}, err
+	}
+
+	args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	rows, err := w.DB.Query(ctx, `select `+withdrawalColumns+` from withdrawal_requests w`+filter+
+		fmt.Sprintf(` order by w.created_at desc limit $%d offset $%d`, len(args)-1, len(args)), args...)
+	if err != nil {
+		log.Println("Error while selecting withdrawal requests", err)
+		return models.WithdrawalRequestsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		request := models.WithdrawalRequest{}
+		if err := rows.Scan(
+			&request.ID,
+			&request.StaffID,
+			&request.Amount,
+			&request.Status,
+			&request.Note,
+			&request.DecidedBy,
+			&request.DecidedAt,
+			&request.TransactionID,
+			&request.CreatedAt,
+			&request.UpdatedAt,
+		); err != nil {
+			log.Println("Error while scanning withdrawal request", err)
+			return models.WithdrawalRequestsResponse{}, err
+		}
+		requests = append(requests, request)
+	}
+
+	return models.WithdrawalRequestsResponse{
+		WithdrawalRequests: requests,
+		Count:              count,
+	}, rows.Err()
+}
+
+// Approve writes the withdraw transaction, which takes the amount off the staff balance.
+// Nothing is changed and storage.ErrNotEnoughBalance is returned when the balance is smaller.
+func (w *withdrawalRepo) Approve(ctx context.Context, decision models.WithdrawalDecision) error {
+	tx, err := w.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		staffID, status string
+		amount          int
+	)
+	if err := tx.QueryRow(ctx, `select staff_id, amount, status from withdrawal_requests 
+		where id = $1 for update`, decision.ID).Scan(&staffID, &amount, &status); err != nil {
+		log.Println("Error while selecting withdrawal request", err)
+		return err
+	}
+
+	if status != "pending" {
+		return errors.New("withdrawal request is already " + status)
+	}
+
+	transactionID, err := insertTransaction(ctx, tx, models.CreateTransaction{
+		StaffID:         staffID,
+		TransactionType: "withdraw",
+		SourceType:      "withdrawal",
+		Amount:          float64(amount),
+		Description:     "withdrawal request " + decision.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var balance float64
+	if err := tx.QueryRow(ctx, `select balance from staffs where id = $1`, staffID).Scan(&balance); err != nil {
+		log.Println("Error while selecting staff balance", err)
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `update withdrawal_requests set status = 'approved', decided_by = $2, decided_at = now(), 
+		transaction_id = $3, updated_at = now() where id = $1`, decision.ID, decision.ActorID, transactionID); err != nil {
+		log.Println("Error while approving withdrawal request", err)
+		return err
+	}
+
+	if err := addWithdrawalEvent(ctx, tx, decision.ID, decision.ActorID, "approved", decision.Note, balance); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (w *withdrawalRepo) Reject(ctx context.Context, decision models.WithdrawalDecision) error {
+	return w.close(ctx, decision, "rejected")
+}
+
+func (w *withdrawalRepo) Cancel(ctx context.Context, decision models.WithdrawalDecision) error {
+	return w.close(ctx, decision, "cancelled")
+}
+
+// close ends a pending request without a pay out, the status doubles as the audit action.
+func (w *withdrawalRepo) close(ctx context.Context, decision models.WithdrawalDecision, status string) error {
+	tx, err := w.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var balance float64
+	if err := tx.QueryRow(ctx, `update withdrawal_requests w set status = $2, decided_by = $3, decided_at = now(), 
+		updated_at = now() from staffs s where w.id = $1 and w.status = 'pending' and s.id = w.staff_id 
+		returning s.balance`, decision.ID, status, decision.ActorID).Scan(&balance); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("withdrawal request is not pending")
+		}
+		log.Println("Error while closing withdrawal request", err)
+		return err
+	}
+
+	if err := addWithdrawalEvent(ctx, tx, decision.ID, decision.ActorID, status, decision.Note, balance); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func addWithdrawalEvent(ctx context.Context, tx pgx.Tx, requestID, actorID, action, note string, balance float64) error {
+	if _, err := tx.Exec(ctx, `insert into withdrawal_request_events 
+		(id, withdrawal_request_id, actor_id, action, note, balance) values ($1, $2, $3, $4, $5, $6)`,
+		uuid.New(),
+		requestID,
+		actorID,
+		action,
+		note,
+		balance,
+	); err != nil {
+		log.Println("Error while inserting withdrawal request event", err)
+		return err
+	}
+
+	return nil
+}
+
+const withdrawalColumns = `w.id, w.staff_id, w.amount, w.status, coalesce(w.note, ''), coalesce(w.decided_by::text, ''), 
+	w.decided_at, coalesce(w.transaction_id::text, ''), w.created_at, w.updated_at`
@@ -0,0 +1,326 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sell/api/models"
+	"sell/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type writeOffRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewWriteOffRepo(DB *pgxpool.Pool) storage.IWriteOffRepo {
+	return &writeOffRepo{
+		DB: DB,
+	}
+}
+
+func (s *writeOffRepo) Create(ctx context.Context, writeOff models.CreateWriteOff) (string, error) {
+	id := uuid.New().String()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction:", err)
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `INSERT INTO write_offs 
+		(id, branch_id, staff_id, reason, note)
+			VALUES($1, $2, $3, $4, $5)`,
+		id,
+		writeOff.BranchID,
+		writeOff.StaffID,
+		writeOff.Reason,
+		writeOff.Note,
+	); err != nil {
+		log.Println("Error while inserting data:", err)
+		return "", err
+	}
+
+	for _, item := range writeOff.Items {
+		if _, err = tx.Exec(ctx, `INSERT INTO write_off_items (id, write_off_id, product_id, variant_id, quantity) 
+				VALUES ($1, $2, $3, nullif($4, '')::uuid, $5)`,
+			uuid.New(),
+			id,
+			item.ProductID,
+			item.VariantID,
+			item.Quantity,
+		); err != nil {
+			log.Println("Error while inserting write off item:", err)
+			return "", err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing write off:", err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *writeOffRepo) GetByID(ctx context.Context, id models.PrimaryKey) (models.WriteOff, error) {
+	writeOff := models.WriteOff{}
+	query := `SELECT id, branch_id, staff_id, reason, status, coalesce(note, ''), coalesce(approved_by::text, ''), 
+       				approved_at, created_at, updated_at
+				FROM write_offs WHERE id = $1 and deleted_at is null`
+
+	if err := s.DB.QueryRow(ctx, query, id.ID).Scan(
+		&writeOff.ID,
+		&writeOff.BranchID,
+		&writeOff.StaffID,
+		&writeOff.Reason,
+		&writeOff.Status,
+		&writeOff.Note,
+		&writeOff.ApprovedBy,
+		&writeOff.ApprovedAt,
+		&writeOff.CreatedAt,
+		&writeOff.UpdatedAt,
+	); err != nil {
+		log.Println("Error while selecting write off by ID:", err)
+		return models.WriteOff{}, err
+	}
+
+	items, err := s.getItems(ctx, writeOff.ID)
+	if err != nil {
+		return models.WriteOff{}, err
+	}
+	writeOff.Items = items
+
+	return writeOff, nil
+}
+
+func (s *writeOffRepo) GetList(ctx context.Context, request models.WriteOffGetListRequest) (models.WriteOffsResponse, error) {
+	var (
+		writeOffs = []models.WriteOff{}
+		count     int
+		filter    string
+		args      = []interface{}{}
+	)
+
+	if request.BranchID != "" {
+		args = append(args, request.BranchID)
+		filter += fmt.Sprintf(` and branch_id = $%d`, len(args))
+	}
+
+	if request.Status != "" {
+		args = append(args, request.Status)
+		filter += fmt.Sprintf(` and status = $%d`, len(args))
+	}
+
+	if err := s.DB.QueryRow(ctx, `SELECT COUNT(*) FROM write_offs where deleted_at is null`+filter, args...).Scan(&count); err != nil {
+		log.Println("Error while scanning count of write offs:", err)
+		return models.WriteOffsResponse{}, err
+	}
+
+	args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	query := `SELECT id, branch_id, staff_id, reason, status, coalesce(note, ''), coalesce(approved_by::text, ''), 
+       				approved_at, created_at, updated_at
+						FROM write_offs where deleted_at is null` + filter +
+		fmt.Sprintf(` order by created_at desc LIMIT $%d OFFSET $%d `, len(args)-1, len(args))
+
+	rows, err := s.DB.Query(ctx, query, args...)
+	if err != nil {
+		log.Println("Error while querying write offs:", err)
+		return models.WriteOffsResponse{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		writeOff := models.WriteOff{}
+		if err := rows.Scan(
+			&writeOff.ID,
+			&writeOff.BranchID,
+			&writeOff.StaffID,
+			&writeOff.Reason,
+			&writeOff.Status,
+			&writeOff.Note,
+			&writeOff.ApprovedBy,
+			&writeOff.ApprovedAt,
+			&writeOff.CreatedAt,
+			&writeOff.UpdatedAt,
+		); err != nil {
+			log.Println("Error while scanning row of write offs:", err)
+			return models.WriteOffsResponse{}, err
+		}
+		writeOffs = append(writeOffs, writeOff)
+	}
+	rows.Close()
+
+	for i := range writeOffs {
+		if writeOffs[i].Items, err = s.getItems(ctx, writeOffs[i].ID); err != nil {
+			return models.WriteOffsResponse{}, err
+		}
+	}
+
+	return models.WriteOffsResponse{
+		WriteOffs: writeOffs,
+		Count:     count,
+	}, nil
+}
+
+// Approve decrements the branch stock and writes a minus repository transaction
+// with the write off reason for every item. Nothing is changed when one of the
+// items does not have enough stock.
+func (s *writeOffRepo) Approve(ctx context.Context, decision models.WriteOffDecision) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		log.Println("Error while starting transaction:", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var branchID, reason, status string
+	if err = tx.QueryRow(ctx, `SELECT branch_id, reason, status FROM write_offs 
+                                WHERE id = $1 and deleted_at is null FOR UPDATE`, decision.ID).Scan(&branchID, &reason, &status); err != nil {
+		log.Println("Error while selecting write off:", err)
+		return err
+	}
+
+	if status != "pending" {
+		return fmt.Errorf("%w: write off is %s", storage.ErrAlreadyDecided, status)
+	}
+
+	items, err := s.getItems(ctx, decision.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err := moveStock(ctx, tx, branchID, item.ProductID, item.VariantID, -item.Quantity)
+		if errors.Is(err, storage.ErrNotEnoughStock) {
+			return fmt.Errorf("%w: %s", storage.ErrNotEnoughStock, item.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(ctx, `INSERT INTO repository_transactions
+		(id, branch_id, staff_id, product_id, variant_id, repository_transaction_type, price, quantity, reason)
+			VALUES($1, $2, $3, $4, nullif($5, '')::uuid, 'minus', 
+			       coalesce((select price from product_variants where id = nullif($5, '')::uuid),
+			                (select price from products where id = $4), 0) * $6, $6, $7)`,
+			uuid.New(),
+			branchID,
+			decision.ManagerID,
+			item.ProductID,
+			item.VariantID,
+			item.Quantity,
+			reason,
+		); err != nil {
+			log.Println("Error while inserting repository transaction:", err)
+			return err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE write_offs SET status = 'approved', approved_by = $1, approved_at = NOW(), 
+                      updated_at = NOW() WHERE id = $2`, decision.ManagerID, decision.ID); err != nil {
+		log.Println("Error while approving write off:", err)
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Println("Error while committing write off approval:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *writeOffRepo) Reject(ctx context.Context, decision models.WriteOffDecision) error {
+	tag, err := s.DB.Exec(ctx, `UPDATE write_offs SET status = 'rejected', approved_by = $1, approved_at = NOW(), 
+                      updated_at = NOW() WHERE id = $2 AND status = 'pending'`, decision.ManagerID, decision.ID)
+	if err != nil {
+		log.Println("Error while rejecting write off:", err)
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: write off is not pending", storage.ErrAlreadyDecided)
+	}
+
+	return nil
+}
+
+func (s *writeOffRepo) Delete(ctx context.Context, id string) error {
+	query := `UPDATE write_offs SET deleted_at = NOW() WHERE id = $1 AND status = 'pending'`
+
+	if _, err := s.DB.Exec(ctx, query, id); err != nil {
+		log.Println("Error while deleting write off :", err)
+		return err
+	}
+
+	return nil
+}
+
+// Report sums the approved write offs of the month by branch and reason.
+func (s *writeOffRepo) Report(ctx context.Context, month time.Time) ([]models.WriteOffReportRow, error) {
+	rows := []models.WriteOffReportRow{}
+
+	query := `SELECT w.branch_id, w.reason, count(distinct w.id), sum(i.quantity), 
+       				sum(i.quantity * coalesce(v.price, p.price, 0))
+				FROM write_offs w
+						 JOIN write_off_items i ON i.write_off_id = w.id
+						 JOIN products p ON p.id = i.product_id
+						 LEFT JOIN product_variants v ON v.id = i.variant_id
+				WHERE w.status = 'approved' AND w.deleted_at is null 
+				  AND w.approved_at >= $1 AND w.approved_at < $2
+				GROUP BY w.branch_id, w.reason
+				ORDER BY w.branch_id, w.reason`
+
+	result, err := s.DB.Query(ctx, query, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		log.Println("Error while querying write off report:", err)
+		return nil, err
+	}
+	defer result.Close()
+
+	for result.Next() {
+		row := models.WriteOffReportRow{}
+		if err := result.Scan(
+			&row.BranchID,
+			&row.Reason,
+			&row.WriteOffs,
+			&row.Quantity,
+			&row.Amount,
+		); err != nil {
+			log.Println("Error while scanning row of write off report:", err)
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (s *writeOffRepo) getItems(ctx context.Context, writeOffID string) ([]models.WriteOffItem, error) {
+	items := []models.WriteOffItem{}
+
+	rows, err := s.DB.Query(ctx, `SELECT product_id, coalesce(variant_id::text, ''), quantity 
+					FROM write_off_items WHERE write_off_id = $1 order by created_at`, writeOffID)
+	if err != nil {
+		log.Println("Error while querying write off items:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.WriteOffItem{}
+		if err := rows.Scan(&item.ProductID, &item.VariantID, &item.Quantity); err != nil {
+			log.Println("Error while scanning row of write off items:", err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
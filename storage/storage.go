@@ -2,18 +2,62 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"sell/api/models"
+	"time"
 )
 
+// ErrNotEnoughBalance is returned when a transaction would take a staff balance below zero.
+var ErrNotEnoughBalance = errors.New("not enough balance")
+
+// ErrNoTariff is returned when a commission is asked for a staff without a tariff.
+var ErrNoTariff = errors.New("staff has no tariff")
+
+// ErrGeneratedTransaction is returned when a transaction a payroll run, a withdrawal request, a
+// sales target payout or a sale posted is changed, a reversing transaction corrects it instead.
+var ErrGeneratedTransaction = errors.New("transaction was generated, post a reversing transaction instead")
+
+// ErrNotEnoughStock is returned when a movement would take the stock of a product below zero.
+var ErrNotEnoughStock = errors.New("not enough product in storage")
+
+// ErrCategoryCycle is returned when a category would be moved under itself or its descendant.
+var ErrCategoryCycle = errors.New("category can not be moved under itself or its descendant")
+
+// ErrAlreadyDecided is returned when a pending request was approved or rejected before.
+var ErrAlreadyDecided = errors.New("already decided")
+
+// ErrUnbalancedEntry is returned when the debits of a journal entry do not equal its credits.
+var ErrUnbalancedEntry = errors.New("journal entry is not balanced")
+
+// ErrUnknownAccount is returned when a posting names an account code that does not exist.
+var ErrUnknownAccount = errors.New("unknown account")
+
 type IStorage interface {
 	Close()
 	StaffTariff() IStaffTariffRepo
 	Staff() IStaffRepo
+	RefreshToken() IRefreshTokenRepo
+	PasswordReset() IPasswordResetRepo
+	Attendance() IAttendanceRepo
+	Payroll() IPayrollRepo
+	Withdrawal() IWithdrawalRepo
+	SalesTarget() ISalesTargetRepo
+	Commission() ICommissionRepo
+	Ledger() ILedgerRepo
 	Repository() IRepositoryRepo
 	Basket() IBasketRepo
 	RTransaction() IRepositoryTransactionRepo
+	Stock() IStockRepo
+	WriteOff() IWriteOffRepo
 	Category() ICategory
 	Product() IProducts
+	ProductUnit() IProductUnitRepo
+	ProductVariant() IProductVariantRepo
+	ProductImage() IProductImageRepo
+	ProductBarcode() IProductBarcodeRepo
+	Bundle() IBundleRepo
+	PriceList() IPriceListRepo
+	PriceChange() IPriceChangeRepo
 	Branch() IBranchStorage
 	Sale() ISaleStorage
 	Transaction() ITransactionStorage
@@ -30,11 +74,91 @@ type IStaffTariffRepo interface {
 type IStaffRepo interface {
 	Create(context.Context, models.CreateStaff) (string, error)
 	StaffByID(context.Context, models.PrimaryKey) (models.Staff, error)
-	GetStaffTList(context.Context, models.GetListRequest) (models.StaffsResponse, error)
+	GetStaffTList(context.Context, models.StaffGetListRequest) (models.StaffsResponse, error)
 	UpdateStaff(context.Context, models.UpdateStaff) (string, error)
 	DeleteStaff(context.Context, string) error
-	GetPassword(context.Context, string) (string, error)
 	UpdatePassword(context.Context, models.UpdateStaffPassword) error
+	GetCredentials(context.Context, string) (models.StaffCredentials, error)
+	GetByLogin(context.Context, string) (models.StaffCredentials, error)
+	PasswordHistory(context.Context, string, int) ([]string, error)
+	RegisterFailedLogin(context.Context, string, int, time.Time) error
+	ResetFailedLogins(context.Context, string) error
+	EnsureOwner(context.Context, string, string) error
+	Assign(context.Context, models.CreateStaffAssignment, time.Time) error
+	Assignments(context.Context, string) ([]models.StaffAssignment, error)
+}
+
+type IRefreshTokenRepo interface {
+	Create(context.Context, models.CreateRefreshToken) (string, error)
+	GetByHash(context.Context, string) (models.RefreshToken, error)
+	Revoke(context.Context, string) error
+	RevokeByStaff(context.Context, string) error
+}
+
+type IPasswordResetRepo interface {
+	Create(context.Context, models.CreatePasswordReset) (string, error)
+	GetByHash(context.Context, string) (models.PasswordReset, error)
+	Use(context.Context, string, string) error
+}
+
+type IAttendanceRepo interface {
+	ClockIn(context.Context, string, string, time.Time) (string, error)
+	Open(context.Context, string) (models.Attendance, error)
+	ClockOut(context.Context, string, time.Time) error
+	StartBreak(context.Context, string, time.Time) error
+	EndBreak(context.Context, string, time.Time) error
+	GetByID(context.Context, models.PrimaryKey) (models.Attendance, error)
+	GetList(context.Context, models.AttendanceGetListRequest) (models.AttendancesResponse, error)
+	Update(context.Context, models.UpdateAttendance) error
+	AutoClose(context.Context, time.Time, time.Duration) (int64, error)
+	Timesheet(context.Context, string, time.Time, time.Time) (models.Timesheet, error)
+}
+
+type IPayrollRepo interface {
+	Create(context.Context, models.CreatePayrollRun) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.PayrollRun, error)
+	GetList(context.Context, models.PayrollRunGetListRequest) (models.PayrollRunsResponse, error)
+	Delete(context.Context, string) error
+	Approve(context.Context, string, string) error
+}
+
+type IWithdrawalRepo interface {
+	Create(context.Context, models.CreateWithdrawalRequest) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.WithdrawalRequest, error)
+	GetList(context.Context, models.WithdrawalRequestGetListRequest) (models.WithdrawalRequestsResponse, error)
+	Approve(context.Context, models.WithdrawalDecision) error
+	Reject(context.Context, models.WithdrawalDecision) error
+	Cancel(context.Context, models.WithdrawalDecision) error
+}
+
+type ISalesTargetRepo interface {
+	Create(context.Context, models.CreateSalesTarget) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.SalesTarget, error)
+	GetList(context.Context, models.SalesTargetGetListRequest) (models.SalesTargetsResponse, error)
+	Delete(context.Context, string) error
+	Progress(context.Context, models.SalesTargetProgressRequest) ([]models.SalesTargetProgress, error)
+	Evaluate(context.Context, time.Time) (int, error)
+}
+
+type ICommissionRepo interface {
+	Create(context.Context, models.CreateCommissionRule) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.CommissionRule, error)
+	GetList(context.Context, string) ([]models.CommissionRule, error)
+	Update(context.Context, models.UpdateCommissionRule) (string, error)
+	Delete(context.Context, string, string) error
+	Calculate(context.Context, models.CommissionRequest) (models.Commission, error)
+}
+
+type ILedgerRepo interface {
+	CreateAccount(context.Context, models.CreateAccount) (string, error)
+	GetAccountByID(context.Context, models.PrimaryKey) (models.Account, error)
+	GetAccountList(context.Context) (models.AccountsResponse, error)
+	CreateEntry(context.Context, models.CreateJournalEntry) (string, error)
+	GetEntryByID(context.Context, models.PrimaryKey) (models.JournalEntry, error)
+	GetEntryList(context.Context, models.JournalEntryGetListRequest) (models.JournalEntriesResponse, error)
+	ReverseEntry(context.Context, models.ReverseJournalEntry) (string, error)
+	TrialBalance(context.Context, models.TrialBalanceRequest) (models.TrialBalance, error)
+	AccountStatement(context.Context, models.AccountStatementRequest) (models.AccountStatement, error)
 }
 
 type IRepositoryRepo interface {
@@ -43,13 +167,15 @@ type IRepositoryRepo interface {
 	GetList(context.Context, models.GetListRequest) (models.RepositoriesResponse, error)
 	Update(context.Context, models.UpdateRepository) (string, error)
 	Delete(context.Context, string) error
-	UpdateProductQuantity(context.Context, models.UpdateRepository) (string, error)
+	MoveProductQuantity(context.Context, string, string, string, float64) (float64, error)
+	GetProductCount(context.Context, string, string, string) (float64, error)
 }
 
 type IBasketRepo interface {
 	Create(context.Context, models.CreateBasket) (string, error)
 	GetByID(context.Context, models.PrimaryKey) (models.Basket, error)
 	GetList(context.Context, models.GetListRequest) (models.BasketsResponse, error)
+	GetBySaleID(context.Context, string) ([]models.Basket, error)
 	Update(context.Context, models.UpdateBasket) (string, error)
 	Delete(context.Context, string) error
 }
@@ -62,12 +188,31 @@ type IRepositoryTransactionRepo interface {
 	Delete(context.Context, string) error
 }
 
+type IStockRepo interface {
+	Mismatches(context.Context, string) (models.StockReconcileResponse, error)
+	Reconcile(context.Context, models.StockReconcileRequest) (models.StockReconcileResponse, error)
+}
+
+type IWriteOffRepo interface {
+	Create(context.Context, models.CreateWriteOff) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.WriteOff, error)
+	GetList(context.Context, models.WriteOffGetListRequest) (models.WriteOffsResponse, error)
+	Approve(context.Context, models.WriteOffDecision) error
+	Reject(context.Context, models.WriteOffDecision) error
+	Delete(context.Context, string) error
+	Report(context.Context, time.Time) ([]models.WriteOffReportRow, error)
+}
+
 type ICategory interface {
 	Create(context.Context, models.CreateCategory) (string, error)
 	GetByID(context.Context, string) (models.Category, error)
 	GetList(context.Context, models.GetListRequest) (models.CategoryResponse, error)
 	Update(context.Context, models.UpdateCategory) (string, error)
 	Delete(context.Context, string) error
+	GetTree(context.Context, string) ([]*models.CategoryNode, error)
+	GetBreadcrumbs(context.Context, string) ([]models.Category, error)
+	Move(context.Context, models.MoveCategory) (string, error)
+	GetUsage(context.Context, string) (models.CategoryUsage, error)
 }
 
 type IProducts interface {
@@ -76,6 +221,76 @@ type IProducts interface {
 	GetList(context.Context, models.ProductGetListRequest) (models.ProductResponse, error)
 	Update(context.Context, models.UpdateProduct) (string, error)
 	Delete(context.Context, string) error
+	GetByPLU(context.Context, string) (models.Product, error)
+	Import(context.Context, []models.ProductImportRow, bool) (models.ProductImportReport, error)
+	Export(context.Context) ([]models.ProductImportRow, error)
+	Search(context.Context, models.ProductSearchRequest) (models.ProductSearchResponse, error)
+	Suggest(context.Context, string, int) ([]models.ProductSuggestion, error)
+}
+
+type IProductUnitRepo interface {
+	Create(context.Context, models.CreateProductUnit) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.ProductUnit, error)
+	GetList(context.Context, models.GetListRequest) (models.ProductUnitsResponse, error)
+	Update(context.Context, models.UpdateProductUnit) (string, error)
+	Delete(context.Context, string) error
+}
+
+type IProductVariantRepo interface {
+	Create(context.Context, models.CreateProductVariant) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.ProductVariant, error)
+	GetList(context.Context, models.GetListRequest) (models.ProductVariantsResponse, error)
+	Update(context.Context, models.UpdateProductVariant) (string, error)
+	Delete(context.Context, string) error
+}
+
+type IProductBarcodeRepo interface {
+	Create(context.Context, models.CreateProductBarcode) (string, error)
+	GetByID(context.Context, string) (models.ProductBarcode, error)
+	GetByCode(context.Context, string) (models.ProductBarcode, error)
+	GetList(context.Context, string) ([]models.ProductBarcode, error)
+	Delete(context.Context, string) error
+}
+
+type IProductImageRepo interface {
+	Create(context.Context, models.CreateProductImage) (string, error)
+	GetByID(context.Context, string) (models.ProductImage, error)
+	GetList(context.Context, string) ([]models.ProductImage, error)
+	Reorder(context.Context, models.ReorderProductImages) error
+	Delete(context.Context, string) error
+}
+
+type IBundleRepo interface {
+	Create(context.Context, models.CreateBundle) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.Bundle, error)
+	GetByProductID(context.Context, string) (models.Bundle, error)
+	GetList(context.Context, models.GetListRequest) (models.BundlesResponse, error)
+	Update(context.Context, models.UpdateBundle) (string, error)
+	Delete(context.Context, string) error
+}
+
+type IPriceListRepo interface {
+	Create(context.Context, models.CreatePriceList) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.PriceList, error)
+	GetList(context.Context, models.GetListRequest) (models.PriceListsResponse, error)
+	Update(context.Context, models.UpdatePriceList) (string, error)
+	Delete(context.Context, models.PrimaryKey) error
+	SetItem(context.Context, models.SetPriceListItem) error
+	RemoveItem(context.Context, string, string) error
+	History(context.Context, models.PriceListHistoryRequest) (models.PriceListHistoryResponse, error)
+	Assign(context.Context, models.AssignPriceList) error
+	EffectivePrices(context.Context, string, []string) (map[string]int, error)
+}
+
+type IPriceChangeRepo interface {
+	Create(context.Context, models.CreatePriceChange) (string, error)
+	GetByID(context.Context, models.PrimaryKey) (models.PriceChange, error)
+	GetList(context.Context, models.GetListRequest) (models.PriceChangesResponse, error)
+	Cancel(context.Context, models.PrimaryKey) error
+	Preview(context.Context, models.CreatePriceChange) (models.PriceChangePreview, error)
+	Due(context.Context, time.Time) ([]string, error)
+	Apply(context.Context, models.PrimaryKey) (int, error)
+	History(context.Context, models.GetListRequest) (models.ProductPriceHistoryResponse, error)
 }
 
 type IBranchStorage interface {
@@ -92,7 +307,8 @@ type ISaleStorage interface {
 	GetList(context.Context, models.GetListRequest) (models.SaleResponse, error)
 	Update(context.Context, models.UpdateSale) (string, error)
 	Delete(context.Context, string) error
-	UpdatePrice(context.Context, int, string) (string, error)
+	End(context.Context, models.EndSale) error
+	Refund(context.Context, models.RefundSale) error
 }
 
 type ITransactionStorage interface {
@@ -101,4 +317,7 @@ type ITransactionStorage interface {
 	GetList(context.Context, models.TransactionGetListRequest) (models.TransactionResponse, error)
 	Update(context.Context, models.UpdateTransaction) (string, error)
 	Delete(context.Context, string) error
+	Statement(context.Context, models.StaffStatementRequest) (models.StaffStatement, error)
+	BalanceMismatches(context.Context) ([]models.BalanceMismatch, error)
+	FixBalances(context.Context) (int64, error)
 }
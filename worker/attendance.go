@@ -0,0 +1,26 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// CloseForgottenAttendances closes the shifts that stayed open longer than AttendanceAutoClose.
+// Their clock out is set AttendanceAutoClose after the clock in and a manager can correct it.
+func (w Worker) CloseForgottenAttendances(ctx context.Context) error {
+	if w.cfg.AttendanceAutoClose <= 0 {
+		return nil
+	}
+
+	count, err := w.storage.Attendance().AutoClose(ctx, time.Now().Add(-w.cfg.AttendanceAutoClose), w.cfg.AttendanceAutoClose)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Printf("%d forgotten attendances auto closed", count)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"log"
+)
+
+// CheckBalances logs every staff whose stored balance differs from the transactions ledger
+// and, when enabled, sets the balance back to the ledger sum.
+func (w Worker) CheckBalances(ctx context.Context) error {
+	mismatches, err := w.storage.Transaction().BalanceMismatches(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, mismatch := range mismatches {
+		log.Printf("balance mismatch: staff %s stored %v ledger %v", mismatch.StaffID, mismatch.Stored, mismatch.Ledger)
+	}
+
+	if w.cfg.BalanceCheckFix && len(mismatches) > 0 {
+		count, err := w.storage.Transaction().FixBalances(ctx)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("%d balances set back to the ledger", count)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sell/api/models"
+	"time"
+)
+
+// ApplyPriceChanges applies every scheduled price change whose effective time has come.
+func (w Worker) ApplyPriceChanges(ctx context.Context) error {
+	ids, err := w.storage.PriceChange().Due(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		count, err := w.storage.PriceChange().Apply(ctx, models.PrimaryKey{ID: id})
+		if err != nil {
+			log.Printf("error while applying price change %s: %v", id, err)
+			continue
+		}
+
+		log.Printf("price change %s applied to %d products", id, count)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PaySalesTargetBonuses pays the bonuses of the tiers reached last month once it is closed,
+// running all month long so a missed run is caught up.
+func (w Worker) PaySalesTargetBonuses(ctx context.Context) error {
+	now := time.Now()
+
+	count, err := w.storage.SalesTarget().Evaluate(ctx, time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location()))
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Printf("%d sales target bonuses paid", count)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sell/api/models"
+)
+
+// ReconcileStock logs every product whose repositories count differs from the
+// repository transactions ledger and, when enabled, posts corrective transactions.
+func (w Worker) ReconcileStock(ctx context.Context) error {
+	var (
+		response models.StockReconcileResponse
+		err      error
+	)
+
+	if w.cfg.StockReconcileFix {
+		response, err = w.storage.Stock().Reconcile(ctx, models.StockReconcileRequest{})
+	} else {
+		response, err = w.storage.Stock().Mismatches(ctx, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, mismatch := range response.Mismatches {
+		log.Printf("stock mismatch: branch %s product %s variant %q expected %v actual %v",
+			mismatch.BranchID, mismatch.ProductID, mismatch.VariantID, mismatch.Expected, mismatch.Actual)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sell/config"
+	"sell/storage"
+	"time"
+)
+
+// Worker runs the background jobs of the service.
+type Worker struct {
+	cfg     config.Config
+	storage storage.IStorage
+}
+
+func New(cfg config.Config, store storage.IStorage) Worker {
+	return Worker{cfg: cfg, storage: store}
+}
+
+// Run starts every job in its own goroutine. The jobs stop when ctx is done.
+func (w Worker) Run(ctx context.Context) {
+	go w.every(ctx, "stock reconcile", w.cfg.StockReconcileInterval, w.ReconcileStock)
+	go w.every(ctx, "price changes", w.cfg.PriceChangeInterval, w.ApplyPriceChanges)
+	go w.every(ctx, "attendance auto close", w.cfg.AttendanceInterval, w.CloseForgottenAttendances)
+	go w.every(ctx, "sales targets", w.cfg.SalesTargetInterval, w.PaySalesTargetBonuses)
+	go w.every(ctx, "balance check", w.cfg.BalanceCheckInterval, w.CheckBalances)
+}
+
+func (w Worker) every(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	if interval <= 0 {
+		log.Printf("job %s is disabled", name)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				log.Printf("error while running job %s: %v", name, err)
+			}
+		}
+	}
+}